@@ -0,0 +1,9 @@
+// Code generated by cmd/sample/gen/digests.go; DO NOT EDIT.
+
+package main
+
+// Digests of the embedded content, checked by "sample version --verify"
+var (
+	schemasDigest = "sha256:89bffeac4780ce9905a605f15e528507b32731bd4be13ccc20b7430c2129f79b"
+	docsDigest    = "sha256:17186ea830436f12730c665e85b39d28abaaf601bbb9ec98f279f629e454143f"
+)
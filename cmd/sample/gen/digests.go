@@ -0,0 +1,54 @@
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// versionOutput is the part of "sample version --output json" with the embedded content digests
+type versionOutput struct {
+	Embedded []struct {
+		Name   string `json:"name"`
+		Digest string `json:"digest"`
+	} `json:"embedded"`
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("Must specify a target directory for the digests.")
+	}
+
+	// Compute the digests of the embedded content with the version command
+	out, err := exec.Command("go", "run", "./cmd/sample", "version", "--output", "json").Output()
+	if err != nil {
+		log.Fatal(fmt.Errorf("computing digests: %w", err))
+	}
+	var info versionOutput
+	if err := json.Unmarshal(out, &info); err != nil {
+		log.Fatal(fmt.Errorf("parsing version output: %w", err))
+	}
+
+	src := &bytes.Buffer{}
+	src.WriteString("// Code generated by cmd/sample/gen/digests.go; DO NOT EDIT.\n\npackage main\n\n")
+	src.WriteString("// Digests of the embedded content, checked by \"sample version --verify\"\nvar (\n")
+	for _, e := range info.Embedded {
+		fmt.Fprintf(src, "%sDigest = %q\n", e.Name, e.Digest)
+	}
+	src.WriteString(")\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("formatting digests: %w", err))
+	}
+	if err := os.WriteFile(filepath.Join(os.Args[1], "digests.go"), formatted, 0o644); err != nil {
+		log.Fatal(fmt.Errorf("writing digests: %w", err))
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/act3-ai/go-common/pkg/config"
 	"github.com/act3-ai/go-common/pkg/embedutil"
 	"github.com/act3-ai/go-common/pkg/otel"
+	"github.com/act3-ai/go-common/pkg/runner"
 	vv "github.com/act3-ai/go-common/pkg/version"
 )
 
@@ -39,27 +40,37 @@ func getVersionInfo() vv.Info {
 
 const verbosityEnvName = "ACE_SAMPLE_VERBOSITY"
 
-func mainE(args []string) error {
-	ctx := context.Background()
-
+// newRoot creates the root command with all subcommands
+func newRoot() (*cobra.Command, error) {
 	info := getVersionInfo()        // Load the version info from the build
 	root := newSample(info.Version) // Create the root command
 
 	schemaAssociations := []commands.SchemaAssociation{
 		{
-			Definition: "configuration-schema.json",
+			Definition: "schemas/configuration-schema.json",
 			FileMatch:  config.DefaultConfigValidatePath("ace", "sample", "config.yaml"),
 		},
 	}
 
+	// Digests of the embedded content are reported by the version command
+	embedded := []commands.EmbeddedContent{
+		{Name: "schemas", FS: schemas, Digest: schemasDigest},
+		{Name: "docs", FS: docs, Digest: docsDigest},
+	}
+
 	docs := &embedutil.Documentation{
 		Title:   "Sample command showing the use of go-common's utilities for CLI development",
 		Command: root,
+		Version: info,
 		Categories: []*embedutil.Category{
 			embedutil.NewCategory(
 				"docs", "General Documentation", root.Name(), 7,
 				embedutil.LoadMarkdown("quick-start-guide", "Example Quick Start Guide", "docs/quick-start-guide.md", docs),
 			),
+			embedutil.NewSchemaCategory(
+				"schemas", "Configuration Reference", root.Name(),
+				schemas, "schemas/configuration-schema.json",
+			),
 		},
 	}
 
@@ -68,12 +79,20 @@ func mainE(args []string) error {
 			ID:    "utils",
 			Title: "Utility commands",
 		},
-		commands.NewVersionCmd(info),
+		commands.NewVersionCmd(info, embedded...),
 		commands.NewInfoCmd(docs),
 		commands.NewGendocsCmd(docs),
+		commands.NewInstallCompletionCmd(root),
 		commands.NewGenschemaCmd(schemas, schemaAssociations),
+		commands.NewValidateCmd(schemas, schemaAssociations),
 	)
 
+	return root, nil
+}
+
+// newOtelConfig creates the OpenTelemetry configuration
+func newOtelConfig(ctx context.Context) *otel.Config {
+	info := getVersionInfo()
 	r, _ := resource.New(
 		ctx,
 		resource.WithAttributes(
@@ -85,18 +104,16 @@ func mainE(args []string) error {
 		resource.WithOS(),
 	)
 
-	otelCfg := &otel.Config{
+	return &otel.Config{
 		Resource: r,
 	}
-
-	root.SetArgs(args)
-
-	// Run root command with OTel instrumentation enabled.
-	return otel.Run(ctx, root, otelCfg, verbosityEnvName)
 }
 
 func main() {
-	if err := mainE(os.Args[1:]); err != nil {
-		os.Exit(1)
-	}
+	ctx := context.Background()
+	otelCfg := newOtelConfig(ctx)
+	os.Exit(runner.Main(ctx, newRoot, runner.WithExecutor(func(ctx context.Context, root *cobra.Command) error {
+		// Run root command with OTel instrumentation enabled.
+		return otel.Run(ctx, root, otelCfg, verbosityEnvName)
+	})))
 }
@@ -1,22 +1,39 @@
 package main
 
 import (
+	"io"
 	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/runner"
 )
 
-func Test_mainE(t *testing.T) {
+func Test_main(t *testing.T) {
 	tests := []struct {
-		name    string
-		args    []string
-		wantErr bool
+		name     string
+		args     []string
+		wantCode int
 	}{
-		{"help command", []string{"--help"}, false},
-		{"version command", []string{"version"}, false},
+		{"help command", []string{"--help"}, 0},
+		{"version command", []string{"version"}, 0},
+		{"verify embedded content", []string{"version", "--verify"}, 0},
+		{"unknown command", []string{"unknown"}, 1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := mainE(tt.args); (err != nil) != tt.wantErr {
-				t.Errorf("mainE() error = %v, wantErr %v", err, tt.wantErr)
+			newRootWithArgs := func() (*cobra.Command, error) {
+				root, err := newRoot()
+				if err != nil {
+					return nil, err
+				}
+				root.SetArgs(tt.args)
+				root.SetOut(io.Discard)
+				root.SetErr(io.Discard)
+				return root, nil
+			}
+			if code := runner.Main(t.Context(), newRootWithArgs); code != tt.wantCode {
+				t.Errorf("runner.Main() exit code = %d, want %d", code, tt.wantCode)
 			}
 		})
 	}
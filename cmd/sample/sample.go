@@ -12,6 +12,7 @@ import (
 	"github.com/act3-ai/go-common/pkg/embedutil"
 	"github.com/act3-ai/go-common/pkg/options"
 	"github.com/act3-ai/go-common/pkg/options/cobrautil"
+	"github.com/act3-ai/go-common/pkg/options/cobrautil/formats"
 	"github.com/act3-ai/go-common/pkg/options/flagutil"
 	"github.com/act3-ai/go-common/pkg/options/optionshelp"
 	"github.com/act3-ai/go-common/pkg/termdoc"
@@ -93,6 +94,8 @@ func newSample(version string) *cobra.Command {
 				}
 				return typeName
 			},
+			// Display values set by environment variables in place of defaults.
+			ShowEnvValues: true,
 			// If there is a configured environment variable for the flag,
 			// add environment variable name to the usage string
 			FormatUsage: func(flag *pflag.Flag, usage string) string {
@@ -109,6 +112,10 @@ func newSample(version string) *cobra.Command {
 		},
 	}
 
+	// Render help as markdown when output is piped.
+	markdownOptions := formats.Markdown()
+	formatOptions.NonTerminal = &markdownOptions
+
 	// Set custom usage function to format command
 	// help text using our special formatting.
 	cobrautil.WithCustomUsage(root, formatOptions)
@@ -6,3 +6,7 @@ package gen
 
 // Generate CLI documentation with gendocs command
 //go:generate go run ./cmd/sample gendocs md cmd/sample/docs/cli --only-commands
+
+// Record the digests of the embedded schemas and docs in cmd/sample/digests.go,
+// so "sample version --verify" detects content changed since generation
+//go:generate go run cmd/sample/gen/digests.go cmd/sample
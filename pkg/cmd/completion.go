@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/cobra"
+)
+
+// completionShell defines how completion scripts are generated and installed for a shell
+type completionShell struct {
+	name     string                                                  // Name of the shell
+	fileName func(name string) string                                // Completion script file name for the tool
+	dir      func() string                                           // Directory the completion script is installed to
+	generate func(root *cobra.Command, w io.Writer, desc bool) error // Generates the completion script
+	note     func(dir, path string) string                           // Instructions printed after installation
+}
+
+// completionShells lists the supported shells
+var completionShells = []completionShell{
+	{
+		name:     "bash",
+		fileName: func(name string) string { return name },
+		dir:      func() string { return filepath.Join(xdg.DataHome, "bash-completion", "completions") },
+		generate: func(root *cobra.Command, w io.Writer, desc bool) error { return root.GenBashCompletionV2(w, desc) },
+	},
+	{
+		name:     "zsh",
+		fileName: func(name string) string { return "_" + name },
+		dir:      func() string { return filepath.Join(xdg.DataHome, "zsh", "site-functions") },
+		generate: func(root *cobra.Command, w io.Writer, desc bool) error {
+			if desc {
+				return root.GenZshCompletion(w)
+			}
+			return root.GenZshCompletionNoDesc(w)
+		},
+		note: func(dir, _ string) string {
+			return "Add the directory to your fpath in ~/.zshrc before compinit is called:\n  fpath=(" + dir + " $fpath)"
+		},
+	},
+	{
+		name:     "fish",
+		fileName: func(name string) string { return name + ".fish" },
+		dir:      func() string { return filepath.Join(xdg.ConfigHome, "fish", "completions") },
+		generate: func(root *cobra.Command, w io.Writer, desc bool) error { return root.GenFishCompletion(w, desc) },
+	},
+	{
+		name:     "powershell",
+		fileName: func(name string) string { return name + ".ps1" },
+		dir:      func() string { return filepath.Join(xdg.DataHome, "powershell", "completions") },
+		generate: func(root *cobra.Command, w io.Writer, desc bool) error {
+			if desc {
+				return root.GenPowerShellCompletionWithDesc(w)
+			}
+			return root.GenPowerShellCompletion(w)
+		},
+		note: func(_, path string) string {
+			return "Source the script from your PowerShell profile:\n  . " + path
+		},
+	},
+}
+
+// completionShellNames lists the names of the supported shells
+func completionShellNames() []string {
+	names := make([]string, 0, len(completionShells))
+	for _, sh := range completionShells {
+		names = append(names, sh.name)
+	}
+	return names
+}
+
+// findCompletionShell finds a supported shell by name
+func findCompletionShell(name string) (completionShell, error) {
+	i := slices.IndexFunc(completionShells, func(sh completionShell) bool { return sh.name == name })
+	if i < 0 {
+		return completionShell{}, fmt.Errorf("unsupported shell %q, must be one of: %s", name, strings.Join(completionShellNames(), ", "))
+	}
+	return completionShells[i], nil
+}
+
+// detectShell detects the user's shell from the environment
+func detectShell() (string, error) {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return strings.TrimSuffix(filepath.Base(shell), ".exe"), nil
+	}
+	if runtime.GOOS == "windows" {
+		return "powershell", nil
+	}
+	return "", fmt.Errorf("could not detect shell, specify one of: %s", strings.Join(completionShellNames(), ", "))
+}
+
+// writeCompletionScript generates the shell's completion script for the root command into the file at path
+func writeCompletionScript(root *cobra.Command, sh completionShell, path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o775)
+	if err != nil {
+		return fmt.Errorf("creating completion directory: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating completion script: %w", err)
+	}
+
+	err = sh.generate(root, f, true)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("generating %s completion script: %w", sh.name, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing completion script: %w", err)
+	}
+	return nil
+}
+
+// NewCompletionCmd creates a completion command that writes the shell completion
+// script for the root command to stdout, replacing cobra's default completion
+// command. The shell is detected from the SHELL environment variable if not
+// specified. The scripts request completions from the tool, so they include
+// the dynamic completions of a [CompletionRegistry].
+func NewCompletionCmd(root *cobra.Command) *cobra.Command {
+	root.CompletionOptions.DisableDefaultCmd = true
+	var noDesc bool
+
+	cmd := &cobra.Command{
+		Use:   "completion [shell]",
+		Short: "Generate the autocompletion script for your shell",
+		Long: "Generates the autocompletion script for " + root.Name() + " for the specified shell, or the shell detected from the SHELL environment variable.\n\n" +
+			"To install the script to your shell's completion directory, use the install-completion command.",
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: completionShellNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var shellName string
+			if len(args) > 0 {
+				shellName = args[0]
+			} else {
+				var err error
+				shellName, err = detectShell()
+				if err != nil {
+					return err
+				}
+			}
+
+			sh, err := findCompletionShell(shellName)
+			if err != nil {
+				return err
+			}
+			if err := sh.generate(root, cmd.OutOrStdout(), !noDesc); err != nil {
+				return fmt.Errorf("generating %s completion script: %w", sh.name, err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&noDesc, "no-descriptions", false, "disable completion descriptions")
+
+	return cmd
+}
+
+// NewCompletionDocsCmd creates a command that generates shell completion scripts
+// for the root command for each supported shell, for distribution with the
+// generated documentation
+func NewCompletionDocsCmd(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [dir]",
+		Short: "Generate shell completion scripts for " + strings.Join(completionShellNames(), ", "),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			for _, sh := range completionShells {
+				err := writeCompletionScript(root, sh, filepath.Join(dir, sh.name, sh.fileName(root.Name())))
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Generated completion scripts: "+dir)
+			return err
+		},
+	}
+
+	return cmd
+}
+
+// NewInstallCompletionCmd creates an install-completion command that installs the
+// shell completion script for the root command to the user's shell completion directory
+func NewInstallCompletionCmd(root *cobra.Command) *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:       "install-completion [shell]",
+		Short:     "Install the shell completion script for your shell",
+		Long:      "Installs the shell completion script to your shell's completion directory. The shell is detected from the SHELL environment variable if not specified.",
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: completionShellNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var shellName string
+			if len(args) > 0 {
+				shellName = args[0]
+			} else {
+				var err error
+				shellName, err = detectShell()
+				if err != nil {
+					return err
+				}
+			}
+
+			sh, err := findCompletionShell(shellName)
+			if err != nil {
+				return err
+			}
+
+			installDir := dir
+			if installDir == "" {
+				installDir = sh.dir()
+			}
+			path := filepath.Join(installDir, sh.fileName(root.Name()))
+
+			err = writeCompletionScript(root, sh, path)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			_, err = fmt.Fprintf(out, "Installed %s completion script: %s\n", sh.name, path)
+			if err != nil {
+				return err
+			}
+			if sh.note != nil {
+				_, err = fmt.Fprintln(out, sh.note(installDir, path))
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "directory to install the completion script to (default is the shell's user completion directory)")
+
+	return cmd
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/config"
+	"github.com/act3-ai/go-common/pkg/logger"
+)
+
+// CompletionRegistry stores named sources of dynamic shell completions, so
+// commands can share them for their arguments and flags.
+//
+// Example:
+//
+//	completions := commands.NewCompletionRegistry(root)
+//	completions.Register("config-files", commands.FileCompletions("yaml", "yml"))
+//	completions.Register("format", cobra.FixedCompletions([]string{"json", "yaml", "table"}, cobra.ShellCompDirectiveNoFileComp))
+//	completions.RegisterCached("projects", time.Hour, listProjects)
+//
+//	completions.Args(applyCmd, "config-files")
+//	completions.Flag(getCmd, "output", "format")
+type CompletionRegistry struct {
+	mu       sync.RWMutex
+	sources  map[string]cobra.CompletionFunc
+	cacheDir string
+}
+
+// NewCompletionRegistry creates a completion registry for the root command.
+// Cached completions are stored in the user's cache directory for the tool.
+func NewCompletionRegistry(root *cobra.Command) *CompletionRegistry {
+	return &CompletionRegistry{
+		sources:  map[string]cobra.CompletionFunc{},
+		cacheDir: config.CacheDir(root.Name(), "completions"),
+	}
+}
+
+// Register adds a completion source, replacing any source with the same name.
+func (r *CompletionRegistry) Register(name string, source cobra.CompletionFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[name] = source
+}
+
+// RegisterCached adds a completion source for resources listed by list,
+// such as the resources of a remote service. The list is cached on disk for
+// ttl, since every completion request runs a new process. The cache is shared
+// by all commands and arguments using the source.
+func (r *CompletionRegistry) RegisterCached(name string, ttl time.Duration, list func(ctx context.Context) ([]cobra.Completion, error)) {
+	path := filepath.Join(r.cacheDir, name+".json")
+	r.Register(name, func(cmd *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		completions, err := cachedCompletions(cmd.Context(), path, ttl, list)
+		if err != nil {
+			cobra.CompErrorln(err.Error())
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// Source returns the named completion source.
+func (r *CompletionRegistry) Source(name string) (cobra.CompletionFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.sources[name]
+	return source, ok
+}
+
+// Names returns the names of the registered sources, sorted.
+func (r *CompletionRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// lookup returns a completion function that resolves the named source when
+// completions are requested, so sources can be registered after commands.
+func (r *CompletionRegistry) lookup(name string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		source, ok := r.Source(name)
+		if !ok {
+			cobra.CompErrorln("unknown completion source " + name)
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return source(cmd, args, toComplete)
+	}
+}
+
+// Args completes the arguments of cmd with the named source.
+func (r *CompletionRegistry) Args(cmd *cobra.Command, name string) {
+	cmd.ValidArgsFunction = r.lookup(name)
+}
+
+// Flag completes the values of the flag of cmd with the named source.
+func (r *CompletionRegistry) Flag(cmd *cobra.Command, flagName, name string) error {
+	if err := cmd.RegisterFlagCompletionFunc(flagName, r.lookup(name)); err != nil {
+		return fmt.Errorf("registering completions for flag %q: %w", flagName, err)
+	}
+	return nil
+}
+
+// FileCompletions completes files with the given extensions, without the
+// leading dot, and directories.
+func FileCompletions(extensions ...string) cobra.CompletionFunc {
+	return func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return extensions, cobra.ShellCompDirectiveFilterFileExt
+	}
+}
+
+// DirCompletions completes directories.
+func DirCompletions() cobra.CompletionFunc {
+	return func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+}
+
+// completionCache is the on-disk format of cached completions.
+type completionCache struct {
+	Time        time.Time          `json:"time"`
+	Completions []cobra.Completion `json:"completions"`
+}
+
+// cachedCompletions returns the completions cached at path if they are newer than ttl,
+// or lists and caches them.
+func cachedCompletions(ctx context.Context, path string, ttl time.Duration, list func(ctx context.Context) ([]cobra.Completion, error)) ([]cobra.Completion, error) {
+	log := logger.FromContext(ctx)
+	if data, err := os.ReadFile(path); err == nil {
+		var cache completionCache
+		if err := json.Unmarshal(data, &cache); err == nil && time.Since(cache.Time) < ttl {
+			return cache.Completions, nil
+		}
+	}
+
+	completions, err := list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing completions: %w", err)
+	}
+
+	data, err := json.Marshal(completionCache{Time: time.Now(), Completions: completions})
+	if err == nil {
+		_, err = config.EnsureDir(filepath.Dir(path), config.DirPerm)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		// completions still work without the cache
+		log.DebugContext(ctx, "Failed to cache completions", "path", path, "error", err)
+	}
+	return completions, nil
+}
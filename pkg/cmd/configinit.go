@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	yamlv3 "go.yaml.in/yaml/v3"
+
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/cobrautil"
+)
+
+// NewConfigInitCmd creates a config-init command that creates a configuration
+// file by asking for the value of each option of the groups that can be set in
+// the configuration file (options with a JSON path). Values are validated by
+// option type, and each option is written with its description as a comment.
+// Sensitive options are not prompted for, to avoid writing secrets to the file.
+//
+// The file is written to path, typically [config.DefaultConfigPath], unless
+// the --output flag is set. The --schema flag adds a yaml-language-server
+// modeline so editors validate the file.
+//
+// Example:
+//
+//	commands.NewConfigInitCmd(optionGroups, config.DefaultConfigPath("ace", "sample", "config.yaml"))
+func NewConfigInitCmd(groups []*options.Group, path string) *cobra.Command {
+	var output, schema string
+	var force, defaults bool
+
+	cmd := &cobra.Command{
+		Use:   "config-init",
+		Short: "Create a configuration file interactively",
+		Long: "Creates a configuration file by prompting for the value of each configuration option. " +
+			"Press enter to keep an option's default value.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := os.Stat(output); err == nil && !force {
+				return fmt.Errorf("configuration file %s already exists, use --force to overwrite it", output)
+			} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("checking configuration file: %w", err)
+			}
+
+			var ask func(ctx context.Context, opt *options.Option) (string, error)
+			if !defaults {
+				in, ok := cmd.InOrStdin().(*os.File)
+				if !ok {
+					in = os.Stdin
+				}
+				prompter := cobrautil.NewTerminalPrompter(in, cmd.ErrOrStderr())
+				if !prompter.Interactive() {
+					return fmt.Errorf("%w, use --defaults to write the default configuration", cobrautil.ErrNotInteractive)
+				}
+				ask = func(ctx context.Context, opt *options.Option) (string, error) {
+					return promptOption(ctx, prompter, opt)
+				}
+			}
+
+			data, err := configFile(cmd.Context(), groups, schema, ask)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(filepath.Dir(output), 0o775); err != nil {
+				return fmt.Errorf("creating configuration directory: %w", err)
+			}
+			if err := os.WriteFile(output, data, 0o600); err != nil {
+				return fmt.Errorf("writing configuration file: %w", err)
+			}
+			cmd.Println("Created configuration file: " + output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", path, "path to write the configuration file to")
+	cmd.Flags().StringVar(&schema, "schema", "", "URI of the JSON Schema definition of the configuration file, for editor validation")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing configuration file")
+	cmd.Flags().BoolVar(&defaults, "defaults", false, "write the default configuration without prompting")
+
+	return cmd
+}
+
+// configurable reports whether the option can be set in a configuration file by config-init.
+func configurable(opt *options.Option) bool {
+	switch opt.Type {
+	case options.Object, options.List, options.StringMap:
+		return false
+	}
+	return opt.JSON != "" && opt.Deprecated == ""
+}
+
+// promptOption asks for the value of an option, returning the default if the answer is empty.
+func promptOption(ctx context.Context, prompter *cobrautil.TerminalPrompter, opt *options.Option) (string, error) {
+	label := opt.JSON
+	if desc := opt.ShortDescription(); desc != "" {
+		label = desc + " (" + label + ")"
+	}
+
+	switch opt.Type {
+	case options.Boolean:
+		def, _ := strconv.ParseBool(opt.Default)
+		answer, err := prompter.Confirm(ctx, label, def)
+		return strconv.FormatBool(answer), err //nolint:wrapcheck
+	case options.Choice:
+		if len(opt.Choices) > 0 {
+			return prompter.Select(ctx, label, opt.Choices) //nolint:wrapcheck
+		}
+	}
+
+	if opt.Default != "" {
+		label += " [" + opt.Default + "]"
+	}
+	answer, err := prompter.Input(ctx, label, func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return validateOptionValue(opt, s)
+	})
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	if answer == "" {
+		return opt.Default, nil
+	}
+	return answer, nil
+}
+
+// validateOptionValue checks that s is a valid value for the option's type.
+func validateOptionValue(opt *options.Option, s string) error {
+	var err error
+	switch opt.Type {
+	case options.Boolean:
+		_, err = strconv.ParseBool(s)
+	case options.Integer:
+		_, err = strconv.ParseInt(s, 10, 64)
+	case options.Float:
+		_, err = strconv.ParseFloat(s, 64)
+	case options.Duration:
+		_, err = time.ParseDuration(s)
+	case options.Choice:
+		if len(opt.Choices) > 0 && !slices.Contains(opt.Choices, s) {
+			err = fmt.Errorf("must be one of: %s", strings.Join(opt.Choices, ", "))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", opt.Type, err)
+	}
+	return nil
+}
+
+// configNode is a key of a configuration file.
+type configNode struct {
+	key      string
+	comment  string
+	value    *string // nil if unset
+	str      bool    // value is a string
+	children []*configNode
+}
+
+// child returns the child node for key, creating it if needed.
+func (n *configNode) child(key string) *configNode {
+	for _, c := range n.children {
+		if c.key == key {
+			return c
+		}
+	}
+	c := &configNode{key: key}
+	n.children = append(n.children, c)
+	return c
+}
+
+// configFile renders a commented YAML configuration file with the values of
+// the configurable options, asking for each value with ask, or using the
+// defaults if ask is nil. Options without a value are commented out.
+func configFile(ctx context.Context, groups []*options.Group, schema string,
+	ask func(ctx context.Context, opt *options.Option) (string, error),
+) ([]byte, error) {
+	root := &configNode{}
+	for _, group := range groups {
+		groupComment := joinLines(group.Title, group.Description)
+		for _, opt := range group.Options {
+			if !configurable(opt) {
+				continue
+			}
+
+			value := opt.Default
+			if ask != nil && !opt.Sensitive {
+				var err error
+				value, err = ask(ctx, opt)
+				if err != nil {
+					return nil, fmt.Errorf("prompting for %s: %w", opt.JSON, err)
+				}
+			}
+
+			node := root
+			for key := range strings.SplitSeq(opt.JSON, ".") {
+				node = node.child(key)
+			}
+			node.comment = opt.ShortDescription()
+			if opt.Sensitive && opt.Env != "" {
+				node.comment = joinLines(node.comment, "Sensitive, set with the "+opt.Env+" environment variable instead.")
+			}
+			if groupComment != "" {
+				node.comment = joinLines(groupComment, "", node.comment)
+				groupComment = ""
+			}
+			if value != "" && !opt.Sensitive {
+				node.value = &value
+			}
+			node.str = opt.Type == options.String || opt.Type == options.Choice || opt.Type == options.Duration
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if schema != "" {
+		fmt.Fprintf(buf, "# yaml-language-server: $schema=%s\n\n", schema)
+	}
+	if err := writeConfigNodes(buf, root.children, ""); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeConfigNodes writes the nodes as YAML with their comments.
+func writeConfigNodes(buf *bytes.Buffer, nodes []*configNode, indent string) error {
+	for _, n := range nodes {
+		if n.comment != "" {
+			for line := range strings.SplitSeq(n.comment, "\n") {
+				buf.WriteString(strings.TrimRight(indent+"# "+line, " ") + "\n")
+			}
+		}
+		switch {
+		case len(n.children) > 0:
+			buf.WriteString(indent + n.key + ":\n")
+			if err := writeConfigNodes(buf, n.children, indent+"  "); err != nil {
+				return err
+			}
+		case n.value == nil:
+			buf.WriteString(indent + "# " + n.key + ":\n")
+		default:
+			var value any = *n.value
+			if !n.str {
+				// let YAML determine the type of booleans and numbers
+				value = yamlv3.Node{Kind: yamlv3.ScalarNode, Value: *n.value}
+			}
+			data, err := yamlv3.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("encoding %s: %w", n.key, err)
+			}
+			buf.WriteString(indent + n.key + ": " + strings.TrimSuffix(string(data), "\n") + "\n")
+		}
+	}
+	return nil
+}
+
+// joinLines joins lines with newlines, dropping leading and trailing empty lines.
+func joinLines(lines ...string) string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/md"
+	"github.com/act3-ai/go-common/pkg/termdoc"
+	"github.com/act3-ai/go-common/pkg/termdoc/mdfmt"
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// Severity determines how a failed [Check] affects the doctor command.
+type Severity string
+
+// Check severities.
+const (
+	SeverityRequired    Severity = "required"    // failure fails the command
+	SeverityRecommended Severity = "recommended" // failure is reported as a warning
+)
+
+// CheckStatus is the outcome of a [Check].
+type CheckStatus string
+
+// Check outcomes.
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+	CheckSkip CheckStatus = "skip"
+)
+
+// CheckResult is the result of a [Check].
+type CheckResult struct {
+	Status  CheckStatus
+	Message string // Details of the result, e.g. the version found or the error
+}
+
+// Check verifies a part of the user's environment.
+type Check struct {
+	Name     string
+	Severity Severity
+
+	// Run performs the check, returning its result and, unless it passed,
+	// a Markdown remedy describing how the user can fix the problem.
+	Run func(ctx context.Context) (result CheckResult, remedy string)
+}
+
+// DefaultCheckTimeout bounds the time each check may run.
+const DefaultCheckTimeout = 10 * time.Second
+
+// NewDoctorCmd creates a doctor command that runs the checks and prints their
+// results as a table, followed by remedies for the failed checks. The command
+// fails if a required check fails.
+//
+// Example:
+//
+//	commands.NewDoctorCmd(
+//		commands.ConfigFileCheck(config.DefaultConfigSearchPath("ace", "sample", "config.yaml")...),
+//		commands.EndpointCheck("Registry reachable", "https://registry.example.com/v2/", nil),
+//		commands.VersionCheck(source, nil),
+//	)
+func NewDoctorCmd(checks ...Check) *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check your environment for problems",
+		Args:  cobra.NoArgs,
+		// failed checks are not usage errors
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rows := make([][]string, 0, len(checks))
+			var remedies []string
+			failed := 0
+			for _, check := range checks {
+				result, remedy := runCheck(cmd.Context(), check, timeout)
+				if result.Status == CheckFail && check.Severity == SeverityRequired {
+					failed++
+				}
+				rows = append(rows, []string{check.Name, statusLabel(result.Status), result.Message})
+				if remedy != "" && (result.Status == CheckFail || result.Status == CheckWarn) {
+					remedies = append(remedies, "- **"+check.Name+"**: "+remedy)
+				}
+			}
+
+			out := mdfmt.WriteTable([]string{"Check", "Status", "Details"}, rows)
+			if len(remedies) > 0 {
+				out += "\n### Remedies\n\n" + strings.Join(remedies, "\n") + "\n"
+			}
+			cmd.Print(termdoc.AutoMarkdownFormat().Format(out))
+
+			if failed > 0 {
+				return fmt.Errorf("%d required checks failed", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", DefaultCheckTimeout, "maximum time for each check")
+
+	return cmd
+}
+
+// runCheck runs the check with a timeout. Failures of recommended checks are reported as warnings.
+func runCheck(ctx context.Context, check Check, timeout time.Duration) (CheckResult, string) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, remedy := check.Run(ctx)
+	if result.Status == CheckFail && check.Severity == SeverityRecommended {
+		result.Status = CheckWarn
+	}
+	return result, remedy
+}
+
+// statusLabel formats a check status for the results table.
+func statusLabel(status CheckStatus) string {
+	switch status {
+	case CheckPass:
+		return "✔ pass"
+	case CheckWarn:
+		return "! warn"
+	case CheckFail:
+		return "✘ fail"
+	default:
+		return "- " + string(status)
+	}
+}
+
+// ConfigFileCheck creates a recommended check that the first existing
+// configuration file in paths is readable. It is skipped if none exist.
+func ConfigFileCheck(paths ...string) Check {
+	return Check{
+		Name:     "Configuration file readable",
+		Severity: SeverityRecommended,
+		Run: func(context.Context) (CheckResult, string) {
+			for _, path := range paths {
+				f, err := os.Open(path)
+				switch {
+				case errors.Is(err, os.ErrNotExist):
+					continue
+				case err != nil:
+					return CheckResult{Status: CheckFail, Message: err.Error()},
+						"Check the permissions of " + md.Code(path) + "."
+				}
+				_ = f.Close()
+				return CheckResult{Status: CheckPass, Message: path}, ""
+			}
+			return CheckResult{Status: CheckSkip, Message: "no configuration file found"}, ""
+		},
+	}
+}
+
+// EndpointCheck creates a required check that a GET request to the URL
+// succeeds without a server error. A nil client uses [http.DefaultClient].
+func EndpointCheck(name, u string, client httputil.Client) Check {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return Check{
+		Name:     name,
+		Severity: SeverityRequired,
+		Run: func(ctx context.Context) (CheckResult, string) {
+			remedy := "Check your network connection and proxy settings, and that " + md.Code(u) + " is correct."
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+			if err != nil {
+				return CheckResult{Status: CheckFail, Message: err.Error()}, remedy
+			}
+			start := time.Now()
+			resp, err := client.Do(req)
+			if err != nil {
+				return CheckResult{Status: CheckFail, Message: err.Error()}, remedy
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode >= http.StatusInternalServerError {
+				return CheckResult{Status: CheckFail, Message: resp.Status}, "The service is unavailable, try again later."
+			}
+			return CheckResult{Status: CheckPass, Message: fmt.Sprintf("%s in %s", resp.Status, time.Since(start).Round(time.Millisecond))}, ""
+		},
+	}
+}
+
+// VersionCheck creates a recommended check that the current version, from
+// [version.Get], is the latest release of the source. A nil client uses [http.DefaultClient].
+func VersionCheck(source ReleaseSource, client httputil.Client) Check {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return Check{
+		Name:     "Version current",
+		Severity: SeverityRecommended,
+		Run: func(ctx context.Context) (CheckResult, string) {
+			current := canonicalVersion(version.Get().Version)
+			if !semver.IsValid(current) {
+				return CheckResult{Status: CheckSkip, Message: "development build"}, ""
+			}
+			release, err := source.Latest(ctx, client)
+			if err != nil {
+				return CheckResult{Status: CheckFail, Message: err.Error()}, "Check your network connection."
+			}
+			latest := canonicalVersion(release.Version)
+			if semver.Compare(current, latest) < 0 {
+				return CheckResult{Status: CheckFail, Message: current + " < " + latest},
+					strings.TrimSpace("Update to " + latest + ". " + release.Notes)
+			}
+			return CheckResult{Status: CheckPass, Message: current}, ""
+		},
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Editors supported by the genschema command's --editor flag.
+const (
+	editorVSCode    = "vscode"    // VS Code settings.json
+	editorJetBrains = "jetbrains" // JetBrains IDE .idea/jsonSchemas.xml
+	editorNeovim    = "neovim"    // Neovim nvim-lspconfig settings for yaml-language-server and vscode-json-language-server
+	editorModeline  = "modeline"  // yaml-language-server inline modeline comments
+)
+
+// splitFileMatch splits file match patterns into YAML and JSON patterns.
+func splitFileMatch(fileMatches []string) (yamlFiles, jsonFiles []string) {
+	for _, pattern := range fileMatches {
+		switch filepath.Ext(pattern) {
+		case ".yaml", ".yml":
+			yamlFiles = append(yamlFiles, pattern)
+		case ".json":
+			jsonFiles = append(jsonFiles, pattern)
+		}
+	}
+	return yamlFiles, jsonFiles
+}
+
+/*
+Example JetBrains .idea/jsonSchemas.xml file:
+
+	<?xml version="1.0" encoding="UTF-8"?>
+	<project version="4">
+	  <component name="JsonSchemaMappingsProjectConfiguration">
+	    <state>
+	      <map>
+	        <entry key="template-schema.json">
+	          <value>
+	            <SchemaInfo>
+	              <option name="name" value="template-schema.json" />
+	              <option name="relativePathToSchema" value="/Users/username/.config/act3/pt/schema/template-schema.json" />
+	              <option name="patterns">
+	                <list>
+	                  <Item>
+	                    <option name="pattern" value="true" />
+	                    <option name="path" value=".act3-template.yaml" />
+	                  </Item>
+	                </list>
+	              </option>
+	            </SchemaInfo>
+	          </value>
+	        </entry>
+	      </map>
+	    </state>
+	  </component>
+	</project>
+*/
+
+// printJetBrainsSettings prints the JetBrains IDE schema mappings associating the schemas with files.
+func printJetBrainsSettings(cmd *cobra.Command, associations []SchemaAssociation) error {
+	if len(associations) == 0 {
+		return nil
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(xml.Header)
+	b.WriteString("<project version=\"4\">\n")
+	b.WriteString("  <component name=\"JsonSchemaMappingsProjectConfiguration\">\n")
+	b.WriteString("    <state>\n")
+	b.WriteString("      <map>\n")
+	for _, assoc := range associations {
+		name := filepath.Base(assoc.Definition)
+		fmt.Fprintf(b, "        <entry key=%s>\n", xmlAttr(name))
+		b.WriteString("          <value>\n")
+		b.WriteString("            <SchemaInfo>\n")
+		fmt.Fprintf(b, "              <option name=\"name\" value=%s />\n", xmlAttr(name))
+		fmt.Fprintf(b, "              <option name=\"relativePathToSchema\" value=%s />\n", xmlAttr(strings.TrimPrefix(assoc.Definition, "file://")))
+		b.WriteString("              <option name=\"patterns\">\n")
+		b.WriteString("                <list>\n")
+		for _, pattern := range assoc.FileMatch {
+			b.WriteString("                  <Item>\n")
+			b.WriteString("                    <option name=\"pattern\" value=\"true\" />\n")
+			fmt.Fprintf(b, "                    <option name=\"path\" value=%s />\n", xmlAttr(pattern))
+			b.WriteString("                  </Item>\n")
+		}
+		b.WriteString("                </list>\n")
+		b.WriteString("              </option>\n")
+		b.WriteString("            </SchemaInfo>\n")
+		b.WriteString("          </value>\n")
+		b.WriteString("        </entry>\n")
+	}
+	b.WriteString("      </map>\n")
+	b.WriteString("    </state>\n")
+	b.WriteString("  </component>\n")
+	b.WriteString("</project>")
+
+	cmd.Println("Add the following to the project's .idea/jsonSchemas.xml file to enable YAML and JSON file validation:\n\n" + b.String() + "\n")
+	return nil
+}
+
+// xmlAttr quotes and escapes an XML attribute value.
+func xmlAttr(s string) string {
+	buf := &bytes.Buffer{}
+	_ = xml.EscapeText(buf, []byte(s))
+	return `"` + buf.String() + `"`
+}
+
+/*
+Example Neovim nvim-lspconfig settings:
+
+	require("lspconfig").yamlls.setup({
+	  settings = {
+	    yaml = {
+	      schemas = {
+	        ["file:///Users/username/.config/act3/pt/schema/template-schema.json"] = { ".act3-template.yaml" },
+	      },
+	    },
+	  },
+	})
+*/
+
+// printNeovimSettings prints the Neovim language server settings associating the schemas with files.
+func printNeovimSettings(cmd *cobra.Command, associations []SchemaAssociation) error {
+	yamlSchemas := &strings.Builder{}
+	jsonSchemas := &strings.Builder{}
+
+	for _, assoc := range associations {
+		schemaFileURI := assoc.Definition
+		yamlFiles, jsonFiles := splitFileMatch(assoc.FileMatch)
+		if len(yamlFiles) > 0 {
+			fmt.Fprintf(yamlSchemas, "        [%s] = %s,\n", strconv.Quote(schemaFileURI), luaList(yamlFiles))
+		}
+		if len(jsonFiles) > 0 {
+			fmt.Fprintf(jsonSchemas, "        { url = %s, fileMatch = %s },\n", strconv.Quote(schemaFileURI), luaList(jsonFiles))
+		}
+	}
+
+	if yamlSchemas.Len() > 0 {
+		cmd.Println("Add the following to Neovim's configuration to enable YAML file validation with yaml-language-server:\n\n" +
+			"require(\"lspconfig\").yamlls.setup({\n" +
+			"  settings = {\n" +
+			"    yaml = {\n" +
+			"      schemas = {\n" +
+			yamlSchemas.String() +
+			"      },\n" +
+			"    },\n" +
+			"  },\n" +
+			"})\n")
+	}
+
+	if jsonSchemas.Len() > 0 {
+		cmd.Println("Add the following to Neovim's configuration to enable JSON file validation with vscode-json-language-server:\n\n" +
+			"require(\"lspconfig\").jsonls.setup({\n" +
+			"  settings = {\n" +
+			"    json = {\n" +
+			"      schemas = {\n" +
+			jsonSchemas.String() +
+			"      },\n" +
+			"    },\n" +
+			"  },\n" +
+			"})\n")
+	}
+
+	return nil
+}
+
+// luaList formats the strings as a Lua list.
+func luaList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = strconv.Quote(item)
+	}
+	return "{ " + strings.Join(quoted, ", ") + " }"
+}
+
+// printModelines prints the inline comments associating files with the schemas.
+//
+// The yaml-language-server modeline is supported by any editor using yaml-language-server.
+// JSON files are associated with the "$schema" property instead.
+func printModelines(cmd *cobra.Command, associations []SchemaAssociation) error {
+	for _, assoc := range associations {
+		schemaFileURI := assoc.Definition
+		yamlFiles, jsonFiles := splitFileMatch(assoc.FileMatch)
+		if len(yamlFiles) > 0 {
+			cmd.Println("Add the following comment to the top of " + strings.Join(yamlFiles, ", ") +
+				" to enable YAML file validation:\n\n" +
+				"# yaml-language-server: $schema=" + schemaFileURI + "\n")
+		}
+		if len(jsonFiles) > 0 {
+			cmd.Println("Add the following property to " + strings.Join(jsonFiles, ", ") +
+				" to enable JSON file validation:\n\n" +
+				"  \"$schema\": " + strconv.Quote(schemaFileURI) + "\n")
+		}
+	}
+	return nil
+}
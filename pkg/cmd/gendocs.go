@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 
 	embedutil "github.com/act3-ai/go-common/pkg/embedutil"
@@ -18,8 +21,13 @@ func NewGendocsCmd(docs *embedutil.Documentation) *cobra.Command {
 		newHTMLCmd(docs),
 		newMarkdownCmd(docs),
 		newManpageCmd(docs),
+		newJSONCmd(docs),
 	)
 
+	if docs.Command != nil {
+		cmd.AddCommand(NewCompletionDocsCmd(docs.Command))
+	}
+
 	return cmd
 }
 
@@ -31,6 +39,9 @@ func newHTMLCmd(docs *embedutil.Documentation) *cobra.Command {
 		Flat:   false,
 	}
 
+	var check bool
+	var cssFile string
+
 	cmd := &cobra.Command{
 		Use: "html [dir]",
 		Aliases: []string{
@@ -45,13 +56,27 @@ func newHTMLCmd(docs *embedutil.Documentation) *cobra.Command {
 				dir = args[0]
 			}
 
-			return docs.Write(cmd.Context(), dir, opts)
+			if cssFile != "" {
+				css, err := os.ReadFile(cssFile)
+				if err != nil {
+					return fmt.Errorf("reading stylesheet: %w", err)
+				}
+				opts.HTML.CSS = string(css)
+			}
+
+			return writeDocs(cmd, docs, dir, opts, check)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.Index, "index", "i", true, `generate an index.html index file`)
 	cmd.Flags().BoolVarP(&opts.Flat, "flat", "f", false, `generate docs in a flat directory structure`)
+	cmd.Flags().BoolVar(&opts.SinglePage, "single-page", false, `generate all docs in a single index.html file`)
+	cmd.MarkFlagsMutuallyExclusive("single-page", "flat")
+	cmd.Flags().StringVar(&cssFile, "css", "", `stylesheet file replacing the default theme of each page`)
+	cmd.Flags().StringSliceVar(&opts.HTML.Stylesheets, "stylesheet", nil, `URL of an additional stylesheet linked by each page, relative URLs are relative to dir`)
+	addLinkFlags(cmd, opts)
 	// gendocsCmd.Flags().BoolVarP(&opts.Serve, "serve", "s", opts.Serve, "Serve generated docs")
+	addCheckFlag(cmd, &check)
 
 	return cmd
 }
@@ -64,6 +89,8 @@ func newMarkdownCmd(docs *embedutil.Documentation) *cobra.Command {
 		Flat:   false,
 	}
 
+	var check bool
+
 	var onlyCommands bool
 
 	cmd := &cobra.Command{
@@ -83,18 +110,28 @@ func newMarkdownCmd(docs *embedutil.Documentation) *cobra.Command {
 			if len(args) > 0 {
 				dir = args[0]
 			}
-			return docs.Write(cmd.Context(), dir, opts)
+			return writeDocs(cmd, docs, dir, opts, check)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.Index, "index", "i", true, `generate a README.md index file`)
 	cmd.Flags().BoolVarP(&opts.Flat, "flat", "f", false, `generate docs in a flat directory structure`)
+	cmd.Flags().BoolVar(&opts.SinglePage, "single-page", false, `generate all docs in a single README.md file`)
 	cmd.Flags().BoolVar(&onlyCommands, "only-commands", false, "only generate command documentation")
 	cmd.MarkFlagsMutuallyExclusive("only-commands", "index")
+	cmd.MarkFlagsMutuallyExclusive("single-page", "flat")
+	addLinkFlags(cmd, opts)
+	addCheckFlag(cmd, &check)
 
 	return cmd
 }
 
+// addLinkFlags adds flags to configure link rewriting and validation.
+func addLinkFlags(cmd *cobra.Command, opts *embedutil.Options) {
+	cmd.Flags().StringVar(&opts.Links.BaseURL, "link-base-url", "", `base URL for relative links to files that are not embedded documents`)
+	cmd.Flags().BoolVar(&opts.ValidateLinks, "validate-links", false, `fail if embedded documents contain broken relative links`)
+}
+
 func newManpageCmd(docs *embedutil.Documentation) *cobra.Command {
 	opts := &embedutil.Options{
 		Format: embedutil.Manpage,
@@ -103,6 +140,8 @@ func newManpageCmd(docs *embedutil.Documentation) *cobra.Command {
 		Flat:   true,
 	}
 
+	var check bool
+
 	cmd := &cobra.Command{
 		Use: "man [dir]",
 		Aliases: []string{
@@ -116,9 +155,50 @@ func newManpageCmd(docs *embedutil.Documentation) *cobra.Command {
 			if len(args) > 0 {
 				dir = args[0]
 			}
-			return docs.Write(cmd.Context(), dir, opts)
+			return writeDocs(cmd, docs, dir, opts, check)
 		},
 	}
 
+	addCheckFlag(cmd, &check)
+
 	return cmd
 }
+
+func newJSONCmd(docs *embedutil.Documentation) *cobra.Command {
+	opts := &embedutil.Options{
+		Format: embedutil.JSON,
+		Types:  []embedutil.DocType{embedutil.TypeGeneral, embedutil.TypeCommands},
+	}
+
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "json [dir]",
+		Short: "Generate documentation in JSON format",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return writeDocs(cmd, docs, dir, opts, check)
+		},
+	}
+
+	addCheckFlag(cmd, &check)
+
+	return cmd
+}
+
+// addCheckFlag adds a flag to check generated docs instead of writing them.
+func addCheckFlag(cmd *cobra.Command, check *bool) {
+	cmd.Flags().BoolVar(check, "check", false, `check that docs in the output directory are up to date, without writing them`)
+}
+
+// writeDocs writes the docs to dir, or checks that the docs in dir are up to date.
+func writeDocs(cmd *cobra.Command, docs *embedutil.Documentation, dir string, opts *embedutil.Options, check bool) error {
+	if check {
+		return docs.Check(cmd.Context(), dir, opts)
+	}
+	return docs.Write(cmd.Context(), dir, opts)
+}
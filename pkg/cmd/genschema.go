@@ -8,8 +8,13 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
 )
 
 // SchemaAssociation associates a JSON Schema definition to the files it validates
@@ -57,31 +62,62 @@ type SchemaAssociation struct {
 //
 // [go-common/pkg/genschema]: https://github.com/act3-ai/go-common/-/tree/main/pkg/genschema
 func NewGenschemaCmd(schemaDefs fs.FS, associations []SchemaAssociation) *cobra.Command {
+	var bundle bool
+	var output, format, editor, schemaURL string
+
 	schemaCmd := &cobra.Command{
-		Use:   "genschema <schema location>",
+		Use:   "genschema [schema location]",
 		Short: "Outputs configuration file validators",
 		Long: `Outputs schema definitions for configuration files in JSON Schema format.
-Provides instructions for adding the schema definitions to VS Code to validate configuration files.`,
-		Args:   cobra.ExactArgs(1),
+Provides instructions for adding the schema definitions to an editor to validate configuration files.
+
+With --bundle, all schema definitions are combined into a single schema, written to stdout or the --output file.
+
+With --schema-url, editor settings reference the schema definitions hosted under the given URL instead of local files.
+The schema location may be omitted to print the settings without writing the schema definitions.`,
+		Example: `  # Write schema definitions to a directory
+  genschema schemas
+
+  # Write a single bundled schema in YAML format to stdout
+  genschema --bundle --format yaml
+
+  # Print settings referencing schema definitions hosted on a static site
+  genschema --schema-url https://example.com/schemas`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if bundle {
+				return cobra.NoArgs(cmd, args)
+			}
+			if schemaURL != "" {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			schemaDir, err := filepath.Abs(args[0])
-			if err != nil {
-				return fmt.Errorf("could not evaluate output directory: %w", err)
+			if bundle {
+				return writeSchemaBundle(cmd.OutOrStdout(), schemaDefs, output, format)
 			}
 
-			if err := os.MkdirAll(schemaDir, 0o755); err != nil {
-				return fmt.Errorf("failed to create output directory: %w", err)
+			var schemaDir string
+			if len(args) > 0 {
+				var err error
+				if schemaDir, err = filepath.Abs(args[0]); err != nil {
+					return fmt.Errorf("could not evaluate output directory: %w", err)
+				}
+
+				if err := os.MkdirAll(schemaDir, 0o755); err != nil {
+					return fmt.Errorf("failed to create output directory: %w", err)
+				}
 			}
 
 			/*
 				Iterate over each schema that needs generated
 			*/
 
-			yamlSettings := vsCodeYAMLSchemaSettings{}
-			jsonSettings := vsCodeJSONSchemaSettings{}
+			// Associations with the URIs of the written or hosted schema files
+			var written []SchemaAssociation
 
-			if err = fs.WalkDir(schemaDefs, ".", func(path string, d fs.DirEntry, err error) error {
+			if err := fs.WalkDir(schemaDefs, ".", func(path string, d fs.DirEntry, err error) error {
 				if err != nil {
 					return err
 				}
@@ -91,20 +127,24 @@ Provides instructions for adding the schema definitions to VS Code to validate c
 				}
 
 				// Create file from fs.FS to schemaDir
-				if err = copyFile(schemaDefs, schemaDir, path); err != nil {
-					return fmt.Errorf("could not create schema definition %q: %w", path, err)
+				if schemaDir != "" {
+					if err = copyFile(schemaDefs, schemaDir, path); err != nil {
+						return fmt.Errorf("could not create schema definition %q: %w", path, err)
+					}
 				}
 
-				schemaFile := filepath.Join(schemaDir, path)
+				// Reference the hosted schema if a URL was given
+				schemaFile := "file://" + filepath.Join(schemaDir, path)
+				if schemaURL != "" {
+					schemaFile = strings.TrimSuffix(schemaURL, "/") + "/" + path
+				}
 
 				for _, assoc := range associations {
 					if path == assoc.Definition {
-						// Build the VS Code settings to associate the schema with files
-						newYAML, newJSON := generateVSCodeSettings(schemaFile, assoc.FileMatch)
-
-						// Add the settings to the global settings
-						yamlSettings.add(newYAML)
-						jsonSettings.add(newJSON)
+						written = append(written, SchemaAssociation{
+							Definition: schemaFile,
+							FileMatch:  assoc.FileMatch,
+						})
 					}
 				}
 
@@ -113,27 +153,155 @@ Provides instructions for adding the schema definitions to VS Code to validate c
 				return fmt.Errorf("error generating schema files: %w", err)
 			}
 
-			if len(yamlSettings) > 0 {
-				yamlout, err := yamlSettings.marshal()
-				if err != nil {
-					return err
-				}
-				cmd.Println("Add the following to VS Code's settings.json file to enable YAML file validation:\n\n" + yamlout + "\n")
+			// Print instructions to associate the schemas with files in the editor
+			switch editor {
+			case editorJetBrains:
+				return printJetBrainsSettings(cmd, written)
+			case editorNeovim:
+				return printNeovimSettings(cmd, written)
+			case editorModeline:
+				return printModelines(cmd, written)
+			default:
+				return printVSCodeSettings(cmd, written)
 			}
+		},
+	}
 
-			if len(jsonSettings) > 0 {
-				jsonout, err := jsonSettings.marshal()
-				if err != nil {
-					return err
-				}
-				cmd.Println("Add the following to VS Code's settings.json file to enable JSON file validation:\n\n" + jsonout + "\n")
-			}
+	schemaCmd.Flags().BoolVar(&bundle, "bundle", false, `combine all schema definitions into a single schema`)
+	schemaCmd.Flags().StringVarP(&output, "output", "o", "-", `file to write the bundled schema to, "-" for stdout`)
+	flagutil.ChoiceVar(schemaCmd.Flags(), &format, "format", schemaFormatJSON,
+		[]string{schemaFormatJSON, schemaFormatYAML}, `format of the bundled schema`)
+	flagutil.ChoiceVar(schemaCmd.Flags(), &editor, "editor", editorVSCode,
+		[]string{editorVSCode, editorJetBrains, editorNeovim, editorModeline}, `editor to print schema association settings for`)
+	schemaCmd.Flags().StringVar(&schemaURL, "schema-url", "", `base URL of hosted schema definitions to reference in editor settings instead of local files`)
+
+	return schemaCmd
+}
+
+// Bundled schema output formats.
+const (
+	schemaFormatJSON = "json"
+	schemaFormatYAML = "yaml"
+)
+
+// writeSchemaBundle writes the schema definitions as a single bundled schema to the output file, or w if output is "-".
+func writeSchemaBundle(w io.Writer, schemaDefs fs.FS, output, format string) error {
+	bundle, err := bundleSchemas(schemaDefs)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to create bundled schema: %w", err)
+	}
+	if format == schemaFormatYAML {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return fmt.Errorf("failed to convert bundled schema to YAML: %w", err)
+		}
+	} else {
+		data = append(data, '\n')
+	}
 
+	if output == "" || output == "-" {
+		_, err = w.Write(data)
+		return err //nolint:wrapcheck
+	}
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write bundled schema: %w", err)
+	}
+	return nil
+}
+
+// bundleSchemas combines the JSON Schema definitions in schemaDefs into a single schema.
+//
+// The definitions of each schema are merged into the bundle's $defs, and each schema's
+// root type is referenced in the bundle's anyOf.
+func bundleSchemas(schemaDefs fs.FS) (map[string]any, error) {
+	defs := map[string]any{}
+	var roots []any
+	bundle := map[string]any{}
+
+	if err := fs.WalkDir(schemaDefs, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
 			return nil
-		},
+		}
+
+		data, err := fs.ReadFile(schemaDefs, path)
+		if err != nil {
+			return fmt.Errorf("could not read schema definition %q: %w", path, err)
+		}
+		schema := map[string]any{}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return fmt.Errorf("could not parse schema definition %q: %w", path, err)
+		}
+
+		if dialect, ok := schema["$schema"]; ok {
+			bundle["$schema"] = dialect
+		}
+		fileDefs, _ := schema["$defs"].(map[string]any)
+		for name, def := range fileDefs {
+			if existing, ok := defs[name]; ok && !reflect.DeepEqual(existing, def) {
+				return fmt.Errorf("schema definition %q in %q conflicts with another schema", name, path)
+			}
+			defs[name] = def
+		}
+
+		ref, _ := schema["$ref"].(string)
+		if ref == "" || len(fileDefs) == 0 {
+			// Schemas without definitions are added as a definition
+			name := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".json"), "-schema")
+			delete(schema, "$schema")
+			delete(schema, "$id")
+			delete(schema, "$defs")
+			defs[name] = schema
+			ref = "#/$defs/" + name
+		}
+		roots = append(roots, map[string]any{"$ref": ref})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error bundling schema files: %w", err)
 	}
 
-	return schemaCmd
+	bundle["$defs"] = defs
+	bundle["anyOf"] = roots
+	return bundle, nil
+}
+
+// printVSCodeSettings prints the VS Code settings associating the schemas with files.
+func printVSCodeSettings(cmd *cobra.Command, associations []SchemaAssociation) error {
+	yamlSettings := vsCodeYAMLSchemaSettings{}
+	jsonSettings := vsCodeJSONSchemaSettings{}
+
+	for _, assoc := range associations {
+		// Build the VS Code settings to associate the schema with files
+		newYAML, newJSON := generateVSCodeSettings(assoc.Definition, assoc.FileMatch)
+
+		// Add the settings to the global settings
+		yamlSettings.add(newYAML)
+		jsonSettings.add(newJSON)
+	}
+
+	if len(yamlSettings) > 0 {
+		yamlout, err := yamlSettings.marshal()
+		if err != nil {
+			return err
+		}
+		cmd.Println("Add the following to VS Code's settings.json file to enable YAML file validation:\n\n" + yamlout + "\n")
+	}
+
+	if len(jsonSettings) > 0 {
+		jsonout, err := jsonSettings.marshal()
+		if err != nil {
+			return err
+		}
+		cmd.Println("Add the following to VS Code's settings.json file to enable JSON file validation:\n\n" + jsonout + "\n")
+	}
+
+	return nil
 }
 
 func copyFile(srcFS fs.FS, dstDir, path string) error {
@@ -143,6 +311,9 @@ func copyFile(srcFS fs.FS, dstDir, path string) error {
 	}
 
 	destFile := filepath.Join(dstDir, path)
+	if err := os.MkdirAll(filepath.Dir(destFile), 0o755); err != nil {
+		return fmt.Errorf("could not create directory for %q: %w", destFile, err)
+	}
 
 	dst, err := os.Create(destFile)
 	if err != nil {
@@ -156,24 +327,10 @@ func copyFile(srcFS fs.FS, dstDir, path string) error {
 	return nil
 }
 
-func generateVSCodeSettings(schemaFile string, fileMatches []string) (yamlRule vsCodeYAMLSchemaSettings, jsonRule vsCodeJSONSchemaSettings) {
-	// VS Code requires local file paths begin with "file://"
-	schemaFileURI := "file://" + schemaFile
-
-	yamlFiles := []string{}
-	jsonFiles := []string{}
+func generateVSCodeSettings(schemaFileURI string, fileMatches []string) (yamlRule vsCodeYAMLSchemaSettings, jsonRule vsCodeJSONSchemaSettings) {
 
 	// Process file matches to output settings to add to VS Code
-	for _, pattern := range fileMatches {
-		switch filepath.Ext(pattern) {
-		case ".yaml", ".yml":
-			// Add entry to the YAML schemas map
-			yamlFiles = append(yamlFiles, pattern)
-		case ".json":
-			// Add to list of file matches
-			jsonFiles = append(jsonFiles, pattern)
-		}
-	}
+	yamlFiles, jsonFiles := splitFileMatch(fileMatches)
 
 	// Only add the YAML setting if there were YAML files given
 	switch length := len(yamlFiles); {
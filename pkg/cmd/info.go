@@ -4,14 +4,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 
 	"github.com/act3-ai/go-common/pkg/embedutil"
+	"github.com/act3-ai/go-common/pkg/md"
 )
 
 // NewInfoCmd creates an info command that allows the viewing of embedded documentation
-// in the terminal, converted to Markdown
+// in the terminal, converted to Markdown. Documents can be searched with the
+// search subcommand, and a single section viewed with the --section flag.
 func NewInfoCmd(docs *embedutil.Documentation) *cobra.Command {
 	infoCmd := &cobra.Command{
 		Use:   "info <topic>",
@@ -20,7 +27,7 @@ func NewInfoCmd(docs *embedutil.Documentation) *cobra.Command {
 	}
 
 	// Add subcommands for each provided document
-	for _, cat := range docs.Categories {
+	for _, cat := range docs.OrderedCategories() {
 
 		// Add a command group for the category
 		infoCmd.AddGroup(&cobra.Group{
@@ -30,7 +37,7 @@ func NewInfoCmd(docs *embedutil.Documentation) *cobra.Command {
 
 		// Add subcommands for each document in the category
 		for _, doc := range cat.Docs {
-			subCmd := newDocCmd(doc)
+			subCmd := newDocCmd(docs, doc)
 
 			// Associate command with the category's command group
 			subCmd.GroupID = cat.Key
@@ -40,12 +47,170 @@ func NewInfoCmd(docs *embedutil.Documentation) *cobra.Command {
 		}
 	}
 
+	infoCmd.AddCommand(newInfoSearchCmd(docs))
+
 	return infoCmd
 }
 
+// allDocs lists the documents of all categories.
+func allDocs(docs *embedutil.Documentation) []*embedutil.Document {
+	var all []*embedutil.Document
+	for _, cat := range docs.OrderedCategories() {
+		all = append(all, cat.Docs...)
+	}
+	return all
+}
+
+// renderMarkdown expands and renders a document as Markdown.
+func renderMarkdown(docs *embedutil.Documentation, doc *embedutil.Document) (string, error) {
+	expanded, err := docs.Expand(doc)
+	if err != nil {
+		return "", fmt.Errorf("rendering document: %w", err)
+	}
+	contents, err := expanded.Render(embedutil.Markdown)
+	if err != nil {
+		return "", fmt.Errorf("rendering document: %w", err)
+	}
+	return string(contents), nil
+}
+
+// mdSection is a section of a Markdown document, starting at a header.
+type mdSection struct {
+	Title  string
+	Anchor string // Link fragment of the header, without the "#"
+	Level  int
+	Start  int // Index of the header line
+	End    int // Index after the last line of the section
+}
+
+// markdownSections finds the sections of the Markdown lines, ignoring code blocks.
+func markdownSections(lines []string) []mdSection {
+	var sections []mdSection
+	inCode := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		title := strings.TrimSpace(line[level:])
+		if title == "" || !strings.HasPrefix(line[level:], " ") {
+			continue
+		}
+		// close the previous sections at the same or a deeper level
+		for j := range sections {
+			if sections[j].End == 0 && sections[j].Level >= level {
+				sections[j].End = i
+			}
+		}
+		sections = append(sections, mdSection{
+			Title:  title,
+			Anchor: strings.TrimPrefix(md.HeaderLinkTarget(title), "#"),
+			Level:  level,
+			Start:  i,
+		})
+	}
+	for j := range sections {
+		if sections[j].End == 0 {
+			sections[j].End = len(lines)
+		}
+	}
+	return sections
+}
+
+// extractSection returns the section of the Markdown content with the anchor
+// or title (case-insensitive) name.
+func extractSection(content, name string) (string, error) {
+	name = strings.TrimPrefix(name, "#")
+	lines := strings.Split(content, "\n")
+	sections := markdownSections(lines)
+	for _, sec := range sections {
+		if sec.Anchor == strings.ToLower(name) || strings.EqualFold(sec.Title, name) {
+			return strings.Join(lines[sec.Start:sec.End], "\n"), nil
+		}
+	}
+	anchors := make([]string, 0, len(sections))
+	for _, sec := range sections {
+		anchors = append(anchors, sec.Anchor)
+	}
+	return "", fmt.Errorf("section %q not found, must be one of: %s", name, strings.Join(anchors, ", "))
+}
+
+// newInfoSearchCmd creates a command that searches the documents.
+func newInfoSearchCmd(docs *embedutil.Documentation) *cobra.Command {
+	var docKeys []string
+
+	cmd := &cobra.Command{
+		Use:   "search <pattern>",
+		Short: "Search the documentation",
+		Long: "Searches the documentation for lines matching the pattern, a case-insensitive regular expression. " +
+			"Matching lines are printed with the document name and line number.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			re, err := regexp.Compile("(?i)" + args[0])
+			if err != nil {
+				return fmt.Errorf("invalid pattern: %w", err)
+			}
+			out := termenv.NewOutput(cmd.OutOrStdout())
+			matches := 0
+			for _, doc := range allDocs(docs) {
+				if len(docKeys) > 0 && !slices.Contains(docKeys, doc.Key) {
+					continue
+				}
+				content, err := renderMarkdown(docs, doc)
+				if err != nil {
+					return err
+				}
+				for i, line := range strings.Split(content, "\n") {
+					locs := re.FindAllStringIndex(line, -1)
+					if len(locs) == 0 {
+						continue
+					}
+					matches++
+					cmd.Println(out.String(doc.Key).Foreground(out.Color("5")).String() + ":" +
+						out.String(strconv.Itoa(i+1)).Foreground(out.Color("2")).String() + ": " +
+						highlightMatches(out, line, locs))
+				}
+			}
+			if matches == 0 {
+				return fmt.Errorf("no matches for %q", args[0])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&docKeys, "doc", nil, "only search the named documents")
+	_ = cmd.RegisterFlagCompletionFunc("doc", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		var keys []cobra.Completion
+		for _, doc := range allDocs(docs) {
+			keys = append(keys, cobra.CompletionWithDesc(doc.Key, doc.Title))
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+// highlightMatches styles the matched ranges of the line.
+func highlightMatches(out *termenv.Output, line string, locs [][]int) string {
+	b := &strings.Builder{}
+	prev := 0
+	for _, loc := range locs {
+		b.WriteString(line[prev:loc[0]])
+		b.WriteString(out.String(line[loc[0]:loc[1]]).Bold().Foreground(out.Color("1")).String())
+		prev = loc[1]
+	}
+	b.WriteString(line[prev:])
+	return b.String()
+}
+
 // Creates a command to render a single document in the terminal
-func newDocCmd(doc *embedutil.Document) *cobra.Command {
-	var writeDir string
+func newDocCmd(docs *embedutil.Documentation, doc *embedutil.Document) *cobra.Command {
+	var writeDir, section string
 
 	cmd := &cobra.Command{
 		Use:   doc.Key,
@@ -53,10 +218,17 @@ func newDocCmd(doc *embedutil.Document) *cobra.Command {
 		Long:  fmt.Sprintf("View the %q document in your terminal.", doc.Title),
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			contents, err := doc.Render(embedutil.Markdown)
+			rendered, err := renderMarkdown(docs, doc)
 			if err != nil {
-				return fmt.Errorf("rendering document: %w", err)
+				return err
+			}
+			if section != "" {
+				rendered, err = extractSection(rendered, section)
+				if err != nil {
+					return err
+				}
 			}
+			contents := []byte(rendered)
 
 			if writeDir != "" {
 				if err := os.MkdirAll(writeDir, 0o775); err != nil {
@@ -81,6 +253,19 @@ func newDocCmd(doc *embedutil.Document) *cobra.Command {
 
 	cmd.Flags().StringVarP(&writeDir, "write", "w", "", "write the document to a Markdown file (optionally specify a target directory)")
 	cmd.Flags().Lookup("write").NoOptDefVal = "."
+	cmd.Flags().StringVarP(&section, "section", "s", "", "only view the section with the header's title or link anchor")
+	_ = cmd.RegisterFlagCompletionFunc("section", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		content, err := renderMarkdown(docs, doc)
+		if err != nil {
+			cobra.CompErrorln(err.Error())
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var anchors []cobra.Completion
+		for _, sec := range markdownSections(strings.Split(content, "\n")) {
+			anchors = append(anchors, cobra.CompletionWithDesc(sec.Anchor, sec.Title))
+		}
+		return anchors, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	return cmd
 }
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/act3-ai/go-common/pkg/config"
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/md"
+	"github.com/act3-ai/go-common/pkg/termdoc"
+	"github.com/act3-ai/go-common/pkg/termdoc/mdfmt"
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// VersionNotifier prints a notice when a newer release is available.
+//
+// The latest release is checked in the background while the command runs, at most
+// once per TTL, and cached between runs.
+//
+// Example:
+//
+//	notifier := &VersionNotifier{
+//		Source:    &GitHubReleases{Owner: "act3-ai", Repo: "example", AssetName: GoReleaserAssetName("example")},
+//		OptOutEnv: "ACE_EXAMPLE_NO_UPDATE_NOTIFIER",
+//	}
+//	notifier.Register(root)
+type VersionNotifier struct {
+	// Source finds the latest release.
+	Source ReleaseSource
+
+	// Version is the current version, defaults to [version.Get].
+	Version version.Info
+
+	// Client is used for all requests, defaults to [http.DefaultClient].
+	Client httputil.Client
+
+	// CacheFile stores the result of the last check, defaults to
+	// "$XDG_CACHE_HOME/<root command name>/version-check.json", see [config.CacheDir].
+	CacheFile string
+
+	// TTL is the time between checks, defaults to 24 hours.
+	TTL time.Duration
+
+	// Timeout is the maximum time to wait for the check after the command
+	// completes before skipping the notice, defaults to 1 second.
+	Timeout time.Duration
+
+	// OptOutEnv is an environment variable that disables the notifier when set to a true value.
+	OptOutEnv string
+
+	// Format renders the notice, defaults to [termdoc.AutoMarkdownFormat].
+	Format *mdfmt.Formatter
+}
+
+// versionCheck is the cached result of a version check.
+type versionCheck struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest"`
+	Notes     string    `json:"notes,omitempty"`
+}
+
+// Register checks for a newer release in the root command's PersistentPreRunE
+// and prints the notice to stderr in its PersistentPostRunE.
+//
+// Register should be called after the root command's persistent hooks are set. Subcommands
+// that set their own persistent hooks do not run the notifier.
+func (n *VersionNotifier) Register(root *cobra.Command) {
+	var result chan *versionCheck
+
+	preRunE := root.PersistentPreRunE
+	preRun := root.PersistentPreRun
+	root.PersistentPreRun = nil
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if n.enabled(cmd) {
+			result = make(chan *versionCheck, 1)
+			go func() {
+				result <- n.check(cmd.Context(), root.Name())
+			}()
+		}
+		switch {
+		case preRunE != nil:
+			return preRunE(cmd, args)
+		case preRun != nil:
+			preRun(cmd, args)
+		}
+		return nil
+	}
+
+	postRunE := root.PersistentPostRunE
+	postRun := root.PersistentPostRun
+	root.PersistentPostRun = nil
+	root.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+		switch {
+		case postRunE != nil:
+			if err := postRunE(cmd, args); err != nil {
+				return err
+			}
+		case postRun != nil:
+			postRun(cmd, args)
+		}
+		if result == nil {
+			return nil
+		}
+		timeout := n.Timeout
+		if timeout == 0 {
+			timeout = time.Second
+		}
+		select {
+		case check := <-result:
+			n.notify(cmd, root.Name(), check)
+		case <-time.After(timeout):
+		}
+		return nil
+	}
+}
+
+// enabled reports whether the notifier should run for the command.
+func (n *VersionNotifier) enabled(cmd *cobra.Command) bool {
+	if n.OptOutEnv != "" {
+		if optOut, err := strconv.ParseBool(os.Getenv(n.OptOutEnv)); err == nil && optOut {
+			return false
+		}
+	}
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "update":
+		return false
+	}
+	return true
+}
+
+// check returns the latest release, from the cache if it was checked within the TTL.
+// Returns nil if the check fails.
+//
+// Each attempt is recorded in the cache before the release source is queried, so a
+// check that fails or outlives the command is not retried until the TTL expires.
+func (n *VersionNotifier) check(ctx context.Context, name string) *versionCheck {
+	log := logger.FromContext(ctx)
+	ttl := n.TTL
+	if ttl == 0 {
+		ttl = 24 * time.Hour
+	}
+	cacheFile := n.CacheFile
+	if cacheFile == "" {
+		cacheFile = filepath.Join(config.CacheDir(name), "version-check.json")
+	}
+
+	cached := &versionCheck{}
+	if data, err := os.ReadFile(cacheFile); err == nil && json.Unmarshal(data, cached) == nil &&
+		time.Since(cached.CheckedAt) < ttl {
+		return cached
+	}
+
+	// Keep the last known release until the check succeeds
+	cached.CheckedAt = time.Now()
+	if err := writeVersionCheck(cacheFile, cached); err != nil {
+		log.Debug("Caching version check failed", slog.Any("error", err))
+	}
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	release, err := n.Source.Latest(ctx, client)
+	if err != nil {
+		log.Debug("Checking for a newer release failed", slog.Any("error", err))
+		return nil
+	}
+
+	check := &versionCheck{
+		CheckedAt: cached.CheckedAt,
+		Latest:    release.Version,
+		Notes:     release.Notes,
+	}
+	if err := writeVersionCheck(cacheFile, check); err != nil {
+		log.Debug("Caching version check failed", slog.Any("error", err))
+	}
+	return check
+}
+
+// writeVersionCheck writes the version check to the cache file.
+func writeVersionCheck(cacheFile string, check *versionCheck) error {
+	data, err := json.Marshal(check)
+	if err != nil {
+		return fmt.Errorf("encoding version check: %w", err)
+	}
+	if _, err := config.EnsureDir(filepath.Dir(cacheFile), config.DirPerm); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	if err := os.WriteFile(cacheFile, data, 0o644); err != nil {
+		return fmt.Errorf("writing cache file: %w", err)
+	}
+	return nil
+}
+
+// notify prints the notice if the latest release is newer than the current version.
+func (n *VersionNotifier) notify(cmd *cobra.Command, name string, check *versionCheck) {
+	if check == nil {
+		return
+	}
+	info := n.Version
+	if info.Version == "" {
+		info = version.Get()
+	}
+	current, latest := canonicalVersion(info.Version), canonicalVersion(check.Latest)
+	if !semver.IsValid(current) || !semver.IsValid(latest) || semver.Compare(latest, current) <= 0 {
+		return
+	}
+
+	notice := fmt.Sprintf("A new release of %s is available: %s → %s\n",
+		md.Bold(name), md.Code(current), md.Code(latest))
+	if check.Notes != "" {
+		notice += "\nRelease notes: " + check.Notes + "\n"
+	}
+	if update, _, err := cmd.Root().Find([]string{"update"}); err == nil && update != cmd.Root() {
+		notice += "\nRun " + md.Code(update.CommandPath()) + " to update.\n"
+	}
+
+	format := n.Format
+	if format == nil {
+		format = termdoc.AutoMarkdownFormat()
+	}
+	fmt.Fprint(cmd.ErrOrStderr(), "\n"+format.Format(notice))
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// fakeReleaseSource returns the result of latest, counting calls.
+type fakeReleaseSource struct {
+	calls  atomic.Int32
+	latest func(ctx context.Context) (*Release, error)
+}
+
+func (s *fakeReleaseSource) Latest(ctx context.Context, _ httputil.Client) (*Release, error) {
+	s.calls.Add(1)
+	return s.latest(ctx)
+}
+
+// runNotifier executes a root command with the notifier registered, returning its stderr.
+func runNotifier(t *testing.T, n *VersionNotifier) string {
+	t.Helper()
+	root := &cobra.Command{
+		Use: "sample",
+		Run: func(*cobra.Command, []string) {},
+	}
+	n.Register(root)
+	stderr := &strings.Builder{}
+	root.SetErr(stderr)
+	root.SetArgs(nil)
+	require.NoError(t, root.ExecuteContext(t.Context()))
+	return stderr.String()
+}
+
+func TestVersionNotifier(t *testing.T) {
+	source := &fakeReleaseSource{latest: func(context.Context) (*Release, error) {
+		return &Release{Version: "v1.1.0", Notes: "https://example.com/releases/v1.1.0"}, nil
+	}}
+	n := &VersionNotifier{
+		Source:    source,
+		Version:   version.Info{Version: "v1.0.0"},
+		CacheFile: filepath.Join(t.TempDir(), "version-check.json"),
+	}
+
+	out := runNotifier(t, n)
+	assert.Contains(t, out, "A new release of")
+	assert.Contains(t, out, "v1.1.0")
+	assert.Contains(t, out, "https://example.com/releases/v1.1.0")
+
+	// Cached within the TTL
+	out = runNotifier(t, n)
+	assert.Contains(t, out, "v1.1.0")
+	assert.Equal(t, int32(1), source.calls.Load())
+
+	// Current version is the latest
+	n.Version = version.Info{Version: "v1.1.0"}
+	assert.Empty(t, runNotifier(t, n))
+}
+
+func TestVersionNotifierFailure(t *testing.T) {
+	source := &fakeReleaseSource{latest: func(context.Context) (*Release, error) {
+		return nil, errors.New("network is unreachable")
+	}}
+	n := &VersionNotifier{
+		Source:    source,
+		Version:   version.Info{Version: "v1.0.0"},
+		CacheFile: filepath.Join(t.TempDir(), "version-check.json"),
+	}
+
+	assert.Empty(t, runNotifier(t, n))
+	assert.Empty(t, runNotifier(t, n))
+	assert.Equal(t, int32(1), source.calls.Load(), "failed checks are not retried within the TTL")
+
+	// Retried after the TTL
+	n.TTL = time.Nanosecond
+	assert.Empty(t, runNotifier(t, n))
+	assert.Equal(t, int32(2), source.calls.Load())
+}
+
+func TestVersionNotifierTimeout(t *testing.T) {
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	source := &fakeReleaseSource{latest: func(context.Context) (*Release, error) {
+		started <- struct{}{} // the attempt is recorded before the source is called
+		<-release
+		return nil, errors.New("timed out")
+	}}
+	n := &VersionNotifier{
+		Source:    source,
+		Version:   version.Info{Version: "v1.0.0"},
+		CacheFile: filepath.Join(t.TempDir(), "version-check.json"),
+		Timeout:   10 * time.Millisecond,
+	}
+
+	start := time.Now()
+	assert.Empty(t, runNotifier(t, n))
+	<-started
+	assert.Empty(t, runNotifier(t, n))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), source.calls.Load(), "slow checks are not retried within the TTL")
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+// ErrNoReleaseAsset is returned when a release has no asset for the current platform.
+var ErrNoReleaseAsset = errors.New("no release asset for platform")
+
+// Release describes a released binary for the current platform.
+type Release struct {
+	Version   string // Semantic version of the release, such as "v1.2.3"
+	URL       string // Download URL of the binary, or a .tar.gz or .zip archive containing it
+	Checksum  string // Hex-encoded SHA-256 checksum of the download
+	Signature string // Download URL of a signature for the download, verified by [UpdateOptions.Verify]
+	Notes     string // Release notes or a link to them
+}
+
+// ReleaseSource finds the latest release.
+type ReleaseSource interface {
+	Latest(ctx context.Context, client httputil.Client) (*Release, error)
+}
+
+// AssetNameFunc produces the name of the release asset for the version, operating system, and architecture.
+type AssetNameFunc func(version, goos, goarch string) string
+
+// GoReleaserAssetName produces GoReleaser's default archive name for a binary, "<name>_<version>_<goos>_<goarch>.tar.gz".
+func GoReleaserAssetName(name string) AssetNameFunc {
+	return func(version, goos, goarch string) string {
+		ext := ".tar.gz"
+		if goos == "windows" {
+			ext = ".zip"
+		}
+		return fmt.Sprintf("%s_%s_%s_%s%s", name, strings.TrimPrefix(version, "v"), goos, goarch, ext)
+	}
+}
+
+// GitHubReleases finds the latest release of a GitHub repository.
+//
+// The checksum is read from the ChecksumsAsset, in the format produced by sha256sum.
+type GitHubReleases struct {
+	Owner          string        // Repository owner
+	Repo           string        // Repository name
+	AssetName      AssetNameFunc // Name of the binary asset
+	ChecksumsAsset string        // Name of the checksums asset, defaults to "checksums.txt"
+	BaseURL        string        // API base URL, defaults to "https://api.github.com"
+}
+
+// Latest implements [ReleaseSource].
+func (s *GitHubReleases) Latest(ctx context.Context, client httputil.Client) (*Release, error) {
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	var resp struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/repos/%s/%s/releases/latest", baseURL, s.Owner, s.Repo), &resp); err != nil {
+		return nil, err
+	}
+	assets := map[string]string{}
+	for _, a := range resp.Assets {
+		assets[a.Name] = a.URL
+	}
+	return releaseFromAssets(ctx, client, resp.TagName, resp.HTMLURL, assets, s.AssetName, s.ChecksumsAsset)
+}
+
+// GitLabReleases finds the latest release of a GitLab project.
+//
+// The checksum is read from the ChecksumsAsset link, in the format produced by sha256sum.
+type GitLabReleases struct {
+	Project        string        // Project ID or path, such as "group/project"
+	AssetName      AssetNameFunc // Name of the binary asset link
+	ChecksumsAsset string        // Name of the checksums asset link, defaults to "checksums.txt"
+	BaseURL        string        // Instance URL, defaults to "https://gitlab.com"
+}
+
+// Latest implements [ReleaseSource].
+func (s *GitLabReleases) Latest(ctx context.Context, client httputil.Client) (*Release, error) {
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+	var resp struct {
+		TagName string `json:"tag_name"`
+		Links   struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+		Assets struct {
+			Links []struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"links"`
+		} `json:"assets"`
+	}
+	releaseURL := fmt.Sprintf("%s/api/v4/projects/%s/releases/permalink/latest", baseURL, url.PathEscape(s.Project))
+	if err := getJSON(ctx, client, releaseURL, &resp); err != nil {
+		return nil, err
+	}
+	assets := map[string]string{}
+	for _, l := range resp.Assets.Links {
+		assets[l.Name] = l.URL
+	}
+	return releaseFromAssets(ctx, client, resp.TagName, resp.Links.Self, assets, s.AssetName, s.ChecksumsAsset)
+}
+
+// ReleaseManifest finds the latest release from a static JSON manifest.
+//
+// Example manifest:
+//
+//	{
+//	  "version": "v1.2.3",
+//	  "notes": "https://example.com/releases/v1.2.3",
+//	  "assets": {
+//	    "linux/amd64": {
+//	      "url": "https://example.com/releases/v1.2.3/example-linux-amd64",
+//	      "sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+//	      "signature": "https://example.com/releases/v1.2.3/example-linux-amd64.sig"
+//	    }
+//	  }
+//	}
+type ReleaseManifest struct {
+	URL string // URL of the manifest
+}
+
+// Latest implements [ReleaseSource].
+func (s *ReleaseManifest) Latest(ctx context.Context, client httputil.Client) (*Release, error) {
+	var manifest struct {
+		Version string `json:"version"`
+		Notes   string `json:"notes"`
+		Assets  map[string]struct {
+			URL       string `json:"url"`
+			SHA256    string `json:"sha256"`
+			Signature string `json:"signature"`
+		} `json:"assets"`
+	}
+	if err := getJSON(ctx, client, s.URL, &manifest); err != nil {
+		return nil, err
+	}
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	asset, ok := manifest.Assets[platform]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrNoReleaseAsset, platform)
+	}
+	return &Release{
+		Version:   manifest.Version,
+		URL:       asset.URL,
+		Checksum:  asset.SHA256,
+		Signature: asset.Signature,
+		Notes:     manifest.Notes,
+	}, nil
+}
+
+// releaseFromAssets finds the binary and its checksum in the release assets.
+func releaseFromAssets(ctx context.Context, client httputil.Client, version, notes string, assets map[string]string, assetName AssetNameFunc, checksumsAsset string) (*Release, error) {
+	name := assetName(version, runtime.GOOS, runtime.GOARCH)
+	assetURL, ok := assets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %s/%s: %s", ErrNoReleaseAsset, runtime.GOOS, runtime.GOARCH, name)
+	}
+	release := &Release{
+		Version:   version,
+		URL:       assetURL,
+		Signature: assets[name+".sig"],
+		Notes:     notes,
+	}
+
+	if checksumsAsset == "" {
+		checksumsAsset = "checksums.txt"
+	}
+	if checksumsURL, ok := assets[checksumsAsset]; ok {
+		checksum, err := lookupChecksum(ctx, client, checksumsURL, name)
+		if err != nil {
+			return nil, err
+		}
+		release.Checksum = checksum
+	}
+	return release, nil
+}
+
+// lookupChecksum finds the checksum of the named file in a checksums file in the format produced by sha256sum.
+func lookupChecksum(ctx context.Context, client httputil.Client, checksumsURL, name string) (string, error) {
+	body, err := get(ctx, client, checksumsURL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading checksums: %w", err)
+	}
+	return "", fmt.Errorf("checksum for %s not found in %s", name, checksumsURL)
+}
+
+// getJSON decodes the JSON response from the URL.
+func getJSON(ctx context.Context, client httputil.Client, u string, v any) error {
+	body, err := get(ctx, client, u)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response from %s: %w", u, err)
+	}
+	return nil
+}
+
+// get requests the URL, returning the response body for successful responses.
+func get(ctx context.Context, client httputil.Client, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %s: %w", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("requesting %s: %s", u, resp.Status)
+	}
+	return resp.Body, nil
+}
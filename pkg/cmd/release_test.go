@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveFiles serves the files by escaped path, responding 404 to other requests.
+// The "{{server}}" placeholder in files is replaced with the server URL.
+func serveFiles(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, strings.ReplaceAll(data, "{{server}}", server.URL))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// assetName is the name of the test release asset for this platform.
+var assetName = GoReleaserAssetName("example")("v1.2.3", runtime.GOOS, runtime.GOARCH)
+
+func TestGoReleaserAssetName(t *testing.T) {
+	name := GoReleaserAssetName("example")
+	assert.Equal(t, "example_1.2.3_linux_amd64.tar.gz", name("v1.2.3", "linux", "amd64"))
+	assert.Equal(t, "example_1.2.3_windows_arm64.zip", name("1.2.3", "windows", "arm64"))
+}
+
+func TestGitHubReleases(t *testing.T) {
+	checksums := "0123  other.tar.gz\nabcd  " + assetName + "\n"
+	server := serveFiles(t, map[string]string{
+		"/repos/act3-ai/example/releases/latest": `{
+			"tag_name": "v1.2.3",
+			"html_url": "https://github.com/act3-ai/example/releases/v1.2.3",
+			"assets": [
+				{"name": "` + assetName + `", "browser_download_url": "{{server}}/download/` + assetName + `"},
+				{"name": "` + assetName + `.sig", "browser_download_url": "{{server}}/download/` + assetName + `.sig"},
+				{"name": "checksums.txt", "browser_download_url": "{{server}}/download/checksums.txt"}
+			]
+		}`,
+		"/repos/act3-ai/other/releases/latest": `{"tag_name": "v1.0.0", "assets": []}`,
+		"/download/checksums.txt":              checksums,
+	})
+
+	source := &GitHubReleases{Owner: "act3-ai", Repo: "example", AssetName: GoReleaserAssetName("example"), BaseURL: server.URL}
+	release, err := source.Latest(t.Context(), server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, &Release{
+		Version:   "v1.2.3",
+		URL:       server.URL + "/download/" + assetName,
+		Checksum:  "abcd",
+		Signature: server.URL + "/download/" + assetName + ".sig",
+		Notes:     "https://github.com/act3-ai/example/releases/v1.2.3",
+	}, release)
+
+	source.Repo = "other"
+	_, err = source.Latest(t.Context(), server.Client())
+	require.ErrorIs(t, err, ErrNoReleaseAsset)
+
+	source.Repo = "missing"
+	_, err = source.Latest(t.Context(), server.Client())
+	require.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestGitLabReleases(t *testing.T) {
+	server := serveFiles(t, map[string]string{
+		"/api/v4/projects/group%2Fexample/releases/permalink/latest": `{
+			"tag_name": "v1.2.3",
+			"_links": {"self": "https://gitlab.com/group/example/-/releases/v1.2.3"},
+			"assets": {"links": [
+				{"name": "` + assetName + `", "url": "{{server}}/download/` + assetName + `"},
+				{"name": "sha256sums", "url": "{{server}}/download/sha256sums"}
+			]}
+		}`,
+		"/download/sha256sums": "abcd *" + assetName + "\n",
+	})
+
+	source := &GitLabReleases{Project: "group/example", AssetName: GoReleaserAssetName("example"), ChecksumsAsset: "sha256sums", BaseURL: server.URL}
+	release, err := source.Latest(t.Context(), server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, &Release{
+		Version:  "v1.2.3",
+		URL:      server.URL + "/download/" + assetName,
+		Checksum: "abcd",
+		Notes:    "https://gitlab.com/group/example/-/releases/v1.2.3",
+	}, release)
+
+	// Releases without the checksums asset have no checksum
+	source.ChecksumsAsset = ""
+	release, err = source.Latest(t.Context(), server.Client())
+	require.NoError(t, err)
+	assert.Empty(t, release.Checksum)
+}
+
+func TestReleaseManifest(t *testing.T) {
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	server := serveFiles(t, map[string]string{
+		"/manifest.json": `{
+			"version": "v1.2.3",
+			"notes": "https://example.com/releases/v1.2.3",
+			"assets": {
+				"` + platform + `": {"url": "https://example.com/example", "sha256": "abcd", "signature": "https://example.com/example.sig"}
+			}
+		}`,
+		"/other.json":   `{"version": "v1.2.3", "assets": {"plan9/386": {"url": "https://example.com/example"}}}`,
+		"/invalid.json": `{"version":`,
+	})
+
+	release, err := (&ReleaseManifest{URL: server.URL + "/manifest.json"}).Latest(t.Context(), server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, &Release{
+		Version:   "v1.2.3",
+		URL:       "https://example.com/example",
+		Checksum:  "abcd",
+		Signature: "https://example.com/example.sig",
+		Notes:     "https://example.com/releases/v1.2.3",
+	}, release)
+
+	_, err = (&ReleaseManifest{URL: server.URL + "/other.json"}).Latest(t.Context(), server.Client())
+	require.ErrorIs(t, err, ErrNoReleaseAsset)
+
+	_, err = (&ReleaseManifest{URL: server.URL + "/invalid.json"}).Latest(t.Context(), server.Client())
+	require.ErrorContains(t, err, "decoding response")
+}
+
+func Test_lookupChecksum(t *testing.T) {
+	server := serveFiles(t, map[string]string{
+		"/checksums.txt": "0123  example.zip\nabcd  example.tar.gz\nef01 *example.bin\nmalformed line here\n",
+	})
+	tests := []struct {
+		name    string
+		file    string
+		asset   string
+		want    string
+		wantErr string
+	}{
+		{name: "text mode", file: "/checksums.txt", asset: "example.tar.gz", want: "abcd"},
+		{name: "binary mode", file: "/checksums.txt", asset: "example.bin", want: "ef01"},
+		{name: "not found", file: "/checksums.txt", asset: "example", wantErr: "checksum for example not found"},
+		{name: "missing file", file: "/missing.txt", asset: "example.zip", wantErr: "404 Not Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lookupChecksum(t.Context(), server.Client(), server.URL+tt.file, tt.asset)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// Errors returned by the update command.
+var (
+	// ErrChecksumMismatch is returned when a downloaded release does not match its checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrNoChecksum is returned when a release does not provide a checksum.
+	ErrNoChecksum = errors.New("release does not provide a checksum")
+	// ErrNoSignature is returned when a release does not provide a signature but signature verification is configured.
+	ErrNoSignature = errors.New("release does not provide a signature")
+)
+
+// UpdateOptions configures the update command.
+type UpdateOptions struct {
+	// Source finds the latest release.
+	Source ReleaseSource
+
+	// Version is the current version, defaults to [version.Get].
+	Version version.Info
+
+	// Client is used for all requests, defaults to [http.DefaultClient].
+	Client httputil.Client
+
+	// Verify verifies the signature of the downloaded release, optional.
+	// If set, releases without a signature are rejected.
+	Verify func(ctx context.Context, data, signature []byte) error
+
+	// BinaryName is the name of the binary in release archives, defaults to the name of the running executable.
+	BinaryName string
+}
+
+// NewUpdateCmd creates the update command, which replaces the running executable with the latest release.
+//
+// Releases are found with [UpdateOptions.Source], such as [GitHubReleases], [GitLabReleases],
+// or [ReleaseManifest]. The download is verified with the release's SHA-256 checksum, and with
+// [UpdateOptions.Verify] if set, before the executable is atomically replaced.
+//
+// Example:
+//
+//	NewUpdateCmd(UpdateOptions{
+//		Source: &GitHubReleases{
+//			Owner:     "act3-ai",
+//			Repo:      "example",
+//			AssetName: GoReleaserAssetName("example"),
+//		},
+//	})
+func NewUpdateCmd(opts UpdateOptions) *cobra.Command {
+	var checkOnly, force bool
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update to the latest release",
+		Long: `Downloads the latest release, verifies its checksum, and replaces the running executable.
+
+Use --check-only to check for a newer release without installing it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+			if opts.Client == nil {
+				opts.Client = http.DefaultClient
+			}
+			if opts.Version.Version == "" {
+				opts.Version = version.Get()
+			}
+
+			release, err := opts.Source.Latest(ctx, opts.Client)
+			if err != nil {
+				return fmt.Errorf("finding latest release: %w", err)
+			}
+
+			current, latest := canonicalVersion(opts.Version.Version), canonicalVersion(release.Version)
+			switch {
+			case !semver.IsValid(latest):
+				return fmt.Errorf("latest release has invalid version %q", release.Version)
+			case !semver.IsValid(current):
+				fmt.Fprintf(out, "Current version %s is unknown, latest release is %s\n", opts.Version.Version, latest)
+				if !force {
+					if !checkOnly {
+						fmt.Fprintln(out, "Use --force to install the latest release")
+					}
+					return nil
+				}
+			case semver.Compare(latest, current) <= 0 && (!force || checkOnly):
+				fmt.Fprintf(out, "Already up to date (%s)\n", current)
+				return nil
+			case semver.Compare(latest, current) <= 0:
+				fmt.Fprintf(out, "Reinstalling %s (current %s)\n", latest, current)
+			default:
+				fmt.Fprintf(out, "A new release is available: %s (current %s)\n", latest, current)
+			}
+			if release.Notes != "" {
+				fmt.Fprintf(out, "Release notes: %s\n", release.Notes)
+			}
+			if checkOnly {
+				return nil
+			}
+
+			binary, err := downloadRelease(ctx, opts, release)
+			if err != nil {
+				return err
+			}
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("finding executable: %w", err)
+			}
+			if exe, err = replaceExecutable(exe, binary); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Updated %s to %s\n", exe, latest)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "check for a newer release without installing it")
+	cmd.Flags().BoolVar(&force, "force", false, "install the latest release even if it is not newer than the current version")
+
+	return cmd
+}
+
+// canonicalVersion adds the "v" prefix expected by [semver] to the version.
+func canonicalVersion(v string) string {
+	if v != "" && !strings.HasPrefix(v, "v") {
+		return "v" + v
+	}
+	return v
+}
+
+// downloadRelease downloads and verifies the release, returning the binary.
+func downloadRelease(ctx context.Context, opts UpdateOptions, release *Release) ([]byte, error) {
+	if release.Checksum == "" {
+		return nil, ErrNoChecksum
+	}
+	data, err := download(ctx, opts.Client, release.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, release.Checksum) {
+		return nil, fmt.Errorf("%w: got %s, want %s", ErrChecksumMismatch, got, release.Checksum)
+	}
+
+	if opts.Verify != nil {
+		if release.Signature == "" {
+			return nil, ErrNoSignature
+		}
+		signature, err := download(ctx, opts.Client, release.Signature)
+		if err != nil {
+			return nil, err
+		}
+		if err := opts.Verify(ctx, data, signature); err != nil {
+			return nil, fmt.Errorf("verifying release signature: %w", err)
+		}
+	}
+
+	name := opts.BinaryName
+	if name == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("finding executable: %w", err)
+		}
+		name = filepath.Base(exe)
+	}
+	return extractBinary(data, release.URL, name)
+}
+
+// download reads the response from the URL.
+func download(ctx context.Context, client httputil.Client, u string) ([]byte, error) {
+	body, err := get(ctx, client, u)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", u, err)
+	}
+	return data, nil
+}
+
+// extractBinary extracts the named binary from a .tar.gz or .zip archive.
+// Other downloads are returned unchanged.
+func extractBinary(data []byte, u, name string) ([]byte, error) {
+	switch {
+	case strings.HasSuffix(u, ".tar.gz"), strings.HasSuffix(u, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("reading release archive: %w", err)
+		}
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("reading release archive: %w", err)
+			}
+			if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == name {
+				return io.ReadAll(tr) //nolint:wrapcheck
+			}
+		}
+	case strings.HasSuffix(u, ".zip"):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("reading release archive: %w", err)
+		}
+		for _, f := range zr.File {
+			if path.Base(f.Name) != name || f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("reading release archive: %w", err)
+			}
+			defer rc.Close()
+			return io.ReadAll(rc) //nolint:wrapcheck
+		}
+	default:
+		return data, nil
+	}
+	return nil, fmt.Errorf("binary %q not found in release archive", name)
+}
+
+// replaceExecutable atomically replaces the executable with the binary, returning the
+// executable's path with symlinks resolved.
+func replaceExecutable(exe string, binary []byte) (string, error) {
+	exe, err := filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", fmt.Errorf("finding executable: %w", err)
+	}
+
+	// Write to the same directory so the rename is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(exe), "."+filepath.Base(exe)+".*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("writing new executable: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("writing new executable: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", fmt.Errorf("setting executable permissions: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		// Running executables cannot be replaced on Windows, but they can be renamed
+		old := exe + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return "", fmt.Errorf("moving current executable: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		return "", fmt.Errorf("replacing executable: %w", err)
+	}
+	return exe, nil
+}
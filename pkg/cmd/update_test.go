@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// staticSource is a [ReleaseSource] returning a fixed release.
+type staticSource struct {
+	release *Release
+	err     error
+}
+
+func (s staticSource) Latest(context.Context, httputil.Client) (*Release, error) {
+	return s.release, s.err
+}
+
+func TestNewUpdateCmd(t *testing.T) {
+	newer := &Release{Version: "1.2.0", Notes: "https://example.com/v1.2.0"}
+	tests := []struct {
+		name    string
+		current string
+		release *Release
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "new release",
+			current: "v1.1.0",
+			release: newer,
+			args:    []string{"--check-only"},
+			want:    "A new release is available: v1.2.0 (current v1.1.0)\nRelease notes: https://example.com/v1.2.0\n",
+		},
+		{
+			name:    "up to date",
+			current: "v1.2.0",
+			release: newer,
+			want:    "Already up to date (v1.2.0)\n",
+		},
+		{
+			name:    "newer than release",
+			current: "1.3.0",
+			release: newer,
+			want:    "Already up to date (v1.3.0)\n",
+		},
+		{
+			name:    "forced check",
+			current: "v1.2.0",
+			release: newer,
+			args:    []string{"--force", "--check-only"},
+			want:    "Already up to date (v1.2.0)\n",
+		},
+		{
+			name:    "reinstall",
+			current: "v1.2.0",
+			release: newer,
+			args:    []string{"--force"},
+			want:    "Reinstalling v1.2.0 (current v1.2.0)\nRelease notes: https://example.com/v1.2.0\n",
+			wantErr: ErrNoChecksum,
+		},
+		{
+			name:    "unknown version",
+			current: "devel",
+			release: newer,
+			want:    "Current version devel is unknown, latest release is v1.2.0\nUse --force to install the latest release\n",
+		},
+		{
+			name:    "unknown version check",
+			current: "devel",
+			release: newer,
+			args:    []string{"--check-only"},
+			want:    "Current version devel is unknown, latest release is v1.2.0\n",
+		},
+		{
+			name:    "unknown version forced",
+			current: "devel",
+			release: newer,
+			args:    []string{"--force"},
+			want:    "Current version devel is unknown, latest release is v1.2.0\nRelease notes: https://example.com/v1.2.0\n",
+			wantErr: ErrNoChecksum,
+		},
+		{
+			name:    "new release without checksum",
+			current: "v1.1.0",
+			release: &Release{Version: "v1.2.0"},
+			want:    "A new release is available: v1.2.0 (current v1.1.0)\n",
+			wantErr: ErrNoChecksum,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewUpdateCmd(UpdateOptions{
+				Source:  staticSource{release: tt.release},
+				Version: version.Info{Version: tt.current},
+			})
+			out := &strings.Builder{}
+			cmd.SetOut(out)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetArgs(tt.args)
+			err := cmd.ExecuteContext(t.Context())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestNewUpdateCmdErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  ReleaseSource
+		wantErr string
+	}{
+		{
+			name:    "source error",
+			source:  staticSource{err: errors.New("unavailable")},
+			wantErr: "finding latest release: unavailable",
+		},
+		{
+			name:    "invalid release version",
+			source:  staticSource{release: &Release{Version: "latest"}},
+			wantErr: `latest release has invalid version "latest"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewUpdateCmd(UpdateOptions{Source: tt.source, Version: version.Info{Version: "v1.0.0"}})
+			cmd.SetOut(&strings.Builder{})
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetArgs(nil)
+			require.EqualError(t, cmd.ExecuteContext(t.Context()), tt.wantErr)
+		})
+	}
+}
+
+// checksum is the hex-encoded SHA-256 checksum of the data.
+func checksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func Test_downloadRelease(t *testing.T) {
+	server := serveFiles(t, map[string]string{
+		"/example":     "binary",
+		"/example.sig": "signature",
+	})
+	verify := func(_ context.Context, data, signature []byte) error {
+		if string(signature) != "signature" || string(data) != "binary" {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+	rejectAll := func(context.Context, []byte, []byte) error {
+		return errors.New("bad signature")
+	}
+
+	tests := []struct {
+		name    string
+		release Release
+		verify  func(ctx context.Context, data, signature []byte) error
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			release: Release{URL: "/example", Checksum: checksum("binary")},
+		},
+		{
+			name:    "uppercase checksum",
+			release: Release{URL: "/example", Checksum: strings.ToUpper(checksum("binary"))},
+		},
+		{
+			name:    "signed",
+			release: Release{URL: "/example", Checksum: checksum("binary"), Signature: "/example.sig"},
+			verify:  verify,
+		},
+		{
+			name:    "missing checksum",
+			release: Release{URL: "/example"},
+			wantErr: ErrNoChecksum.Error(),
+		},
+		{
+			name:    "checksum mismatch",
+			release: Release{URL: "/example", Checksum: checksum("other")},
+			wantErr: ErrChecksumMismatch.Error(),
+		},
+		{
+			name:    "missing signature",
+			release: Release{URL: "/example", Checksum: checksum("binary")},
+			verify:  verify,
+			wantErr: ErrNoSignature.Error(),
+		},
+		{
+			name:    "signature not found",
+			release: Release{URL: "/example", Checksum: checksum("binary"), Signature: "/missing.sig"},
+			verify:  verify,
+			wantErr: "404 Not Found",
+		},
+		{
+			name:    "invalid signature",
+			release: Release{URL: "/example", Checksum: checksum("binary"), Signature: "/example.sig"},
+			verify:  rejectAll,
+			wantErr: "verifying release signature: bad signature",
+		},
+		{
+			name:    "download not found",
+			release: Release{URL: "/missing", Checksum: checksum("binary")},
+			wantErr: "404 Not Found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := tt.release
+			release.URL = server.URL + release.URL
+			if release.Signature != "" {
+				release.Signature = server.URL + release.Signature
+			}
+			opts := UpdateOptions{Client: server.Client(), Verify: tt.verify, BinaryName: "example"}
+			got, err := downloadRelease(t.Context(), opts, &release)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "binary", string(got))
+		})
+	}
+}
+
+// archiveEntry is a file or directory in a test archive.
+type archiveEntry struct {
+	name string
+	data string // directories have no data
+}
+
+func tarGz(t *testing.T, entries ...archiveEntry) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o755, Typeflag: tar.TypeDir}
+		if !strings.HasSuffix(e.name, "/") {
+			hdr.Typeflag, hdr.Size = tar.TypeReg, int64(len(e.data))
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(e.data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, entries ...archiveEntry) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(e.data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func Test_extractBinary(t *testing.T) {
+	// The directory has the same name as the binary and comes first
+	entries := []archiveEntry{
+		{name: "example/"},
+		{name: "example/README.md", data: "readme"},
+		{name: "example/example", data: "binary"},
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		url     string
+		binary  string
+		want    string
+		wantErr string
+	}{
+		{name: "tar.gz", data: tarGz(t, entries...), url: "example.tar.gz", binary: "example", want: "binary"},
+		{name: "tgz", data: tarGz(t, entries...), url: "example.tgz", binary: "example", want: "binary"},
+		{name: "zip", data: zipArchive(t, entries...), url: "example.zip", binary: "example", want: "binary"},
+		{name: "plain", data: []byte("binary"), url: "example", binary: "other", want: "binary"},
+		{name: "tar.gz missing binary", data: tarGz(t, entries...), url: "example.tar.gz", binary: "other", wantErr: `binary "other" not found`},
+		{name: "zip missing binary", data: zipArchive(t, entries...), url: "example.zip", binary: "other", wantErr: `binary "other" not found`},
+		{name: "tar.gz only directory", data: tarGz(t, entries[0]), url: "example.tar.gz", binary: "example", wantErr: `binary "example" not found`},
+		{name: "zip only directory", data: zipArchive(t, entries[0]), url: "example.zip", binary: "example", wantErr: `binary "example" not found`},
+		{name: "invalid tar.gz", data: []byte("binary"), url: "example.tar.gz", binary: "example", wantErr: "reading release archive"},
+		{name: "invalid zip", data: []byte("binary"), url: "example.zip", binary: "example", wantErr: "reading release archive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractBinary(tt.data, tt.url, tt.binary)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_replaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "example")
+	require.NoError(t, os.WriteFile(exe, []byte("old"), 0o600))
+
+	got, err := replaceExecutable(exe, []byte("new"))
+	require.NoError(t, err)
+	assert.Equal(t, exe, got)
+	data, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	info, err := os.Stat(exe)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	// Symlinks are resolved so the target is replaced
+	link := filepath.Join(t.TempDir(), "link")
+	require.NoError(t, os.Symlink(exe, link))
+	got, err = replaceExecutable(link, []byte("newer"))
+	require.NoError(t, err)
+	assert.Equal(t, exe, got)
+	data, err = os.ReadFile(link)
+	require.NoError(t, err)
+	assert.Equal(t, "newer", string(data))
+
+	// No temporary files are left behind
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	_, err = replaceExecutable(filepath.Join(dir, "missing"), []byte("new"))
+	require.ErrorContains(t, err, "finding executable")
+}
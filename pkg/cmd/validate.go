@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/spf13/cobra"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"sigs.k8s.io/yaml"
+)
+
+// errValidationFailed is returned by the validate command when any file is invalid
+var errValidationFailed = errors.New("validation failed")
+
+// NewValidateCmd creates the validate command, which validates configuration files against
+// the embedded JSON Schema definitions.
+//
+// The schema for each file is chosen by matching the file's name against the FileMatch
+// patterns of the associations. Every validation error in a file is reported with the
+// line and column of the invalid value.
+//
+// Example:
+//
+//	//go:embed schemas/*
+//	var schemaDefs embed.FS
+//
+//	associations := []SchemaAssociation{
+//		{
+//			Definition: "schemas/project-schema.json",
+//			FileMatch:  []string{".act3-pt.yaml"},
+//		},
+//	}
+//
+//	NewValidateCmd(schemaDefs, associations)
+func NewValidateCmd(schemaDefs fs.FS, associations []SchemaAssociation) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <file>...",
+		Short: "Validates configuration files",
+		Long:  `Validates YAML or JSON configuration files against their schema definitions.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schemas := map[string]*jsonschema.Schema{}
+
+			var failed int
+			for _, file := range args {
+				assoc, ok := findAssociation(associations, file)
+				if !ok {
+					return fmt.Errorf("no schema definition is associated with %q", file)
+				}
+
+				resolved, ok := schemas[assoc.Definition]
+				if !ok {
+					var err error
+					resolved, err = loadSchema(schemaDefs, assoc.Definition)
+					if err != nil {
+						return err
+					}
+					schemas[assoc.Definition] = resolved
+				}
+
+				problems, err := validateFile(resolved, file)
+				if err != nil {
+					return err
+				}
+				if len(problems) == 0 {
+					cmd.Printf("%s: valid\n", file)
+					continue
+				}
+				failed++
+				for _, p := range problems {
+					cmd.Println(p)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%w: %d of %d files are invalid", errValidationFailed, failed, len(args))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// findAssociation finds the schema association with a FileMatch pattern matching the file.
+//
+// Patterns are matched against the trailing elements of the file's path,
+// so "ace/sample/config.yaml" matches "/home/user/.config/ace/sample/config.yaml".
+func findAssociation(associations []SchemaAssociation, file string) (SchemaAssociation, bool) {
+	elems := strings.Split(filepath.ToSlash(filepath.Clean(file)), "/")
+	for _, assoc := range associations {
+		for _, pattern := range assoc.FileMatch {
+			pattern = filepath.ToSlash(pattern)
+			n := strings.Count(pattern, "/") + 1
+			if n > len(elems) {
+				continue
+			}
+			if ok, _ := path.Match(pattern, strings.Join(elems[len(elems)-n:], "/")); ok {
+				return assoc, true
+			}
+		}
+	}
+	return SchemaAssociation{}, false
+}
+
+// schemaLoader loads the schema definitions referenced by "file:///" URLs from the file system
+type schemaLoader struct {
+	fsys fs.FS
+}
+
+// Load implements jsonschema.URLLoader
+func (l schemaLoader) Load(url string) (any, error) {
+	name, ok := strings.CutPrefix(url, "file:///")
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema reference %q", url)
+	}
+	f, err := l.fsys.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer f.Close()
+	return jsonschema.UnmarshalJSON(f) //nolint:wrapcheck
+}
+
+// loadSchema loads and compiles a JSON Schema definition
+func loadSchema(schemaDefs fs.FS, path string) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(schemaLoader{schemaDefs})
+	schema, err := compiler.Compile("file:///" + path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load schema definition %q: %w", path, err)
+	}
+	return schema, nil
+}
+
+// problem is a validation error located in the file
+type problem struct {
+	line, column int
+	message      string
+}
+
+// validateFile validates the YAML or JSON file, returning a description of each problem found
+func validateFile(schema *jsonschema.Schema, file string) ([]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %q: %w", file, err)
+	}
+
+	// JSON is valid YAML, so the YAML node tree provides positions for both formats
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &root); err != nil {
+		return []string{fmt.Sprintf("%s: %v", file, err)}, nil
+	}
+
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", file, err)}, nil
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonData))
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", file, err)}, nil
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil, fmt.Errorf("could not validate %q: %w", file, err)
+	}
+
+	printer := message.NewPrinter(language.English)
+	var problems []problem
+	for _, leaf := range leafErrors(verr) {
+		p := problem{message: leaf.ErrorKind.LocalizedString(printer)}
+		if node := lookupNode(&root, leaf.InstanceLocation); node != nil {
+			p.line, p.column = node.Line, node.Column
+		}
+		problems = append(problems, p)
+	}
+	slices.SortStableFunc(problems, func(a, b problem) int {
+		return cmp.Or(cmp.Compare(a.line, b.line), cmp.Compare(a.column, b.column))
+	})
+
+	descriptions := make([]string, 0, len(problems))
+	for _, p := range problems {
+		if p.line == 0 {
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", file, p.message))
+			continue
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s:%d:%d: %s", file, p.line, p.column, p.message))
+	}
+	return descriptions, nil
+}
+
+// leafErrors returns the errors without causes, which locate each problem in the instance
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
+
+// lookupNode finds the YAML node at the instance location, or the deepest node found
+func lookupNode(node *yamlv3.Node, location []string) *yamlv3.Node {
+	if node.Kind == yamlv3.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	for _, token := range location {
+		var found *yamlv3.Node
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					found = node.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node.Content) {
+				found = node.Content[i]
+			}
+		}
+		if found == nil {
+			return node
+		}
+		node = found
+	}
+	return node
+}
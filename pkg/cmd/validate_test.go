@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var validateSchemas = fstest.MapFS{
+	"schemas/config.json": {Data: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$ref": "#/$defs/Config",
+  "$defs": {
+    "Config": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "servers": {"type": "array", "items": {"$ref": "server.json"}}
+      },
+      "required": ["name"],
+      "additionalProperties": false
+    }
+  }
+}`)},
+	"schemas/server.json": {Data: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "port": {"type": "integer", "maximum": 65535}
+  }
+}`)},
+}
+
+// runValidate executes the validate command on the files, returning its output.
+func runValidate(t *testing.T, files ...string) (string, error) {
+	t.Helper()
+	cmd := NewValidateCmd(validateSchemas, []SchemaAssociation{
+		{Definition: "schemas/config.json", FileMatch: []string{"config.yaml", "config.json"}},
+	})
+	out := &strings.Builder{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SilenceUsage = true
+	cmd.SetArgs(files)
+	err := cmd.ExecuteContext(t.Context())
+	return out.String(), err
+}
+
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(data), 0o600))
+	return file
+}
+
+func TestValidate(t *testing.T) {
+	file := writeFile(t, "config.yaml", "name: sample\nservers:\n  - port: 8080\n")
+	out, err := runValidate(t, file)
+	require.NoError(t, err)
+	assert.Equal(t, file+": valid\n", out)
+}
+
+func TestValidateProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []string
+	}{
+		{
+			name: "all problems reported",
+			file: "config.yaml",
+			data: "name: 1\nservers:\n  - port: 80\n  - port: 70000\nextra: true\n",
+			want: []string{
+				":1:7: got number, want string",
+				":4:11: maximum: got 70,000, want 65,535",
+				":1:1: additional properties 'extra' not allowed",
+			},
+		},
+		{
+			name: "missing property",
+			file: "config.yaml",
+			data: "servers: []\n",
+			want: []string{":1:1: missing property 'name'"},
+		},
+		{
+			name: "json",
+			file: "config.json",
+			data: "{\n  \"name\": \"sample\",\n  \"servers\": [{\"port\": \"http\"}]\n}\n",
+			want: []string{":3:24: got string, want integer"},
+		},
+		{
+			name: "syntax error",
+			file: "config.yaml",
+			data: "name: [\n",
+			want: []string{": yaml:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := writeFile(t, tt.file, tt.data)
+			out, err := runValidate(t, file)
+			require.ErrorIs(t, err, errValidationFailed)
+			for _, want := range tt.want {
+				assert.Contains(t, out, file+want)
+			}
+		})
+	}
+}
+
+func TestValidateUnassociated(t *testing.T) {
+	file := writeFile(t, "other.yaml", "name: sample\n")
+	_, err := runValidate(t, file)
+	require.ErrorContains(t, err, "no schema definition is associated")
+}
@@ -1,34 +1,210 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
+	"github.com/act3-ai/go-common/pkg/md"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/termdoc/mdfmt"
 	"github.com/act3-ai/go-common/pkg/version"
 )
 
+// Version output formats.
+const (
+	versionOutputText     = "text"
+	versionOutputJSON     = "json"
+	versionOutputYAML     = "yaml"
+	versionOutputMarkdown = "markdown"
+)
+
+// EmbeddedContent is content embedded in the binary, such as schemas or documentation,
+// whose digest is reported by the version command.
+type EmbeddedContent struct {
+	// Name identifies the content, such as "schemas"
+	Name string
+
+	// FS is the embedded content
+	FS fs.FS
+
+	// Digest is the digest of the content recorded at build time, checked by the
+	// --verify flag. Digests are computed with [version.DigestFS] and are typically
+	// recorded in a source file by go generate, or set at link time with
+	// -ldflags "-X main.schemasDigest=sha256:...".
+	Digest string
+}
+
+// EmbeddedDigest is the digest of embedded content reported by the version command.
+type EmbeddedDigest struct {
+	Name     string `json:"name"`
+	Digest   string `json:"digest"`
+	Recorded string `json:"recorded,omitempty"`
+}
+
+// versionOutput is the version info with the digests of embedded content.
+type versionOutput struct {
+	version.Info
+	Embedded []EmbeddedDigest `json:"embedded,omitempty"`
+}
+
 // versionOptions is the options for the version
 type versionOptions struct {
 	version.Info
-	Short bool
+	Content []EmbeddedContent
+	Short   bool
+	Deps    bool
+	Verify  bool
+	Output  string
+}
+
+// digests computes the digests of the embedded content.
+func (action *versionOptions) digests() ([]EmbeddedDigest, error) {
+	digests := make([]EmbeddedDigest, 0, len(action.Content))
+	for _, content := range action.Content {
+		digest, err := version.DigestFS(content.FS)
+		if err != nil {
+			return nil, fmt.Errorf("embedded %s: %w", content.Name, err)
+		}
+		digests = append(digests, EmbeddedDigest{
+			Name:     content.Name,
+			Digest:   digest,
+			Recorded: content.Digest,
+		})
+	}
+	return digests, nil
+}
+
+// verifyDigests checks the digests of the embedded content against the recorded digests.
+func verifyDigests(digests []EmbeddedDigest) error {
+	if len(digests) == 0 {
+		return errors.New("no embedded content to verify")
+	}
+	var errs []error
+	for _, d := range digests {
+		switch d.Recorded {
+		case "":
+			errs = append(errs, fmt.Errorf("embedded %s has no recorded digest", d.Name))
+		case d.Digest:
+		default:
+			errs = append(errs, fmt.Errorf("embedded %s digest %s does not match recorded digest %s", d.Name, d.Digest, d.Recorded))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // Run is the action method
 func (action *versionOptions) Run(out io.Writer) error {
+	digests, err := action.digests()
+	if err != nil {
+		return err
+	}
+	if action.Verify {
+		if err := verifyDigests(digests); err != nil {
+			return err
+		}
+	}
+
 	if action.Short {
 		_, err := fmt.Fprintln(out, action.Version)
 		return err
 	}
-	_, err := fmt.Fprintf(out, "%#v\n", action.Info)
-	return err
+
+	info := versionOutput{Info: action.Info, Embedded: digests}
+	if !action.Deps {
+		info.Deps = nil
+	}
+
+	switch action.Output {
+	case versionOutputJSON:
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding version info: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case versionOutputYAML:
+		data, err := yaml.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("encoding version info: %w", err)
+		}
+		_, err = out.Write(data)
+		return err //nolint:wrapcheck
+	case versionOutputMarkdown:
+		_, err := fmt.Fprint(out, versionMarkdown(info))
+		return err
+	default:
+		_, err := fmt.Fprintf(out, "%#v\n", info)
+		return err
+	}
+}
+
+// versionMarkdown formats the version info as markdown tables.
+func versionMarkdown(info versionOutput) string {
+	rows := [][]string{
+		{"Version", info.Version},
+	}
+	for _, row := range [][]string{
+		{"Commit", info.Commit},
+		{"Dirty", strconv.FormatBool(info.Dirty)},
+		{"Built", info.Built},
+		{"Go version", info.GoVersion},
+		{"Path", info.Path},
+		{"Platform", info.Platform},
+	} {
+		if row[1] != "" {
+			rows = append(rows, row)
+		}
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(md.Header(1, "Version") + "\n\n")
+	b.WriteString(mdfmt.WriteTable([]string{"Name", "Value"}, rows))
+
+	if len(info.Deps) > 0 {
+		depRows := make([][]string, 0, len(info.Deps))
+		for _, dep := range info.Deps {
+			replace := ""
+			if dep.Replace != nil {
+				replace = strings.TrimSpace(dep.Replace.Path + " " + dep.Replace.Version)
+			}
+			depRows = append(depRows, []string{md.Code(dep.Path), dep.Version, replace})
+		}
+		b.WriteString("\n" + md.Header(2, "Dependencies") + "\n\n")
+		b.WriteString(mdfmt.WriteTable([]string{"Module", "Version", "Replaced by"}, depRows))
+	}
+
+	if len(info.Embedded) > 0 {
+		embeddedRows := make([][]string, 0, len(info.Embedded))
+		for _, d := range info.Embedded {
+			recorded := d.Recorded
+			if recorded != "" {
+				recorded = md.Code(recorded)
+			}
+			embeddedRows = append(embeddedRows, []string{d.Name, md.Code(d.Digest), recorded})
+		}
+		b.WriteString("\n" + md.Header(2, "Embedded Content") + "\n\n")
+		b.WriteString(mdfmt.WriteTable([]string{"Name", "Digest", "Recorded digest"}, embeddedRows))
+	}
+
+	return b.String()
 }
 
 // NewVersionCmd creates a new "version" subcommand
-func NewVersionCmd(info version.Info) *cobra.Command {
+//
+// The digests of the embedded content are reported so a binary can be matched
+// to released artifacts. The --verify flag checks them against the recorded digests.
+func NewVersionCmd(info version.Info, content ...EmbeddedContent) *cobra.Command {
 	options := &versionOptions{
-		Info: info,
+		Info:    info,
+		Content: content,
 	}
 
 	cmd := &cobra.Command{
@@ -41,6 +217,14 @@ func NewVersionCmd(info version.Info) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&options.Short, "short", "s", false, "print just the version (not extra information)")
+	cmd.Flags().BoolVar(&options.Deps, "deps", false, "include the modules the binary was built with")
+	flagutil.ChoiceVarP(cmd.Flags(), &options.Output, "output", "o", versionOutputText,
+		[]string{versionOutputText, versionOutputJSON, versionOutputYAML, versionOutputMarkdown}, "output format")
+	if len(content) > 0 {
+		cmd.Flags().BoolVar(&options.Verify, "verify", false, "fail if the embedded content does not match the digests recorded at build time")
+	}
+	cmd.MarkFlagsMutuallyExclusive("short", "deps")
+	cmd.MarkFlagsMutuallyExclusive("short", "output")
 
 	return cmd
 }
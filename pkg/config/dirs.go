@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/adrg/xdg"
+)
+
+// Permissions used by [EnsureDir].
+const (
+	// DirPerm is the permission of cache, data, and state directories.
+	DirPerm os.FileMode = 0o755
+	// RuntimeDirPerm is the permission of runtime directories, which the XDG
+	// Base Directory specification requires to be private to the user.
+	RuntimeDirPerm os.FileMode = 0o700
+)
+
+// CacheDir returns the application's directory in $XDG_CACHE_HOME,
+// for non-essential data that can be regenerated.
+func CacheDir(parts ...string) string {
+	return filepath.Join(xdg.CacheHome, filepath.Join(parts...))
+}
+
+// DataDir returns the application's directory in $XDG_DATA_HOME,
+// for data files that should persist.
+func DataDir(parts ...string) string {
+	return filepath.Join(xdg.DataHome, filepath.Join(parts...))
+}
+
+// StateDir returns the application's directory in $XDG_STATE_HOME,
+// for data that should persist between runs but is not important enough
+// to back up, such as logs and history.
+func StateDir(parts ...string) string {
+	return filepath.Join(xdg.StateHome, filepath.Join(parts...))
+}
+
+// RuntimeDir returns the application's directory in $XDG_RUNTIME_DIR,
+// for runtime files such as sockets and lock files.
+//
+// If the runtime base directory does not exist, such as in containers without
+// a login session, a directory for the user in [os.TempDir] is used instead.
+func RuntimeDir(parts ...string) string {
+	base := xdg.RuntimeDir
+	if _, err := os.Stat(base); err != nil {
+		base = filepath.Join(os.TempDir(), "runtime-"+userID())
+	}
+	return filepath.Join(base, filepath.Join(parts...))
+}
+
+// userID identifies the current user for the runtime directory fallback.
+func userID() string {
+	if runtime.GOOS == "windows" {
+		return os.Getenv("USERNAME")
+	}
+	return strconv.Itoa(os.Getuid())
+}
+
+// EnsureDir creates the directory and any parents with the permission, returning the directory.
+// The permission of an existing directory is corrected if it is more permissive,
+// except on Windows where permissions are not supported.
+//
+//	dir, err := EnsureDir(CacheDir("ace", "dt"), DirPerm)
+func EnsureDir(dir string, perm os.FileMode) (string, error) {
+	if err := os.MkdirAll(dir, perm); err != nil {
+		return dir, fmt.Errorf("creating directory: %w", err)
+	}
+	if runtime.GOOS == "windows" {
+		return dir, nil
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return dir, fmt.Errorf("checking directory: %w", err)
+	}
+	if info.Mode().Perm()&^perm != 0 {
+		if err := os.Chmod(dir, perm); err != nil {
+			return dir, fmt.Errorf("setting directory permissions: %w", err)
+		}
+	}
+	return dir, nil
+}
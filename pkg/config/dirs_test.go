@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/adrg/xdg"
+)
+
+func TestDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_RUNTIME_DIR", filepath.Join(home, "runtime"))
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"cache", CacheDir("ace", "dt"), filepath.Join(home, "cache", "ace", "dt")},
+		{"data", DataDir("ace", "dt"), filepath.Join(home, "data", "ace", "dt")},
+		{"state", StateDir("ace", "dt"), filepath.Join(home, "state", "ace", "dt")},
+		// The runtime directory does not exist
+		{"runtime fallback", RuntimeDir("ace", "dt"), filepath.Join(os.TempDir(), "runtime-"+userID(), "ace", "dt")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+
+	if err := os.Mkdir(filepath.Join(home, "runtime"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := RuntimeDir("ace"), filepath.Join(home, "runtime", "ace"); got != want {
+		t.Errorf("RuntimeDir() = %q, want %q", got, want)
+	}
+}
+
+func TestEnsureDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	got, err := EnsureDir(dir, RuntimeDirPerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != dir {
+		t.Errorf("EnsureDir() = %q, want %q", got, dir)
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	// Existing directories that are too permissive are corrected
+	if err := os.Chmod(dir, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EnsureDir(dir, RuntimeDirPerm); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != RuntimeDirPerm {
+		t.Errorf("permission = %v, want %v", perm, RuntimeDirPerm)
+	}
+}
@@ -1,2 +1,17 @@
 // Package config provides configuration utilities for dealing with configuration from both environment variables and configuration files
+//
+// # Directories
+//
+// [DefaultConfigPath], [CacheDir], [DataDir], [StateDir], and [RuntimeDir] return
+// directories for an application in the XDG base directories. The base directories
+// fall back to the platform's conventions when the XDG environment variables are not set:
+//
+//	         Linux             macOS                          Windows
+//	Config   ~/.config         ~/Library/Application Support  %LOCALAPPDATA%
+//	Cache    ~/.cache          ~/Library/Caches               %LOCALAPPDATA%\cache
+//	Data     ~/.local/share    ~/Library/Application Support  %LOCALAPPDATA%
+//	State    ~/.local/state    ~/Library/Application Support  %LOCALAPPDATA%
+//	Runtime  /run/user/$UID    ~/Library/Application Support  %LOCALAPPDATA%
+//
+// Use [EnsureDir] to create them.
 package config
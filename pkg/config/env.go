@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding"
+
 	"github.com/act3-ai/go-common/pkg/config/env"
 )
 
@@ -47,3 +49,66 @@ var (
 	// otherwise returns 0 and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
 	EnvDuration = env.DurationOrError
 )
+
+// EnvT returns the named env variable parsed with parse if it exists,
+// otherwise returns the zero value and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+//
+// Use it with the Parse functions of this package, or any parser for other types:
+//
+//	id, err := config.EnvT("APP_ID", config.ParseText[uuid.UUID])
+//
+// See [env.Lookup] for details.
+func EnvT[T any](name string, parse func(string) (T, error)) (T, error) {
+	return env.Lookup(name, parse)
+}
+
+// EnvOrT returns the named env variable parsed with parse,
+// or the default if it is not set or cannot be parsed.
+//
+// See [env.LookupOr] for details.
+func EnvOrT[T any](name string, def T, parse func(string) (T, error)) T {
+	return env.LookupOr(name, def, parse)
+}
+
+// Parsers for [EnvT] and [EnvOrT], see the env package for details.
+var (
+	ParseString    = env.ParseString
+	ParseInt       = env.ParseInt
+	ParseBool      = env.ParseBool
+	ParseFloat64   = env.ParseFloat64
+	ParseUint      = env.ParseUint
+	ParseDuration  = env.ParseDuration
+	ParseTime      = env.ParseTime
+	ParseURL       = env.ParseURL
+	ParseStringMap = env.ParseStringMap
+	ParseBytesSize = env.ParseBytesSize
+)
+
+// ParseText parses a value of a type implementing [encoding.TextUnmarshaler].
+//
+// See [env.ParseText] for details.
+func ParseText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string) (T, error) {
+	return env.ParseText[T, PT](s)
+}
+
+// ParseList returns a parser splitting values on sep and parsing each item with parse.
+//
+// See [env.ParseList] for details.
+func ParseList[T any](sep string, parse func(string) (T, error)) func(string) ([]T, error) {
+	return env.ParseList(sep, parse)
+}
+
+// BindEnvStruct sets the fields of the struct pointed to by v from the environment variables
+// named by their "env" struct tags, joined to prefix.
+//
+// See [env.BindStruct] for details.
+var BindEnvStruct = env.BindStruct
+
+// BindEnvStructWithResolver sets the fields of the struct like [BindEnvStruct], expanding
+// references in environment variable values with resolve, such as [resolve.Resolver.Resolve].
+//
+// See [env.BindStructWithResolver] for details.
+var BindEnvStructWithResolver = env.BindStructWithResolver
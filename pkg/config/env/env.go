@@ -2,12 +2,16 @@
 package env
 
 import (
+	"encoding"
 	"errors"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // define errors for config
@@ -158,3 +162,207 @@ func DurationOrError(name string) (time.Duration, error) {
 	}
 	return parsedVal, nil
 }
+
+// Float64Or grabs the env variable as a float64 or the default
+func Float64Or(name string, def float64) float64 {
+	return LookupOr(name, def, ParseFloat64)
+}
+
+// Float64OrError returns the named env variable if it exists,
+// otherwise returns 0 and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+func Float64OrError(name string) (float64, error) {
+	return Lookup(name, ParseFloat64)
+}
+
+// UintOr grabs the env variable as a uint or the default
+func UintOr(name string, def uint) uint {
+	return LookupOr(name, def, ParseUint)
+}
+
+// UintOrError returns the named env variable if it exists,
+// otherwise returns 0 and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+func UintOrError(name string) (uint, error) {
+	return Lookup(name, ParseUint)
+}
+
+// TimeOr grabs the env variable as an RFC 3339 time or the default
+func TimeOr(name string, def time.Time) time.Time {
+	return LookupOr(name, def, ParseTime)
+}
+
+// TimeOrError returns the named env variable as an RFC 3339 time if it exists,
+// otherwise returns the zero time and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+func TimeOrError(name string) (time.Time, error) {
+	return Lookup(name, ParseTime)
+}
+
+// URLOr grabs the env variable as an absolute URL or the default
+func URLOr(name string, def *url.URL) *url.URL {
+	return LookupOr(name, def, ParseURL)
+}
+
+// URLOrError returns the named env variable as an absolute URL if it exists,
+// otherwise returns nil and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+func URLOrError(name string) (*url.URL, error) {
+	return Lookup(name, ParseURL)
+}
+
+// StringMapOr grabs the env variable as a map of "k=v,k2=v2" pairs or the default
+func StringMapOr(name string, def map[string]string) map[string]string {
+	return LookupOr(name, def, ParseStringMap)
+}
+
+// StringMapOrError returns the named env variable as a map of "k=v,k2=v2" pairs if it exists,
+// otherwise returns nil and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+func StringMapOrError(name string) (map[string]string, error) {
+	return Lookup(name, ParseStringMap)
+}
+
+// BytesSizeOr grabs the env variable as a number of bytes or the default.
+// Sizes use Kubernetes quantity suffixes, such as "10Mi" or "1G".
+func BytesSizeOr(name string, def int64) int64 {
+	return LookupOr(name, def, ParseBytesSize)
+}
+
+// BytesSizeOrError returns the named env variable as a number of bytes if it exists,
+// otherwise returns 0 and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+// Sizes use Kubernetes quantity suffixes, such as "10Mi" or "1G".
+func BytesSizeOrError(name string) (int64, error) {
+	return Lookup(name, ParseBytesSize)
+}
+
+// Lookup parses the named env variable with parse if it exists,
+// otherwise returns the zero value and either an ErrEnvVarNotFound or an ErrParseEnvVar error.
+//
+// Any type can be loaded by providing a parser, such as one of the Parse functions
+// in this package or [ParseText] for types implementing [encoding.TextUnmarshaler]:
+//
+//	id, err := Lookup("APP_ID", ParseText[uuid.UUID])
+func Lookup[T any](name string, parse func(string) (T, error)) (T, error) {
+	var zero T
+	if name == "" {
+		panic("name must not be empty")
+	}
+	envVal, ok := os.LookupEnv(name)
+	if !ok {
+		return zero, ErrEnvVarNotFound
+	}
+	parsedVal, err := parse(envVal)
+	if err != nil {
+		return zero, ErrParseEnvVar
+	}
+	return parsedVal, nil
+}
+
+// LookupOr parses the named env variable with parse,
+// or returns the default if it is not set or cannot be parsed.
+func LookupOr[T any](name string, def T, parse func(string) (T, error)) T {
+	ret, err := Lookup(name, parse)
+	if err != nil {
+		return def
+	}
+	return ret
+}
+
+// ParseString returns the value unchanged.
+func ParseString(s string) (string, error) {
+	return s, nil
+}
+
+// ParseInt parses a base 10 int.
+func ParseInt(s string) (int, error) {
+	return strconv.Atoi(s) //nolint:wrapcheck
+}
+
+// ParseBool parses a boolean as accepted by [strconv.ParseBool].
+func ParseBool(s string) (bool, error) {
+	return strconv.ParseBool(s) //nolint:wrapcheck
+}
+
+// ParseFloat64 parses a float64.
+func ParseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64) //nolint:wrapcheck
+}
+
+// ParseUint parses a base 10 uint.
+func ParseUint(s string) (uint, error) {
+	v, err := strconv.ParseUint(s, 10, 0)
+	return uint(v), err //nolint:wrapcheck
+}
+
+// ParseDuration parses a duration as accepted by [time.ParseDuration].
+func ParseDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(s) //nolint:wrapcheck
+}
+
+// ParseTime parses an RFC 3339 time.
+func ParseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s) //nolint:wrapcheck
+}
+
+// ParseURL parses an absolute URL.
+func ParseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if !u.IsAbs() {
+		return nil, ErrParseEnvVar
+	}
+	return u, nil
+}
+
+// ParseStringMap parses a map of "k=v,k2=v2" pairs.
+func ParseStringMap(s string) (map[string]string, error) {
+	m := map[string]string{}
+	if s == "" {
+		return m, nil
+	}
+	for pair := range strings.SplitSeq(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, ErrParseEnvVar
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return m, nil
+}
+
+// ParseBytesSize parses a number of bytes with Kubernetes quantity suffixes, such as "10Mi" or "1G".
+func ParseBytesSize(s string) (int64, error) {
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	return q.Value(), nil
+}
+
+// ParseText parses a value of a type implementing [encoding.TextUnmarshaler], such as [net.IP] or uuid.UUID.
+func ParseText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string) (T, error) {
+	var v T
+	err := PT(&v).UnmarshalText([]byte(s))
+	return v, err //nolint:wrapcheck
+}
+
+// ParseList returns a parser splitting values on sep and parsing each item with parse.
+// Whitespace around items is trimmed, and an empty value is an empty list.
+func ParseList[T any](sep string, parse func(string) (T, error)) func(string) ([]T, error) {
+	return func(s string) ([]T, error) {
+		if s == "" {
+			return []T{}, nil
+		}
+		items := strings.Split(s, sep)
+		list := make([]T, 0, len(items))
+		for _, item := range items {
+			v, err := parse(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+}
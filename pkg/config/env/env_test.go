@@ -0,0 +1,188 @@
+package env
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Setenv("TEST_IP", "10.0.0.1")
+	t.Setenv("TEST_PORTS", "80, 443")
+	t.Setenv("TEST_TIMEOUTS", "1s,2m")
+	t.Setenv("TEST_BAD", "nope")
+
+	ip, err := Lookup("TEST_IP", ParseText[net.IP])
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip.String())
+
+	ports, err := Lookup("TEST_PORTS", ParseList(",", ParseInt))
+	require.NoError(t, err)
+	assert.Equal(t, []int{80, 443}, ports)
+
+	timeouts, err := Lookup("TEST_TIMEOUTS", ParseList(",", ParseDuration))
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Minute}, timeouts)
+
+	_, err = Lookup("TEST_BAD", ParseInt)
+	require.ErrorIs(t, err, ErrParseEnvVar)
+
+	_, err = Lookup("TEST_UNSET", ParseText[net.IP])
+	require.ErrorIs(t, err, ErrEnvVarNotFound)
+
+	assert.Equal(t, 8080, LookupOr("TEST_BAD", 8080, ParseInt))
+	assert.Equal(t, 8080, LookupOr("TEST_UNSET", 8080, ParseInt))
+}
+
+func TestLookupOrEmpty(t *testing.T) {
+	t.Setenv("TEST_EMPTY", "")
+
+	// Set but empty values are parsed, falling back to the default only if parsing fails
+	assert.Empty(t, LookupOr("TEST_EMPTY", "default", ParseString))
+	assert.Equal(t, 8080, LookupOr("TEST_EMPTY", 8080, ParseInt))
+	assert.Equal(t, map[string]string{}, LookupOr("TEST_EMPTY", map[string]string{"k": "v"}, ParseStringMap))
+	assert.Equal(t, []int{}, LookupOr("TEST_EMPTY", []int{1}, ParseList(",", ParseInt)))
+
+	// Unset values use the default
+	assert.Equal(t, "default", LookupOr("TEST_UNSET", "default", ParseString))
+	assert.Equal(t, map[string]string{"k": "v"}, LookupOr("TEST_UNSET", map[string]string{"k": "v"}, ParseStringMap))
+
+	_, err := Lookup("TEST_EMPTY", ParseInt)
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	v, err := Lookup("TEST_EMPTY", ParseString)
+	require.NoError(t, err)
+	assert.Empty(t, v)
+}
+
+// parseCase is a test case for a parser.
+type parseCase[T any] struct {
+	in      string
+	want    T
+	wantErr bool
+}
+
+// testParse runs the test cases against the parser.
+func testParse[T any](t *testing.T, parse func(string) (T, error), tests []parseCase[T]) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parse(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFloat64(t *testing.T) {
+	testParse(t, ParseFloat64, []parseCase[float64]{
+		{in: "1.5", want: 1.5},
+		{in: "-2", want: -2},
+		{in: "1e3", want: 1000},
+		{in: "one", wantErr: true},
+		{in: "", wantErr: true},
+	})
+}
+
+func TestParseUint(t *testing.T) {
+	testParse(t, ParseUint, []parseCase[uint]{
+		{in: "0", want: 0},
+		{in: "42", want: 42},
+		{in: "-1", wantErr: true},
+		{in: "1.5", wantErr: true},
+		{in: "0x10", wantErr: true},
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	testParse(t, ParseTime, []parseCase[time.Time]{
+		{in: "2024-01-02T03:04:05Z", want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{in: "2024-01-02T03:04:05.5Z", want: time.Date(2024, 1, 2, 3, 4, 5, 5e8, time.UTC)},
+		{in: "2024-01-02", wantErr: true},
+		{in: "2024-01-02 03:04:05", wantErr: true},
+		{in: "yesterday", wantErr: true},
+	})
+}
+
+func TestParseURL(t *testing.T) {
+	testParse(t, ParseURL, []parseCase[*url.URL]{
+		{in: "https://example.com/path?q=1", want: &url.URL{Scheme: "https", Host: "example.com", Path: "/path", RawQuery: "q=1"}},
+		{in: "file:///tmp/data", want: &url.URL{Scheme: "file", Path: "/tmp/data"}},
+		{in: "/relative/path", wantErr: true},
+		{in: "example.com", wantErr: true},
+		{in: "https://example.com/%zz", wantErr: true},
+		{in: "", wantErr: true},
+	})
+}
+
+func TestParseStringMap(t *testing.T) {
+	testParse(t, ParseStringMap, []parseCase[map[string]string]{
+		{in: "", want: map[string]string{}},
+		{in: "k=v", want: map[string]string{"k": "v"}},
+		{in: "k=v, k2 = v2", want: map[string]string{"k": "v", "k2": "v2"}},
+		{in: "k=a=b,empty=", want: map[string]string{"k": "a=b", "empty": ""}},
+		{in: "k=v,k2", wantErr: true},
+		{in: "k", wantErr: true},
+	})
+}
+
+func TestParseBytesSize(t *testing.T) {
+	testParse(t, ParseBytesSize, []parseCase[int64]{
+		{in: "1024", want: 1024},
+		{in: "10Mi", want: 10 << 20},
+		{in: "1G", want: 1_000_000_000},
+		{in: "1.5G", want: 1_500_000_000},
+		{in: "1.5Gi", want: 3 << 29},
+		{in: "10MB", wantErr: true},
+		{in: "lots", wantErr: true},
+		{in: "", wantErr: true},
+	})
+}
+
+func TestTypedOr(t *testing.T) {
+	t.Setenv("TEST_FLOAT", "0.5")
+	t.Setenv("TEST_UINT", "7")
+	t.Setenv("TEST_NEGATIVE", "-7")
+	t.Setenv("TEST_TIME", "2024-01-02T03:04:05Z")
+	t.Setenv("TEST_URL", "https://example.com")
+	t.Setenv("TEST_RELATIVE_URL", "example.com/path")
+	t.Setenv("TEST_MAP", "a=1,b=2")
+	t.Setenv("TEST_BAD_MAP", "a=1,b")
+	t.Setenv("TEST_SIZE", "10Mi")
+	t.Setenv("TEST_BAD", "garbage")
+
+	assert.InDelta(t, 0.5, Float64Or("TEST_FLOAT", 1), 0)
+	assert.InDelta(t, 1.0, Float64Or("TEST_BAD", 1), 0)
+	assert.Equal(t, uint(7), UintOr("TEST_UINT", 1))
+	assert.Equal(t, uint(1), UintOr("TEST_NEGATIVE", 1))
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), TimeOr("TEST_TIME", time.Time{}))
+	assert.Equal(t, time.Unix(0, 0), TimeOr("TEST_BAD", time.Unix(0, 0)))
+	assert.Equal(t, "https://example.com", URLOr("TEST_URL", nil).String())
+	assert.Nil(t, URLOr("TEST_RELATIVE_URL", nil))
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, StringMapOr("TEST_MAP", nil))
+	assert.Nil(t, StringMapOr("TEST_BAD_MAP", nil))
+	assert.Equal(t, int64(10<<20), BytesSizeOr("TEST_SIZE", 0))
+	assert.Equal(t, int64(1), BytesSizeOr("TEST_BAD", 1))
+
+	_, err := Float64OrError("TEST_BAD")
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	_, err = UintOrError("TEST_NEGATIVE")
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	_, err = TimeOrError("TEST_BAD")
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	_, err = URLOrError("TEST_RELATIVE_URL")
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	_, err = StringMapOrError("TEST_BAD_MAP")
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	_, err = BytesSizeOrError("TEST_BAD")
+	require.ErrorIs(t, err, ErrParseEnvVar)
+	_, err = BytesSizeOrError("TEST_UNSET")
+	require.ErrorIs(t, err, ErrEnvVarNotFound)
+}
@@ -0,0 +1,243 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ErrUnsupportedType is returned by [BindStruct] for fields with types that cannot be parsed from environment variables.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ErrNotStructPointer is returned by [BindStruct] when given a value that is not a pointer to a struct.
+var ErrNotStructPointer = errors.New("value is not a pointer to a struct")
+
+// tagEnv is the struct tag naming a field's environment variable.
+const tagEnv = "env"
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	timeType     = reflect.TypeFor[time.Time]()
+	urlType      = reflect.TypeFor[url.URL]()
+	quantityType = reflect.TypeFor[resource.Quantity]()
+)
+
+// BindStruct sets the fields of the struct pointed to by v from the environment variables
+// named by their "env" struct tags, joined to prefix. Fields whose environment variable
+// is not set are not modified.
+//
+// Nested struct fields are walked with the field's tag and "_" appended to the prefix,
+// or the same prefix if the field has no tag. Fields without a tag, or with the tag "-", are skipped.
+//
+// Supported field types are strings, booleans, integers, unsigned integers, floats,
+// [time.Duration], [time.Time] (RFC 3339), [url.URL], [resource.Quantity], slices of
+// supported types (comma-separated), maps of strings ("k=v,k2=v2"), and pointers to
+// supported types.
+//
+// Example:
+//
+//	type Config struct {
+//		Port int `env:"PORT"`
+//		DB   struct {
+//			Host string `env:"HOST"`
+//		} `env:"DB"`
+//	}
+//
+//	// Reads ACE_EXAMPLE_PORT and ACE_EXAMPLE_DB_HOST
+//	err := env.BindStruct(&cfg, "ACE_EXAMPLE_")
+func BindStruct(v any, prefix string) error {
+	return BindStructWithResolver(context.Background(), v, prefix, nil)
+}
+
+// ResolveFunc expands a reference in an environment variable's value, such as "file:///run/secrets/password".
+type ResolveFunc func(ctx context.Context, value string) (string, error)
+
+// BindStructWithResolver sets the fields of the struct like [BindStruct], expanding
+// references in environment variable values with resolve before they are parsed.
+func BindStructWithResolver(ctx context.Context, v any, prefix string, resolve ResolveFunc) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%T: %w", v, ErrNotStructPointer)
+	}
+	b := &structBinder{ctx: ctx, resolve: resolve}
+	return b.bindFields(rv.Elem(), prefix)
+}
+
+// structBinder binds struct fields to environment variables.
+type structBinder struct {
+	ctx     context.Context
+	resolve ResolveFunc
+}
+
+// bindFields sets the fields of the struct value from environment variables.
+func (b *structBinder) bindFields(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+	var errs []error
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, hasTag := field.Tag.Lookup(tagEnv)
+		if name == "-" {
+			continue
+		}
+
+		value := rv.Field(i)
+		if isNestedStruct(field.Type) {
+			nestedPrefix := prefix
+			if name != "" {
+				nestedPrefix += name + "_"
+			}
+			if value.Kind() == reflect.Pointer && value.IsNil() {
+				// Only allocate nil structs if one of their fields is set
+				nested := reflect.New(field.Type.Elem())
+				if err := b.bindFields(nested.Elem(), nestedPrefix); err != nil {
+					errs = append(errs, err)
+				}
+				if !nested.Elem().IsZero() {
+					value.Set(nested)
+				}
+				continue
+			}
+			if err := b.bindFields(reflect.Indirect(value), nestedPrefix); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if !hasTag || name == "" {
+			continue
+		}
+
+		envName := prefix + name
+		envVal, ok := os.LookupEnv(envName)
+		if !ok {
+			continue
+		}
+		if b.resolve != nil {
+			resolved, err := b.resolve(b.ctx, envVal)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", envName, err))
+				continue
+			}
+			envVal = resolved
+		}
+		if err := setValue(value, envVal); err != nil {
+			errs = append(errs, fmt.Errorf("%s (field %s.%s): %w", envName, rt.Name(), field.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isNestedStruct reports whether the type is a struct (or pointer to a struct) whose fields should be walked.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType && t != urlType && t != quantityType
+}
+
+// setValue parses s into the value.
+func setValue(v reflect.Value, s string) error {
+	switch v.Type() {
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := ParseTime(s)
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case urlType:
+		u, err := ParseURL(s)
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.Set(reflect.ValueOf(*u))
+		return nil
+	case quantityType:
+		q, err := resource.ParseQuantity(s)
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.Set(reflect.ValueOf(q))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		if s != "" {
+			items = strings.Split(s, ",")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedType)
+		}
+		m, err := ParseStringMap(s)
+		if err != nil {
+			return ErrParseEnvVar
+		}
+		mv := reflect.MakeMapWithSize(v.Type(), len(m))
+		for k, val := range m {
+			mv.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), reflect.ValueOf(val).Convert(v.Type().Elem()))
+		}
+		v.Set(mv)
+	default:
+		return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedType)
+	}
+	return nil
+}
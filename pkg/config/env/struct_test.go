@@ -0,0 +1,57 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindStruct(t *testing.T) {
+	type database struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+	type config struct {
+		Name    string            `env:"NAME"`
+		Debug   bool              `env:"DEBUG"`
+		Timeout time.Duration     `env:"TIMEOUT"`
+		Ratio   *float64          `env:"RATIO"`
+		Tags    []string          `env:"TAGS"`
+		Labels  map[string]string `env:"LABELS"`
+		Skipped string            `env:"-"`
+		Unset   string            `env:"UNSET"`
+		DB      database          `env:"DB"`
+		Cache   *database         `env:"CACHE"`
+	}
+
+	t.Setenv("TEST_NAME", "example")
+	t.Setenv("TEST_DEBUG", "true")
+	t.Setenv("TEST_TIMEOUT", "5s")
+	t.Setenv("TEST_RATIO", "0.5")
+	t.Setenv("TEST_TAGS", "a, b")
+	t.Setenv("TEST_LABELS", "k=v,k2=v2")
+	t.Setenv("TEST_DB_HOST", "db.example.com")
+	t.Setenv("TEST_CACHE_PORT", "6379")
+
+	cfg := config{Unset: "default"}
+	require.NoError(t, BindStruct(&cfg, "TEST_"))
+
+	ratio := 0.5
+	assert.Equal(t, config{
+		Name:    "example",
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Ratio:   &ratio,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"k": "v", "k2": "v2"},
+		Unset:   "default",
+		DB:      database{Host: "db.example.com"},
+		Cache:   &database{Port: 6379},
+	}, cfg)
+
+	t.Setenv("TEST_DB_PORT", "not a number")
+	require.ErrorIs(t, BindStruct(&cfg, "TEST_"), ErrParseEnvVar)
+	require.ErrorIs(t, BindStruct(cfg, "TEST_"), ErrNotStructPointer)
+}
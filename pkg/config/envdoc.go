@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+// Struct tags read by [EnvDocs], in addition to the "env" tag read by [BindEnvStruct].
+const (
+	tagEnv     = "env"     // Environment variable name
+	tagDesc    = "desc"    // Short description
+	tagDefault = "default" // Default value, overrides the field's current value
+)
+
+// EnvDoc documents an environment variable.
+type EnvDoc struct {
+	Name        string       // Environment variable name
+	Type        options.Type // Type of the value
+	Description string       // Short description
+	Default     string       // Default value (as a string)
+}
+
+// EnvDocs documents the environment variables of a struct bound with [BindEnvStruct].
+//
+// Descriptions are read from the "desc" struct tag. Defaults are read from the "default"
+// struct tag, or the field's value in v if it is not the zero value.
+func EnvDocs(v any, prefix string) ([]EnvDoc, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv = reflect.Zero(rv.Type().Elem())
+			continue
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T: %w", v, options.ErrNotStruct)
+	}
+	return envFieldDocs(rv, prefix), nil
+}
+
+var (
+	envDurationType = reflect.TypeFor[time.Duration]()
+	envTimeType     = reflect.TypeFor[time.Time]()
+	envURLType      = reflect.TypeFor[url.URL]()
+	envQuantityType = reflect.TypeFor[resource.Quantity]()
+)
+
+// envFieldDocs documents the environment variables of the struct value's fields.
+func envFieldDocs(rv reflect.Value, prefix string) []EnvDoc {
+	var docs []EnvDoc
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get(tagEnv)
+		if name == "-" {
+			continue
+		}
+
+		value := rv.Field(i)
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+			if value.IsNil() {
+				value = reflect.Zero(ft)
+			} else {
+				value = value.Elem()
+			}
+		}
+		if ft.Kind() == reflect.Struct && ft != envTimeType && ft != envURLType && ft != envQuantityType {
+			nestedPrefix := prefix
+			if name != "" {
+				nestedPrefix += name + "_"
+			}
+			docs = append(docs, envFieldDocs(value, nestedPrefix)...)
+			continue
+		}
+		if name == "" {
+			continue
+		}
+
+		doc := EnvDoc{
+			Name:        prefix + name,
+			Type:        envType(ft),
+			Description: field.Tag.Get(tagDesc),
+		}
+		if def, ok := field.Tag.Lookup(tagDefault); ok {
+			doc.Default = def
+		} else if !value.IsZero() {
+			doc.Default = fmt.Sprint(value.Interface())
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// envType returns the option type of the field type.
+func envType(t reflect.Type) options.Type {
+	switch t {
+	case envDurationType:
+		return options.Duration
+	case envTimeType, envURLType, envQuantityType:
+		return options.String
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return options.Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return options.Integer
+	case reflect.Float32, reflect.Float64:
+		return options.Float
+	case reflect.Slice:
+		return options.List
+	case reflect.Map:
+		return options.StringMap
+	default:
+		return options.String
+	}
+}
+
+// EnvGroup produces an option group documenting the environment variables,
+// for use with [optionshelp] and generated documentation.
+//
+// [optionshelp]: https://pkg.go.dev/github.com/act3-ai/go-common/pkg/options/optionshelp
+func EnvGroup(key, title, description string, docs []EnvDoc) *options.Group {
+	g := &options.Group{
+		Key:         key,
+		Title:       title,
+		Description: description,
+	}
+	for _, doc := range docs {
+		opt := &options.Option{
+			Type:    doc.Type,
+			Name:    doc.Name,
+			Env:     doc.Name,
+			Default: doc.Default,
+			Short:   doc.Description,
+		}
+		if doc.Type == options.List || doc.Type == options.StringMap {
+			opt.ValueType = options.String
+		}
+		g.Options = append(g.Options, opt)
+	}
+	return g
+}
+
+// EnvSchema produces a JSON Schema for an object of the environment variables,
+// such as the "env" map of a container configuration.
+//
+// All values are strings, as they are in the environment.
+func EnvSchema(docs []EnvDoc) *jsonschema.Schema {
+	schema := &jsonschema.Schema{
+		Version:    jsonschema.Version,
+		Type:       "object",
+		Title:      "Environment variables",
+		Properties: jsonschema.NewProperties(),
+	}
+	for _, doc := range docs {
+		prop := &jsonschema.Schema{
+			Type:        "string",
+			Description: doc.Description,
+		}
+		if doc.Default != "" {
+			prop.Default = doc.Default
+		}
+		schema.Properties.Set(doc.Name, prop)
+	}
+	return schema
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/config/resolve"
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// Loader merges configuration from defaults, config files, environment variables, and flags.
+//
+// Configuration fields are defined by flags created with [options] functions, which record
+// each field's config file path and environment variable name. Values are applied with the
+// precedence default < profile < config file < env < flag, where profile defaults are
+// those of the profile selected by the flag created with [options.ProfileVar].
+//
+// Example:
+//
+//	loader := &config.Loader{
+//		Flags:       cmd.Flags(),
+//		ConfigFiles: config.DefaultConfigSearchPath("ace", "example", "config.yaml"),
+//	}
+//	values, err := loader.Load(ctx)
+type Loader struct {
+	// Flags defining the configuration fields, already parsed from the command line.
+	Flags *pflag.FlagSet
+
+	// ConfigFiles are the config file paths to merge, in order of precedence.
+	// Fields in earlier files override fields in later files, matching the
+	// order of [DefaultConfigSearchPath]. Missing files are skipped.
+	ConfigFiles []string
+
+	// Resolver expands references in the values of sensitive string options from config
+	// files and environment variables, such as "file:///run/secrets/password". Options
+	// are sensitive if marked with [options.Option.Sensitive]. Optional.
+	Resolver *resolve.Resolver
+
+	// origins maps config file fields to the file that set them
+	origins map[string]string
+}
+
+// Value describes the effective value of a configuration field and where it came from.
+type Value struct {
+	Flag   *pflag.Flag          // Flag defining the field
+	JSON   string               // Path to the field in the config file
+	Value  string               // Effective value of the field
+	Source flagutil.ValueSource // Source of the value
+	Name   string               // Name of the flag, environment variable, config file field, or profile that set the value
+	File   string               // Config file that set the value, if Source is [flagutil.SourceConfig]
+}
+
+// Load applies environment variables and config files to the flags and reports
+// the effective value and source of each configuration field.
+//
+// Config files that were loaded are logged with the logger from the context.
+func (l *Loader) Load(ctx context.Context) ([]Value, error) {
+	log := logger.FromContext(ctx)
+
+	// Environment variables are applied first, so config files do not override them
+	var errs []error
+	l.Flags.VisitAll(func(f *pflag.Flag) {
+		if err := flagutil.ParseEnvOverrides(f); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	// The profile is selected by flag or environment variable, so its defaults are applied after them
+	if err := options.ApplyProfiles(l.Flags); err != nil {
+		return nil, fmt.Errorf("applying profile: %w", err)
+	}
+
+	cfg, err := l.mergeConfigFiles(log)
+	if err != nil {
+		return nil, err
+	}
+	if err := options.BindConfig(l.Flags, cfg); err != nil {
+		return nil, fmt.Errorf("loading configuration: %w", err)
+	}
+	if l.Resolver != nil {
+		if err := l.resolveReferences(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return l.Values(), nil
+}
+
+// resolveReferences expands references in the values of sensitive string flags set from config files or environment variables.
+func (l *Loader) resolveReferences(ctx context.Context) error {
+	var errs []error
+	l.Flags.VisitAll(func(f *pflag.Flag) {
+		source, name := flagutil.GetValueSource(f)
+		if f.Value.Type() != "string" || !flagutil.IsSensitive(f) ||
+			(source != flagutil.SourceConfig && source != flagutil.SourceEnv) {
+			return
+		}
+		resolved, err := l.Resolver.Resolve(ctx, f.Value.String())
+		if err == nil {
+			err = f.Value.Set(resolved)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// Values reports the effective value and source of each configuration field.
+func (l *Loader) Values() []Value {
+	var values []Value
+	l.Flags.VisitAll(func(f *pflag.Flag) {
+		opt := options.FromFlag(f)
+		source, name := flagutil.GetValueSource(f)
+		v := Value{
+			Flag:   f,
+			JSON:   opt.JSON,
+			Value:  f.Value.String(),
+			Source: source,
+			Name:   name,
+		}
+		if source == flagutil.SourceConfig {
+			v.File = l.origins[name]
+		}
+		values = append(values, v)
+	})
+	return values
+}
+
+// mergeConfigFiles reads and merges the config files, recording the file that set each field.
+func (l *Loader) mergeConfigFiles(log *slog.Logger) (map[string]any, error) {
+	l.origins = map[string]string{}
+	cfg := map[string]any{}
+	// Merge from lowest to highest precedence
+	for _, filename := range slices.Backward(l.ConfigFiles) {
+		content, err := os.ReadFile(filename)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			log.Debug("Skipping config file",
+				slog.String("path", filename),
+				slog.Any("reason", err))
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("reading config file: %w", err)
+		}
+
+		fileCfg, err := options.DecodeConfig(content)
+		if err != nil {
+			return nil, fmt.Errorf("decoding config file %q: %w", filename, err)
+		}
+		mergeConfig(cfg, fileCfg, "", filename, l.origins)
+		log.Info("Using config file", slog.String("path", filename))
+	}
+	return cfg, nil
+}
+
+// mergeConfig merges src into dst, recording filename as the origin of each field set from src.
+// Objects are merged recursively, other values are replaced.
+func mergeConfig(dst, src map[string]any, prefix, filename string, origins map[string]string) {
+	for key, value := range src {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		srcObj, srcIsObj := value.(map[string]any)
+		dstObj, dstIsObj := dst[key].(map[string]any)
+		if srcIsObj && dstIsObj {
+			mergeConfig(dstObj, srcObj, path, filename, origins)
+			origins[path] = filename
+			continue
+		}
+		dst[key] = value
+		// Forget fields nested in the replaced value
+		maps.DeleteFunc(origins, func(field, _ string) bool {
+			return strings.HasPrefix(field, path+".")
+		})
+		recordOrigins(value, path, filename, origins)
+	}
+}
+
+// recordOrigins records filename as the origin of the field at path and the fields nested in its value.
+func recordOrigins(value any, path, filename string, origins map[string]string) {
+	origins[path] = filename
+	if obj, ok := value.(map[string]any); ok {
+		for key, v := range obj {
+			recordOrigins(v, path+"."+key, filename, origins)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/config/resolve"
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+func TestLoader(t *testing.T) {
+	var host, user, name string
+	var port int
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options.StringVar(f, &host, "localhost", &options.Option{JSON: "server.host", Flag: "host"})
+	options.IntVar(f, &port, 80, &options.Option{JSON: "server.port", Flag: "port"})
+	options.StringVar(f, &user, "", &options.Option{JSON: "server.user", Flag: "user", Env: "TEST_LOADER_USER"})
+	options.StringVar(f, &name, "default", &options.Option{JSON: "name", Flag: "name"})
+
+	dir := t.TempDir()
+	user1 := filepath.Join(dir, "user.yaml")
+	system := filepath.Join(dir, "system.yaml")
+	require.NoError(t, os.WriteFile(user1, []byte("server: {port: 8080}\n"), 0o600))
+	require.NoError(t, os.WriteFile(system, []byte("server: {host: example.com, port: 9090, user: admin}\n"), 0o600))
+
+	t.Setenv("TEST_LOADER_USER", "from-env")
+	require.NoError(t, f.Parse([]string{"--name", "from-flag"}))
+
+	loader := &Loader{
+		Flags:       f,
+		ConfigFiles: []string{user1, filepath.Join(dir, "missing.yaml"), system},
+	}
+	values, err := loader.Load(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", host)
+	assert.Equal(t, 8080, port, "earlier config file takes precedence")
+	assert.Equal(t, "from-env", user, "env overrides config")
+	assert.Equal(t, "from-flag", name, "flag overrides default")
+
+	want := map[string]Value{
+		"host": {JSON: "server.host", Value: "example.com", Source: flagutil.SourceConfig, Name: "server.host", File: system},
+		"port": {JSON: "server.port", Value: "8080", Source: flagutil.SourceConfig, Name: "server.port", File: user1},
+		"user": {JSON: "server.user", Value: "from-env", Source: flagutil.SourceEnv, Name: "TEST_LOADER_USER"},
+		"name": {JSON: "name", Value: "from-flag", Source: flagutil.SourceFlag, Name: "name"},
+	}
+	require.Len(t, values, len(want))
+	for _, v := range values {
+		w := want[v.Flag.Name]
+		w.Flag = v.Flag
+		assert.Equal(t, w, v, v.Flag.Name)
+	}
+}
+
+func TestLoaderProfile(t *testing.T) {
+	var profile, level, host string
+	var port int
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options.ProfileVar(f, &profile, "", &options.Option{Flag: "profile", Env: "TEST_LOADER_PROFILE", Choices: []string{"dev", "prod"}})
+	options.StringVar(f, &level, "info", &options.Option{JSON: "log.level", Flag: "log-level",
+		ProfileDefaults: map[string]string{"dev": "debug", "prod": "warn"}})
+	options.StringVar(f, &host, "localhost", &options.Option{JSON: "server.host", Flag: "host",
+		ProfileDefaults: map[string]string{"prod": "example.com"}})
+	options.IntVar(f, &port, 80, &options.Option{JSON: "server.port", Flag: "port",
+		ProfileDefaults: map[string]string{"prod": "443"}})
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("server: {host: internal.example.com}\n"), 0o600))
+
+	t.Setenv("TEST_LOADER_PROFILE", "prod")
+	require.NoError(t, f.Parse([]string{"--port", "8443"}))
+
+	loader := &Loader{Flags: f, ConfigFiles: []string{config}}
+	values, err := loader.Load(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "prod", profile, "profile selected by env")
+	assert.Equal(t, "warn", level, "profile overrides default")
+	assert.Equal(t, "internal.example.com", host, "config overrides profile")
+	assert.Equal(t, 8443, port, "flag overrides profile")
+
+	sources := map[string]flagutil.ValueSource{}
+	for _, v := range values {
+		sources[v.Flag.Name] = v.Source
+	}
+	assert.Equal(t, map[string]flagutil.ValueSource{
+		"profile":   flagutil.SourceEnv,
+		"log-level": flagutil.SourceProfile,
+		"host":      flagutil.SourceConfig,
+		"port":      flagutil.SourceFlag,
+	}, sources)
+}
+
+func TestLoaderResolver(t *testing.T) {
+	var password, token, endpoint, command string
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options.StringVar(f, &password, "", &options.Option{JSON: "password", Flag: "password", Sensitive: true})
+	options.StringVar(f, &token, "", &options.Option{JSON: "token", Flag: "token", Env: "TEST_RESOLVER_TOKEN", Sensitive: true})
+	options.StringVar(f, &endpoint, "", &options.Option{JSON: "endpoint", Flag: "endpoint"})
+	options.StringVar(f, &command, "", &options.Option{JSON: "command", Flag: "command", Sensitive: true})
+
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("hunter2\n"), 0o600))
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(
+		"password: file://"+secretFile+"\n"+
+			"endpoint: file://"+secretFile+"\n"+
+			"command: exec://echo pwned\n"), 0o600))
+	t.Setenv("TEST_RESOLVER_TOKEN", "env://TEST_RESOLVER_SECRET")
+	t.Setenv("TEST_RESOLVER_SECRET", "s3cr3t")
+
+	loader := &Loader{
+		Flags:       f,
+		ConfigFiles: []string{configFile},
+		Resolver:    resolve.New(),
+	}
+	_, err := loader.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", password)
+	assert.Equal(t, "s3cr3t", token)
+	assert.Equal(t, "file://"+secretFile, endpoint, "options that are not sensitive are not resolved")
+	assert.Equal(t, "exec://echo pwned", command, "exec is not enabled by default")
+
+	t.Setenv("TEST_RESOLVER_TOKEN", "env://TEST_RESOLVER_MISSING")
+	f.Lookup("token").Changed = false
+	_, err = loader.Load(t.Context())
+	require.ErrorIs(t, err, resolve.ErrUnresolvedReference)
+}
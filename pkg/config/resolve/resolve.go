@@ -0,0 +1,149 @@
+// Package resolve expands references to values stored elsewhere, such as "file:///run/secrets/password",
+// so secrets do not need to be stored inline in config files or flags.
+package resolve
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/act3-ai/go-common/pkg/config/env"
+)
+
+// ErrUnresolvedReference is returned when a referenced value cannot be found.
+var ErrUnresolvedReference = errors.New("unresolved reference")
+
+// Reference schemes supported by [New].
+const (
+	SchemeFile             = "file"       // file:///run/secrets/password; contents of the file
+	SchemeEnv              = "env"        // env://PASSWORD; value of the environment variable
+	SchemeExec             = "exec"       // exec://secret-tool lookup server reg.example.com; output of the command
+	SchemeKubernetesSecret = "k8s-secret" // k8s-secret://namespace/name/key; key of the Kubernetes secret
+)
+
+// Func resolves the reference (the value without the "scheme://" prefix) to a value.
+type Func func(ctx context.Context, ref string) (string, error)
+
+// Resolver expands references in configuration values, so secrets do not need to be stored inline in config files.
+//
+// Values of the form "scheme://ref" with a scheme in Schemes are replaced by the resolved value.
+// Other values are returned unchanged.
+type Resolver struct {
+	Schemes map[string]Func
+}
+
+// New creates a Resolver for the file and env schemes.
+//
+// The exec scheme runs commands, so it must be enabled explicitly with [Exec],
+// only when config files and environment variables are trusted:
+//
+//	r := resolve.New()
+//	r.Schemes[resolve.SchemeExec] = resolve.Exec()
+//
+// Use [KubernetesSecret] to add the k8s-secret scheme:
+//
+//	r := resolve.New()
+//	r.Schemes[resolve.SchemeKubernetesSecret] = resolve.KubernetesSecret(
+//		func(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+//			secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+//			if err != nil {
+//				return nil, err
+//			}
+//			return secret.Data, nil
+//		})
+func New() *Resolver {
+	return &Resolver{
+		Schemes: map[string]Func{
+			SchemeFile: resolveFile,
+			SchemeEnv:  resolveEnv,
+		},
+	}
+}
+
+// Resolve expands the value if it is a reference.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, ref, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, nil
+	}
+	resolve, ok := r.Schemes[scheme]
+	if !ok {
+		return value, nil
+	}
+	resolved, err := resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s reference: %w", scheme, err)
+	}
+	return resolved, nil
+}
+
+// Ensure Resolver.Resolve can be used with env.BindStructWithResolver
+var _ env.ResolveFunc = (*Resolver)(nil).Resolve
+
+// resolveFile reads the file, without a trailing newline.
+func resolveFile(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r"), nil
+}
+
+// resolveEnv looks up the environment variable.
+func resolveEnv(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q: %w", name, ErrUnresolvedReference)
+	}
+	return value, nil
+}
+
+// Exec creates a Func for the exec scheme, which runs the reference as
+// a command with the system shell and returns its trimmed output.
+//
+// Anyone who can set a resolved value can run commands, so only enable it for
+// trusted configuration.
+func Exec() Func {
+	return resolveExec
+}
+
+// resolveExec runs the command with the system shell, returning its trimmed output.
+func resolveExec(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		// #nosec G204
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running command: %w", err)
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
+
+// KubernetesSecret creates a Func for "namespace/name/key" references to
+// Kubernetes secrets, using getSecret to fetch the data of the secret.
+func KubernetesSecret(getSecret func(ctx context.Context, namespace, name string) (map[string][]byte, error)) Func {
+	return func(ctx context.Context, ref string) (string, error) {
+		parts := strings.Split(ref, "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return "", fmt.Errorf("invalid secret reference %q, want namespace/name/key", ref)
+		}
+		data, err := getSecret(ctx, parts[0], parts[1])
+		if err != nil {
+			return "", fmt.Errorf("getting secret %s/%s: %w", parts[0], parts[1], err)
+		}
+		value, ok := data[parts[2]]
+		if !ok {
+			return "", fmt.Errorf("key %q in secret %s/%s: %w", parts[2], parts[0], parts[1], ErrUnresolvedReference)
+		}
+		return string(value), nil
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+)
+
+// WatchDebounce is the time to wait after a config file changes before reloading
+// the configuration, so that multiple writes are handled as a single change.
+var WatchDebounce = 100 * time.Millisecond
+
+// Validator is implemented by configuration types that validate themselves.
+type Validator interface {
+	Validate() error
+}
+
+// Watch loads the configuration from the config files and calls onChange with the
+// configuration each time a config file changes, until the context is canceled.
+//
+// The config files are merged as by [Loader], with fields in earlier files overriding
+// fields in later files, and decoded into a C. If C (or *C) implements [Validator], the
+// configuration is validated before onChange is called. Missing files are skipped.
+//
+// The directories of the config files are watched, and the files are checked for
+// changes after any event in them, so files replaced by renaming or through symbolic
+// links, such as Kubernetes ConfigMap volumes, are reloaded.
+//
+// If the changed configuration fails to load or validate, the change is logged and
+// ignored. If onChange returns an error for a changed configuration, onChange is called
+// again with the previous configuration to roll back the change.
+//
+// Watch returns an error if the initial configuration cannot be loaded or is rejected by onChange.
+//
+// Example:
+//
+//	err := config.Watch(ctx, []string{"config.yaml"}, func(cfg v1alpha1.ServerConfig) error {
+//		return server.Reconfigure(cfg)
+//	})
+func Watch[C any](ctx context.Context, paths []string, onChange func(newCfg C) error) error {
+	log := logger.FromContext(ctx)
+	loader := &Loader{ConfigFiles: paths}
+
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("resolving config file path: %w", err)
+		}
+		files = append(files, abs)
+	}
+	states := statFiles(files)
+
+	current, err := loadConfig[C](log, loader)
+	if err != nil {
+		return err
+	}
+	if err := onChange(current); err != nil {
+		return fmt.Errorf("applying configuration: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating config file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directories to observe files that are created, or replaced by renaming
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if err := watcher.Add(dir); err != nil {
+			log.Debug("Skipping config file directory",
+				slog.String("path", dir),
+				slog.Any("reason", err))
+		}
+	}
+
+	debounce := time.NewTimer(WatchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Warn("Error watching config files", slog.Any("error", err))
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// Events for other files, such as a symbolic link swapped by Kubernetes,
+			// may change the config files, so check the files themselves
+			newStates := statFiles(files)
+			if slices.Equal(states, newStates) {
+				continue
+			}
+			states = newStates
+			log.Debug("Config file changed",
+				slog.String("path", event.Name),
+				slog.String("op", event.Op.String()))
+			debounce.Reset(WatchDebounce)
+		case <-debounce.C:
+			newCfg, err := loadConfig[C](log, loader)
+			if err != nil {
+				log.Error("Ignoring invalid configuration", slog.Any("error", err))
+				continue
+			}
+			if err := onChange(newCfg); err != nil {
+				log.Error("Rolling back configuration change", slog.Any("error", err))
+				if err := onChange(current); err != nil {
+					log.Error("Failed to roll back configuration change", slog.Any("error", err))
+				}
+				continue
+			}
+			log.Info("Reloaded configuration")
+			current = newCfg
+		}
+	}
+}
+
+// fileState identifies the content of a config file, following symbolic links.
+type fileState struct {
+	target  string // Resolved path of the file, empty if it does not exist
+	size    int64
+	modTime time.Time
+}
+
+// statFiles returns the state of each file.
+func statFiles(files []string) []fileState {
+	states := make([]fileState, len(files))
+	for i, file := range files {
+		target, err := filepath.EvalSymlinks(file)
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(target)
+		if err != nil {
+			continue
+		}
+		states[i] = fileState{target: target, size: info.Size(), modTime: info.ModTime()}
+	}
+	return states
+}
+
+// loadConfig merges the config files with the loader, decodes them into a C, and validates the configuration.
+func loadConfig[C any](log *slog.Logger, loader *Loader) (C, error) {
+	var cfg C
+	merged, err := loader.mergeConfigFiles(log)
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return cfg, fmt.Errorf("encoding configuration: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("decoding configuration: %w", err)
+	}
+
+	var v any = &cfg
+	if validator, ok := v.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return cfg, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchConfig struct {
+	Name string `json:"name"`
+}
+
+func (c *watchConfig) Validate() error {
+	if c.Name == "invalid" {
+		return errors.New("invalid name")
+	}
+	return nil
+}
+
+// startWatch runs Watch in the background, returning the configurations passed to onChange.
+// onChange rejects configurations with the name "rejected".
+func startWatch(t *testing.T, path string) <-chan watchConfig {
+	t.Helper()
+	changes := make(chan watchConfig, 10)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, []string{path}, func(cfg watchConfig) error {
+			changes <- cfg
+			if cfg.Name == "rejected" {
+				return errors.New("rejected")
+			}
+			return nil
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+	assert.Equal(t, watchConfig{Name: "initial"}, receive(t, changes))
+	return changes
+}
+
+// receive waits for the next configuration change.
+func receive(t *testing.T, changes <-chan watchConfig) watchConfig {
+	t.Helper()
+	select {
+	case cfg := <-changes:
+		return cfg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for configuration change")
+		return watchConfig{}
+	}
+}
+
+// assertNoChange asserts that the configuration does not change after the debounce interval.
+func assertNoChange(t *testing.T, changes <-chan watchConfig) {
+	t.Helper()
+	select {
+	case cfg := <-changes:
+		t.Errorf("unexpected configuration change: %+v", cfg)
+	case <-time.After(5 * WatchDebounce):
+	}
+}
+
+// setWatchDebounce sets WatchDebounce for the test.
+func setWatchDebounce(t *testing.T, d time.Duration) {
+	t.Helper()
+	previous := WatchDebounce
+	WatchDebounce = d
+	t.Cleanup(func() { WatchDebounce = previous })
+}
+
+func writeConfig(t *testing.T, path, name string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte("name: "+name+"\n"), 0o600))
+}
+
+func TestWatch(t *testing.T) {
+	setWatchDebounce(t, 50*time.Millisecond)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "initial")
+	changes := startWatch(t, path)
+
+	// Writes are debounced into a single change
+	writeConfig(t, path, "first")
+	writeConfig(t, path, "second")
+	assert.Equal(t, watchConfig{Name: "second"}, receive(t, changes))
+	assertNoChange(t, changes)
+
+	// Invalid configuration is ignored
+	writeConfig(t, path, "invalid")
+	assertNoChange(t, changes)
+
+	// Rejected configuration is rolled back
+	writeConfig(t, path, "rejected")
+	assert.Equal(t, watchConfig{Name: "rejected"}, receive(t, changes))
+	assert.Equal(t, watchConfig{Name: "second"}, receive(t, changes))
+	assertNoChange(t, changes)
+}
+
+func TestWatchSymlinkSwap(t *testing.T) {
+	setWatchDebounce(t, 50*time.Millisecond)
+
+	// Layout of a Kubernetes ConfigMap volume:
+	// config.yaml -> ..data/config.yaml, ..data -> ..v1
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0o755))
+	writeConfig(t, filepath.Join(dir, "..v1", "config.yaml"), "initial")
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), filepath.Join(dir, "config.yaml")))
+	changes := startWatch(t, filepath.Join(dir, "config.yaml"))
+
+	// Updates replace the ..data link by renaming
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0o755))
+	writeConfig(t, filepath.Join(dir, "..v2", "config.yaml"), "updated")
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+
+	assert.Equal(t, watchConfig{Name: "updated"}, receive(t, changes))
+}
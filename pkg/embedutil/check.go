@@ -0,0 +1,152 @@
+package embedutil
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+)
+
+// ErrOutOfDate is returned by [Documentation.Check] when the generated documentation differs from the output directory.
+var ErrOutOfDate = errors.New("documentation is out of date")
+
+// maxDiffCells limits the size of line diffs computed by [Documentation.Check].
+const maxDiffCells = 4_000_000
+
+// Check renders the documentation into a temporary directory and compares it to
+// the contents of outputDir, without modifying outputDir.
+//
+// If any file would be added or modified by generating the documentation, Check
+// returns an error wrapping [ErrOutOfDate] that describes the differences. Files in
+// outputDir that are not generated are ignored, since [Documentation.Write] leaves them in place.
+func (docs *Documentation) Check(ctx context.Context, outputDir string, opts *Options) error {
+	tempDir, err := os.MkdirTemp("", "gendocs-check-*")
+	if err != nil {
+		return fmt.Errorf("checking documentation: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Discard the output of Write, which reports the temporary directory
+	err = docs.Write(logger.NewContext(ctx, slog.New(slog.DiscardHandler)), tempDir, opts)
+	if err != nil {
+		return err
+	}
+
+	generated, err := fileHashes(tempDir)
+	if err != nil {
+		return fmt.Errorf("checking documentation: %w", err)
+	}
+	existing, err := fileHashes(outputDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("checking documentation: %w", err)
+	}
+
+	report := new(strings.Builder)
+	for _, path := range sortedKeys(generated) {
+		existingHash, ok := existing[path]
+		switch {
+		case !ok:
+			fmt.Fprintf(report, "added: %s\n", path)
+		case existingHash != generated[path]:
+			fmt.Fprintf(report, "modified: %s\n", path)
+			want, _ := os.ReadFile(filepath.Join(tempDir, path))
+			got, _ := os.ReadFile(filepath.Join(outputDir, path))
+			report.WriteString(lineDiff(string(got), string(want)))
+		}
+	}
+
+	if report.Len() > 0 {
+		return fmt.Errorf("%w: %s\n%s", ErrOutOfDate, outputDir, report)
+	}
+	return nil
+}
+
+// writeFileIfChanged writes data to the file, skipping the write if
+// the file's contents are unchanged so modification times are preserved.
+func writeFileIfChanged(name string, data []byte) error {
+	existing, err := os.ReadFile(name)
+	if err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return os.WriteFile(name, data, 0o644) //nolint:wrapcheck
+}
+
+// fileHashes computes the SHA-256 hash of each file in the directory, keyed by relative path.
+func fileHashes(dir string) (map[string][sha256.Size]byte, error) {
+	hashes := map[string][sha256.Size]byte{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		hashes[filepath.ToSlash(rel)] = sha256.Sum256(data)
+		return nil
+	})
+	return hashes, err //nolint:wrapcheck
+}
+
+// sortedKeys returns the sorted keys of the map.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// lineDiff produces the removed and added lines between two texts, based on their longest common subsequence.
+func lineDiff(a, b string) string {
+	x, y := strings.Split(a, "\n"), strings.Split(b, "\n")
+	if len(x)*len(y) > maxDiffCells {
+		return "  (file too large to diff)\n"
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := new(strings.Builder)
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(diff, "  %4d - %s\n", i+1, x[i])
+			i++
+		default:
+			fmt.Fprintf(diff, "  %4d + %s\n", j+1, y[j])
+			j++
+		}
+	}
+	return diff.String()
+}
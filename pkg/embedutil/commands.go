@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/act3-ai/go-common/pkg/md"
 	"github.com/act3-ai/go-common/pkg/options/cobrautil"
 	"github.com/act3-ai/go-common/pkg/options/flagutil"
 	"github.com/act3-ai/go-common/pkg/termdoc"
@@ -28,18 +29,12 @@ func renderMarkdownTree(cmd *cobra.Command, dir string, opts *Options) error {
 		return fmt.Errorf("command docs: %w", err)
 	}
 
-	buf := new(bytes.Buffer)
-
-	// Generate command
-	err = GenMarkdownCustom(cmd, buf)
+	content, err := commandMarkdown(cmd)
 	if err != nil {
 		return err
 	}
 
-	content := buf.String()
-	content = ansi.Strip(content)
-
-	err = os.WriteFile(dest, []byte(content), 0o644)
+	err = writeFileIfChanged(dest, content)
 	if err != nil {
 		return fmt.Errorf("command docs: %w", err)
 	}
@@ -58,6 +53,63 @@ func renderMarkdownTree(cmd *cobra.Command, dir string, opts *Options) error {
 	return nil
 }
 
+// renderHTMLTree renders HTML pages for the command and its subcommands,
+// in the command directory of outputDir.
+func (docs *Documentation) renderHTMLTree(cmd *cobra.Command, outputDir string, opts *Options) error {
+	pagePath := filepath.ToSlash(filepath.Join(docs.commandDir(opts), setExtension(commandFilePath(cmd, opts), "html")))
+	dest := filepath.Join(outputDir, filepath.FromSlash(pagePath))
+
+	// create parent directory
+	err := os.MkdirAll(filepath.Dir(dest), 0o775)
+	if err != nil {
+		return fmt.Errorf("command docs: %w", err)
+	}
+
+	content, err := commandMarkdown(cmd)
+	if err != nil {
+		return err
+	}
+	content, err = formatHTML(content)
+	if err != nil {
+		return err
+	}
+	content, err = docs.htmlPage(pagePath, cmd.CommandPath(), content, opts)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileIfChanged(dest, content)
+	if err != nil {
+		return fmt.Errorf("command docs: %w", err)
+	}
+
+	for _, cmdC := range cmd.Commands() {
+		if cmdC.Name() == "help" {
+			continue // skip help commands
+		}
+
+		err = docs.renderHTMLTree(cmdC, outputDir, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commandMarkdown produces the normalized markdown documentation of the command.
+func commandMarkdown(cmd *cobra.Command) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Generate command
+	err := GenMarkdownCustom(cmd, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(md.Normalize(ansi.Strip(buf.String()))), nil
+}
+
 func commandFilePath(cmd *cobra.Command, opts *Options) string {
 	switch {
 	case opts.Flat:
@@ -69,6 +69,10 @@ func (doc *Document) ManpageExt() string {
 
 // Render produces the document's content in the requested format
 func (doc *Document) Render(format Format) ([]byte, error) {
+	if render, ok := doc.renderers[format]; ok {
+		return render(doc.Contents)
+	}
+
 	conv := conversion{doc.encoding, format}
 	convFunc, ok := supportedConversions[conv]
 	if !ok {
@@ -1,8 +1,18 @@
 package embedutil
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/fs"
 	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/invopop/jsonschema"
+
+	"github.com/act3-ai/go-common/pkg/genschema"
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/optionshelp"
 )
 
 // Encoding represents an embedded document's encoding
@@ -131,3 +141,111 @@ func LoadJSONSchemaBytes(key, title, name string, data []byte) *Document {
 	}
 	return d
 }
+
+// LoadJSONSchemaMarkdown loads a JSON Schema definition and renders it
+// as a markdown reference Document with [genschema.SchemaDocs]
+// name must be the path to the definition in filesys
+func LoadJSONSchemaMarkdown(key, title, name string, filesys fs.FS) *Document {
+	data, err := fs.ReadFile(filesys, name)
+	if err != nil {
+		panic(err)
+	}
+
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		panic(fmt.Errorf("parsing JSON Schema definition %q: %w", name, err))
+	}
+
+	d := &Document{
+		Key:      key,
+		Title:    title,
+		name:     setExtension(strings.TrimSuffix(filepath.Base(name), ".json"), "md"),
+		Contents: []byte(genschema.SchemaDocs(schema, title)),
+		encoding: EncodingMarkdown,
+	}
+	return d
+}
+
+// NewSchemaCategory initializes a Category of markdown reference documents
+// for each JSON Schema definition in filesys
+//
+// Documents are keyed and titled by the file name.
+// Manpages for the category use extension 5 for file formats.
+func NewSchemaCategory(key, title, manpagePrefix string, filesys fs.FS, names ...string) *Category {
+	docs := make([]*Document, 0, len(names))
+	for _, name := range names {
+		// Trim the suffixes of files generated by genschema, such as "configuration-schema.json"
+		docKey := removeExtension(filepath.Base(name))
+		docKey = strings.TrimSuffix(strings.TrimSuffix(docKey, ".schema"), "-schema")
+		docs = append(docs, LoadJSONSchemaMarkdown(docKey, strcase.ToCamel(docKey), name, filesys))
+	}
+	return NewCategory(key, title, manpagePrefix, 5, docs...)
+}
+
+// LoadOptions renders a configuration options reference into a Document
+// with [optionshelp.MarkdownDoc]
+//
+// Manpage output is rendered by [optionshelp.ManDoc].
+func LoadOptions(key, title string, groups []*options.Group) *Document {
+	contents, err := optionshelp.MarkdownDoc(groups)
+	if err != nil {
+		panic(fmt.Errorf("documenting options %q: %w", key, err))
+	}
+
+	d := &Document{
+		Key:        key,
+		Title:      title,
+		name:       key + ".md",
+		Contents:   []byte(contents + "\n"),
+		manpageExt: 5,
+		encoding:   EncodingMarkdown,
+	}
+	d.renderers = map[Format]conversionFunc{
+		Manpage: func([]byte) ([]byte, error) {
+			name := removeExtension(d.RenderedName(Manpage))
+			page, err := optionshelp.ManDoc(name, groups)
+			return []byte(page), err
+		},
+	}
+	return d
+}
+
+// NewOptionsCategory initializes a Category containing a reference document
+// for the configuration options
+//
+// Manpages for the category use extension 5 for file formats.
+func NewOptionsCategory(key, title, manpagePrefix string, groups []*options.Group) *Category {
+	return NewCategory(key, title, manpagePrefix, 5, LoadOptions(key, title, groups))
+}
+
+// LoadOptionsReference renders a configuration API reference into a Document
+// with [optionshelp.ReferenceDoc]
+func LoadOptionsReference(key, title string, groups []*options.Group) *Document {
+	contents, err := optionshelp.ReferenceDoc(title, groups)
+	if err != nil {
+		panic(fmt.Errorf("documenting options %q: %w", key, err))
+	}
+
+	return &Document{
+		Key:        key,
+		Title:      title,
+		name:       key + ".md",
+		Contents:   []byte(contents + "\n"),
+		manpageExt: 5,
+		encoding:   EncodingMarkdown,
+	}
+}
+
+// NewFlagGroupsCategory initializes a Category containing a configuration API
+// reference for the option groups of the configuration type C, the same groups
+// used to register its flags
+//
+//	groups := options.FlagGroups[Config]{serverGroup, tlsGroup}
+//	overrides := groups.RegisterFlags(cmd.Flags())
+//	docs.Categories = append(docs.Categories,
+//		embedutil.NewFlagGroupsCategory("config", "Configuration Reference", root.Name(), groups))
+//
+// Manpages for the category use extension 5 for file formats.
+func NewFlagGroupsCategory[C any](key, title, manpagePrefix string, groups options.FlagGroups[C]) *Category {
+	return NewCategory(key, title, manpagePrefix, 5, LoadOptionsReference(key, title, groups.Groups()))
+}
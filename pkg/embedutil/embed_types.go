@@ -1,8 +1,13 @@
 package embedutil
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/iancoleman/strcase"
 	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/version"
 )
 
 // Documentation configures how different genres of
@@ -20,15 +25,33 @@ type Documentation struct {
 
 	// Categories stores a list of documentation sub-categories,
 	// allowing organization of generated documentation
-	// Ordering is obeyed in the indexer
+	// Categories are ordered by weight, then by their order in the list,
+	// see [Documentation.OrderedCategories]
 	Categories []*Category
+
+	// Version information and variables available to
+	// documents loaded with LoadMarkdownTemplate
+	Version   version.Info
+	Variables map[string]any
 }
 
 // Category is used to group documents
 type Category struct {
-	Key   string      // Key name for the category in kebab-case
-	Title string      // Readable name for the category (can include spaces)
-	Docs  []*Document // List of documents contained in the category
+	Key         string      // Key name for the category in kebab-case
+	Title       string      // Readable name for the category (can include spaces)
+	Description string      // Markdown description of the category, shown on its landing page and in the index
+	Weight      int         // Ordering weight, categories with lower weights are listed first
+	Docs        []*Document // List of documents contained in the category
+}
+
+// OrderedCategories returns the categories ordered by weight.
+// Categories with equal weights keep their order in Categories.
+func (docs *Documentation) OrderedCategories() []*Category {
+	cats := slices.Clone(docs.Categories)
+	slices.SortStableFunc(cats, func(a, b *Category) int {
+		return cmp.Compare(a.Weight, b.Weight)
+	})
+	return cats
 }
 
 // dirName produces the directory name used for the category
@@ -43,17 +66,21 @@ func (cat *Category) dirName() string {
 type Document struct {
 	Key           string   // Key name for the file in kebab-case
 	Title         string   // Human-readable title for the document
+	Summary       string   // Short description of the document, listed on its category's landing page
 	name          string   // Internal file name
 	manpageExt    int8     // Manpage extension for the file. Ex: 1 for normal docs, 5 for config docs
 	manpagePrefix string   // Prefix for the manpage version of this file
 	Contents      []byte   // Contents of the document
 	encoding      Encoding // Encoding of the file
+	template      bool     // Expand the contents as a Go template when rendered
+
+	renderers map[Format]conversionFunc // Renderers overriding the conversion of Contents to a format
 }
 
 // FindDocument returns the Document with the requested key
 func (docs *Documentation) FindDocument(key string) *Document {
 	// Show help for docs
-	for _, cat := range docs.Categories {
+	for _, cat := range docs.OrderedCategories() {
 		for _, doc := range cat.Docs {
 			if key == doc.Key {
 				return doc
@@ -3,8 +3,11 @@ package embedutil
 import (
 	"github.com/cpuguy83/go-md2man/v2/md2man"
 	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
 	"github.com/gomarkdown/markdown/html"
 	"github.com/gomarkdown/markdown/parser"
+
+	"github.com/act3-ai/go-common/pkg/md"
 )
 
 // Format represents the output format for embedded documents
@@ -19,6 +22,9 @@ const (
 
 	// Manpage represents manpage output
 	Manpage Format = "man"
+
+	// JSON represents a structured JSON document model
+	JSON Format = "json"
 )
 
 // indexable checks if the output format is indexable
@@ -38,41 +44,42 @@ func (f Format) IndexFile() string {
 	}
 }
 
+// formatMarkdown normalizes a markdown document so it passes markdownlint
+func formatMarkdown(data []byte) ([]byte, error) {
+	return []byte(md.Normalize(string(data))), nil
+}
+
 // formatManpage converts a markdown document to a roff format manpage
 func formatManpage(data []byte) ([]byte, error) {
 	return md2man.Render(data), nil
 }
 
-var htmlOpts = &copyOpts{
-	PathFunc: func(path string) (string, error) {
-		// Convert file extension to html
-		return setExtension(path, "html"), nil
-	},
-	ContentFunc: formatHTML,
-}
-
 // formatHTML converts a markdown document to HTML
+//
+// Front-matter is removed, relative links to markdown files are replaced with links to the converted
+// HTML files, code blocks of known languages are highlighted, and headings have anchor links.
+// The result is the body of a page, see [Documentation.htmlPage].
 func formatHTML(data []byte) ([]byte, error) {
 	// create markdown parser with extensions
 	extensions := parser.CommonExtensions | parser.AutoHeadingIDs | parser.NoEmptyLineBeforeBlock
 	p := parser.NewWithExtensions(extensions)
-	doc := p.Parse(data)
+	doc := p.Parse([]byte(stripFrontmatter(string(data))))
+
+	// Link to converted files
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if link, ok := node.(*ast.Link); ok && entering {
+			link.Destination = []byte(htmlLinkTarget(string(link.Destination)))
+		}
+		return ast.GoToNext
+	})
 
 	// create HTML renderer with extensions
-	htmlFlags := html.CommonFlags | html.HrefTargetBlank
-	opts := html.RendererOptions{Flags: htmlFlags}
+	// Links open in the same tab, so the navigation sidebar is kept
+	htmlFlags := html.CommonFlags
+	opts := html.RendererOptions{Flags: htmlFlags, RenderNodeHook: htmlRenderHook}
 	renderer := html.NewRenderer(opts)
 
-	out := markdown.Render(doc, renderer)
-
-	// // Add simple styling
-	// out = append([]byte(heredoc.Doc(`
-	// 	<head>
-	// 	<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/water.css@2/out/water.min.css">
-	// 	</head>
-	// `)), out...)
-
-	return out, nil
+	return markdown.Render(doc, renderer), nil
 }
 
 // represents a conversion from encoding format to output format
@@ -103,7 +110,7 @@ var (
 
 	// Maps an input and output format to a conversion function
 	supportedConversions = map[conversion]conversionFunc{
-		{EncodingMarkdown, Markdown}:   noopConversion,
+		{EncodingMarkdown, Markdown}:   formatMarkdown,
 		{EncodingMarkdown, Manpage}:    formatManpage,
 		{EncodingMarkdown, HTML}:       formatHTML,
 		{EncodingJSONSchema, Markdown}: noopConversion,
@@ -7,8 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/spf13/cobra"
-	"github.com/spf13/cobra/doc"
+	"github.com/act3-ai/go-common/pkg/logger"
 )
 
 // Options stores configuration for rendering embedded documentation
@@ -17,24 +16,40 @@ type Options struct {
 	Types  []DocType // Documentation types to generate
 	Index  bool      // Generate a documentation index file (format-dependent)
 	Flat   bool      // Generate documentation in a flat directory structure
+
+	// Generate all documentation in a single index file (format-dependent)
+	SinglePage bool
+
+	Links         LinkOptions // Rewriting of relative links in embedded documents
+	ValidateLinks bool        // Report broken links in embedded documents before writing output
+
+	HTML HTMLOptions // Theme and page template of HTML output
 }
 
 // Write outputs all embedded documentation in the outputDir
 func (docs *Documentation) Write(ctx context.Context, outputDir string, opts *Options) error {
+	if opts.ValidateLinks {
+		if err := docs.ValidateLinks(opts); err != nil {
+			return fmt.Errorf("validating documentation links: %w", err)
+		}
+	}
+
 	err := os.MkdirAll(outputDir, 0o775)
 	if err != nil {
 		return fmt.Errorf("writing documentation: %w", err)
 	}
 
-	if opts.TypeRequested(TypeCommands) && docs.Command != nil {
-		cmdDir := outputDir
-		if !opts.Flat && len(opts.Types) > 1 {
-			// Create FS for the category's docs
-			cmdDir = filepath.Join(outputDir, "cli")
-		}
+	if opts.Format == JSON {
+		return docs.writeJSON(ctx, outputDir, opts)
+	}
+
+	if opts.SinglePage && opts.Format.indexable() {
+		return docs.writeSinglePage(ctx, outputDir, opts)
+	}
 
+	if opts.TypeRequested(TypeCommands) && docs.Command != nil {
 		// Generate CLI documentation
-		err = renderCommandDocs(docs.Command, cmdDir, opts)
+		err = docs.renderCommandDocs(outputDir, opts)
 		if err != nil {
 			return err
 		}
@@ -42,7 +57,7 @@ func (docs *Documentation) Write(ctx context.Context, outputDir string, opts *Op
 
 	if opts.TypeRequested(TypeGeneral) {
 		// Generate each category
-		for _, cat := range docs.Categories {
+		for _, cat := range docs.OrderedCategories() {
 			catDir := outputDir
 			if !opts.Flat {
 				// Create directory for the category's docs
@@ -54,25 +69,72 @@ func (docs *Documentation) Write(ctx context.Context, outputDir string, opts *Op
 			}
 
 			for _, doc := range cat.Docs {
-				contents, err := doc.Render(opts.Format)
+				contents, err := docs.renderDocument(cat, doc, opts)
 				if err != nil {
 					return err
 				}
+				if opts.Format == HTML && doc.encoding != EncodingJSONSchema {
+					contents, err = docs.htmlPage(docs.outputPath(cat, doc, opts), doc.Title, contents, opts)
+					if err != nil {
+						return err
+					}
+				}
 
-				err = os.WriteFile(filepath.Join(catDir, doc.RenderedName(opts.Format)), contents, 0o644)
+				err = writeFileIfChanged(filepath.Join(catDir, doc.RenderedName(opts.Format)), contents)
 				if err != nil {
 					return fmt.Errorf("creating document: %w", err)
 				}
 			}
+
+			// Create a landing page for the category's directory
+			if opts.Index && !opts.Flat && opts.Format.indexable() && len(cat.Docs) > 0 {
+				index, err := docs.CategoryIndex(cat, opts)
+				if err != nil {
+					return err
+				}
+				err = writeFileIfChanged(filepath.Join(catDir, opts.Format.IndexFile()), index)
+				if err != nil {
+					return fmt.Errorf("creating category index: %w", err)
+				}
+			}
 		}
 	}
 
-	slog.InfoContext(ctx, "Generated documentation", slog.String("dir", outputDir), slog.String("format", string(opts.Format)))
+	logger.FromContext(ctx).InfoContext(ctx, "Generated documentation", slog.String("dir", outputDir), slog.String("format", string(opts.Format)))
+
+	return docs.writeIndex(ctx, outputDir, opts)
+}
+
+// renderDocument renders the document in the requested format, expanding templates and rewriting links.
+func (docs *Documentation) renderDocument(cat *Category, doc *Document, opts *Options) ([]byte, error) {
+	doc, err := docs.Expand(doc)
+	if err != nil {
+		return nil, err
+	}
+	rewritten := *doc
+	rewritten.Contents, _ = docs.rewriteLinks(cat, doc, opts)
+	return rewritten.Render(opts.Format)
+}
+
+func (docs *Documentation) writeSinglePage(ctx context.Context, outputDir string, opts *Options) error {
+	page, err := docs.SinglePage(opts)
+	if err != nil {
+		return err
+	}
+
+	pageFile := filepath.Join(outputDir, opts.Format.IndexFile())
+
+	err = writeFileIfChanged(pageFile, page)
+	if err != nil {
+		return fmt.Errorf("writing documentation: %w", err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "Generated single-page documentation", slog.String("file", pageFile), slog.String("format", string(opts.Format)))
 
-	return docs.writeIndex(outputDir, opts)
+	return nil
 }
 
-func (docs *Documentation) writeIndex(outputDir string, opts *Options) error {
+func (docs *Documentation) writeIndex(ctx context.Context, outputDir string, opts *Options) error {
 	// Check if we can index the output format and if it was requested
 	if !opts.Format.indexable() || !opts.Index {
 		return nil
@@ -90,58 +152,47 @@ func (docs *Documentation) writeIndex(outputDir string, opts *Options) error {
 
 	indexFile := filepath.Join(outputDir, opts.Format.IndexFile())
 
-	err = os.WriteFile(indexFile, index, 0o644)
+	err = writeFileIfChanged(indexFile, index)
 	if err != nil {
 		return fmt.Errorf("creating index: %w", err)
 	}
 
-	_, err = fmt.Println("Generated documentation index: " + indexFile)
-	if err != nil {
-		return err
-	}
+	logger.FromContext(ctx).InfoContext(ctx, "Generated documentation index", slog.String("file", indexFile))
 
 	return nil
 }
 
+// commandDir produces the directory of command documentation in the output directory.
+func (docs *Documentation) commandDir(opts *Options) string {
+	if !opts.Flat && len(opts.Types) > 1 {
+		return "cli"
+	}
+	return ""
+}
+
 // Render command documentation into the specified format
-func renderCommandDocs(cmd *cobra.Command, outputDir string, opts *Options) error {
+func (docs *Documentation) renderCommandDocs(outputDir string, opts *Options) error {
+	cmd := docs.Command
 	cmd.DisableAutoGenTag = true // disable the cobra-generated footer
+	cmdDir := filepath.Join(outputDir, docs.commandDir(opts))
 
 	switch opts.Format {
 	case Manpage:
 		// Generate manpages from the commands
-		err := doc.GenManTree(cmd, nil, outputDir)
+		err := renderManTree(cmd, cmdDir)
 		if err != nil {
 			return fmt.Errorf("documenting commands: %w", err)
 		}
 	case Markdown:
-		err := renderMarkdownTree(cmd, outputDir, opts)
+		err := renderMarkdownTree(cmd, cmdDir, opts)
 		if err != nil {
 			return fmt.Errorf("documenting commands: %w", err)
 		}
 	case HTML:
-		tempDir, err := os.MkdirTemp("", cmd.Name()+"-command-docs-*")
+		err := docs.renderHTMLTree(cmd, outputDir, opts)
 		if err != nil {
 			return fmt.Errorf("documenting commands: %w", err)
 		}
-
-		// Generate markdown docs into temp directory
-		err = renderMarkdownTree(cmd, outputDir, opts)
-		if err != nil {
-			return fmt.Errorf("documenting commands: %w", err)
-		}
-
-		// Dump markdown files from temp directory to destination,
-		// converting files to HTML along the way
-		_, err = copyConvert(tempDir, outputDir, htmlOpts)
-		if err != nil {
-			return err
-		}
-
-		// Clean up temp directory
-		if err := os.RemoveAll(tempDir); err != nil {
-			return fmt.Errorf("documenting commands: %w", err)
-		}
 	}
 	return nil
 }
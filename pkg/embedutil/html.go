@@ -0,0 +1,296 @@
+package embedutil
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/termdoc/codefmt"
+)
+
+var (
+	//go:embed page.html.tmpl
+	pageTemplateStr string
+
+	//go:embed theme.css
+	themeCSS string
+
+	// Parsed default page template.
+	pageTemplate = template.Must(template.New("page").Parse(pageTemplateStr))
+)
+
+// HTMLOptions configures the pages of HTML output.
+type HTMLOptions struct {
+	// Template overrides the default page template, a html/template
+	// executed with [HTMLPage] for each page.
+	Template string
+
+	// CSS replaces the stylesheet of the default theme, which is included in each page.
+	CSS string
+
+	// Stylesheets are URLs of additional stylesheets linked by each page.
+	// Relative URLs are relative to the output directory.
+	Stylesheets []string
+}
+
+// HTMLPage is the data of the page template of HTML output.
+type HTMLPage struct {
+	Title       string        // Title of the page
+	SiteTitle   string        // Title of the documentation
+	Index       string        // URL of the documentation index, relative to the page
+	Nav         []*NavItem    // Navigation tree of the documentation
+	CSS         template.CSS  // Stylesheet of the theme
+	Stylesheets []string      // URLs of additional stylesheets, relative to the page
+	Content     template.HTML // Rendered document
+}
+
+// NavItem is an entry in the navigation tree of HTML output.
+type NavItem struct {
+	Title    string     // Title of the entry
+	URL      string     // URL of the entry's page relative to the current page, empty for sections without a page
+	Current  bool       // Entry is the current page
+	Children []*NavItem // Nested entries
+}
+
+// htmlPage wraps the rendered HTML document in a page with a navigation sidebar,
+// using the page template of the options.
+//
+// The page path is the location of the page in the output directory, used to
+// produce relative links to the other pages.
+func (docs *Documentation) htmlPage(pagePath, title string, content []byte, opts *Options) ([]byte, error) {
+	tmpl := pageTemplate
+	if opts.HTML.Template != "" {
+		var err error
+		tmpl, err = template.New("page").Parse(opts.HTML.Template)
+		if err != nil {
+			return nil, fmt.Errorf("parsing HTML page template: %w", err)
+		}
+	}
+
+	root := relativeRoot(pagePath)
+	page := &HTMLPage{
+		Title:     title,
+		SiteTitle: docs.Title,
+		Nav:       docs.navTree(pagePath, opts),
+		CSS:       template.CSS(themeCSS), //nolint:gosec // trusted stylesheet
+		Content:   template.HTML(content), //nolint:gosec // rendered from trusted documents
+	}
+	if opts.HTML.CSS != "" {
+		page.CSS = template.CSS(opts.HTML.CSS) //nolint:gosec // trusted stylesheet
+	}
+	if opts.Index && !opts.SinglePage {
+		page.Index = root + opts.Format.IndexFile()
+	}
+	for _, sheet := range opts.HTML.Stylesheets {
+		if !strings.Contains(sheet, "://") && !strings.HasPrefix(sheet, "/") {
+			sheet = root + sheet
+		}
+		page.Stylesheets = append(page.Stylesheets, sheet)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, page); err != nil {
+		return nil, fmt.Errorf("rendering HTML page %q: %w", pagePath, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// navTree produces the navigation tree of the documentation, with URLs relative to the page.
+func (docs *Documentation) navTree(pagePath string, opts *Options) []*NavItem {
+	if opts.SinglePage {
+		// Single pages have their own table of contents
+		return nil
+	}
+
+	root := relativeRoot(pagePath)
+	item := func(title, target string) *NavItem {
+		nav := &NavItem{Title: title}
+		if target != "" {
+			nav.URL = root + target
+			nav.Current = target == pagePath
+		}
+		return nav
+	}
+
+	var tree []*NavItem
+	if opts.TypeRequested(TypeGeneral) {
+		for _, cat := range docs.OrderedCategories() {
+			if len(cat.Docs) == 0 {
+				continue
+			}
+			catIndex := ""
+			if opts.Index && !opts.Flat {
+				catIndex = path.Join(cat.dirName(), opts.Format.IndexFile())
+			}
+			catItem := item(cat.Title, catIndex)
+			for _, doc := range cat.Docs {
+				catItem.Children = append(catItem.Children, item(doc.Title, docs.outputPath(cat, doc, opts)))
+			}
+			tree = append(tree, catItem)
+		}
+	}
+
+	if opts.TypeRequested(TypeCommands) && docs.Command != nil {
+		dir := docs.commandDir(opts)
+		var addCommand func(cmd *cobra.Command) *NavItem
+		addCommand = func(cmd *cobra.Command) *NavItem {
+			cmdItem := item(cmd.CommandPath(), path.Join(dir, setExtension(commandFilePath(cmd, opts), "html")))
+			for _, cmdC := range cmd.Commands() {
+				if cmdC.Name() == "help" {
+					continue // skip help commands
+				}
+				cmdItem.Children = append(cmdItem.Children, addCommand(cmdC))
+			}
+			return cmdItem
+		}
+		tree = append(tree, &NavItem{Title: "CLI Commands", Children: []*NavItem{addCommand(docs.Command)}})
+	}
+
+	return tree
+}
+
+// relativeRoot produces the relative path from the page to the output directory, such as "../".
+func relativeRoot(pagePath string) string {
+	return strings.Repeat("../", strings.Count(path.Clean(pagePath), "/"))
+}
+
+// htmlRenderHook renders code blocks with syntax highlighting and
+// headings with anchor links.
+func htmlRenderHook(w io.Writer, node ast.Node, entering bool) (ast.WalkStatus, bool) {
+	switch node := node.(type) {
+	case *ast.CodeBlock:
+		lang := ""
+		if info := strings.Fields(string(node.Info)); len(info) > 0 {
+			lang = info[0]
+		}
+		syntax, ok := codeSyntaxes[lang]
+		if !ok {
+			// Use the default rendering
+			return ast.GoToNext, false
+		}
+		_, _ = fmt.Fprintf(w, "<pre><code class=\"language-%s\">%s</code></pre>\n",
+			html.EscapeString(lang), syntax.highlight(string(node.Literal)))
+		return ast.GoToNext, true
+	case *ast.Heading:
+		if entering || node.HeadingID == "" {
+			return ast.GoToNext, false
+		}
+		_, _ = fmt.Fprintf(w, "<a class=\"anchor\" href=\"#%s\" aria-hidden=\"true\">#</a></h%d>\n",
+			html.EscapeString(node.HeadingID), node.Level)
+		return ast.GoToNext, true
+	default:
+		return ast.GoToNext, false
+	}
+}
+
+// codeSyntax is the syntax of a code block's language.
+type codeSyntax struct {
+	lang     codefmt.LangInfo
+	keywords map[string]bool
+}
+
+// codeSyntaxes maps the languages of code blocks to their syntax for highlighting.
+var codeSyntaxes = map[string]*codeSyntax{
+	"bash":    shellSyntax,
+	"sh":      shellSyntax,
+	"shell":   shellSyntax,
+	"console": shellSyntax,
+	"go": {lang: codefmt.Go, keywords: keywordSet(`break case chan const continue default defer else
+		fallthrough for func go goto if import interface map package range return select struct
+		switch type var true false nil iota`)},
+	"python": {lang: codefmt.LangInfo{LineCommentStart: "#"}, keywords: keywordSet(`and as assert
+		async await break class continue def del elif else except finally for from global if
+		import in is lambda nonlocal not or pass raise return try while with yield None True False`)},
+	"yaml": {lang: codefmt.LangInfo{LineCommentStart: "#"}, keywords: keywordSet(`true false null`)},
+	"toml": {lang: codefmt.LangInfo{LineCommentStart: "#"}, keywords: keywordSet(`true false`)},
+}
+
+var shellSyntax = &codeSyntax{lang: codefmt.Bash, keywords: keywordSet(`if then else elif fi for
+	while until do done case esac in function return export local select`)}
+
+// keywordSet produces the set of space separated keywords.
+func keywordSet(keywords string) map[string]bool {
+	set := map[string]bool{}
+	for _, k := range strings.Fields(keywords) {
+		set[k] = true
+	}
+	return set
+}
+
+// codeTokenRegex matches the tokens highlighted in code: strings, numbers, and words,
+// which include dashes so parts of command-line flags are not mistaken for keywords.
+var codeTokenRegex = regexp.MustCompile(`("(?:[^"\\]|\\.)*"?|'[^']*'?|` + "`[^`]*`?" + `)|(\b\d+(?:\.\d+)?\b)|([A-Za-z_][\w-]*)`)
+
+// highlight formats the code with HTML spans for comments, prompts, keywords, strings, and numbers.
+func (syntax *codeSyntax) highlight(code string) string {
+	formatter := &codefmt.Formatter{
+		Code: func(code string, loc codefmt.Location) string {
+			if loc.Heredoc {
+				return html.EscapeString(code)
+			}
+			return syntax.highlightTokens(code)
+		},
+		Comment: func(comment string, _ codefmt.Location) string {
+			return `<span class="hl-comment">` + html.EscapeString(comment) + `</span>`
+		},
+		Prompt: func(prompt string, _ codefmt.Location) string {
+			return `<span class="hl-prompt">` + html.EscapeString(prompt) + `</span>`
+		},
+	}
+	return formatter.Format(code, syntax.lang)
+}
+
+// highlightTokens escapes the code, wrapping its keywords, strings, and numbers in HTML spans.
+func (syntax *codeSyntax) highlightTokens(code string) string {
+	b := &strings.Builder{}
+	last := 0
+	for _, m := range codeTokenRegex.FindAllStringSubmatchIndex(code, -1) {
+		class := ""
+		switch {
+		case m[2] != -1:
+			class = "hl-string"
+		case m[4] != -1:
+			class = "hl-number"
+		case syntax.keywords[code[m[6]:m[7]]]:
+			class = "hl-keyword"
+		default:
+			continue
+		}
+		b.WriteString(html.EscapeString(code[last:m[0]]))
+		b.WriteString(`<span class="` + class + `">` + html.EscapeString(code[m[0]:m[1]]) + `</span>`)
+		last = m[1]
+	}
+	b.WriteString(html.EscapeString(code[last:]))
+	return b.String()
+}
+
+// htmlLinkTarget replaces the extension of relative links to markdown files with ".html",
+// matching the names of converted files. Links to README.md files are replaced with
+// links to index.html files.
+func htmlLinkTarget(target string) string {
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "mailto:") {
+		return target
+	}
+	file, anchor, found := strings.Cut(target, "#")
+	if path.Ext(file) != ".md" {
+		return target
+	}
+	if path.Base(file) == Markdown.IndexFile() {
+		file = strings.TrimSuffix(file, Markdown.IndexFile()) + HTML.IndexFile()
+	} else {
+		file = setExtension(file, "html")
+	}
+	if found {
+		file += "#" + anchor
+	}
+	return file
+}
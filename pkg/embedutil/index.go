@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
+
+	"github.com/act3-ai/go-common/pkg/md"
 )
 
 // Index creates an index file for the documentation in the requested format
@@ -31,9 +35,9 @@ func (docs *Documentation) Index(outputDir string, opts *Options) ([]byte, error
 		if err != nil {
 			return index, err
 		}
-		return index, nil
+		return docs.htmlPage(opts.Format.IndexFile(), docs.Title, index, opts)
 	case Markdown:
-		return index, err
+		return formatMarkdown(index)
 	default:
 		return nil, nil
 	}
@@ -57,8 +61,16 @@ func (docs *Documentation) generateMarkdownIndex(outputDir string, opts *Options
 			return nil
 		}
 
-		// Append section header
-		_, _ = fmt.Fprintf(index, groupNameTemplate, cat.Title)
+		// Append section header, linking the category's landing page for non-flat renders
+		title := cat.Title
+		if !opts.Flat {
+			title = md.Link(cat.Title, "./"+filepath.Join(cat.dirName(), opts.Format.IndexFile()))
+		}
+		_, _ = fmt.Fprintf(index, groupNameTemplate, title)
+
+		if cat.Description != "" {
+			_, _ = fmt.Fprint(index, strings.TrimSpace(cat.Description)+"\n\n")
+		}
 
 		for _, doc := range cat.Docs {
 			docPath := doc.RenderedName(opts.Format)
@@ -67,7 +79,7 @@ func (docs *Documentation) generateMarkdownIndex(outputDir string, opts *Options
 				docPath = filepath.Join(cat.dirName(), docPath)
 			}
 			// Append file link
-			_, _ = fmt.Fprintf(index, mdLinkTemplate, doc.Title, docPath)
+			_, _ = fmt.Fprint(index, docListItem(doc, docPath))
 		}
 
 		return nil
@@ -106,7 +118,7 @@ func (docs *Documentation) generateMarkdownIndex(outputDir string, opts *Options
 	}
 
 	// Index each category
-	for _, cat := range docs.Categories {
+	for _, cat := range docs.OrderedCategories() {
 		err := addCategory(cat)
 		if err != nil {
 			return nil, err
@@ -121,3 +133,44 @@ func (docs *Documentation) generateMarkdownIndex(outputDir string, opts *Options
 
 	return index.Bytes(), nil
 }
+
+// CategoryIndex creates the landing page of the category in the requested format,
+// listing each of its documents with their summaries
+//
+// Document links are relative to the category's directory.
+func (docs *Documentation) CategoryIndex(cat *Category, opts *Options) ([]byte, error) {
+	if !opts.Format.indexable() {
+		return nil, nil
+	}
+
+	index := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(index, "# %s\n\n", cat.Title)
+	if cat.Description != "" {
+		_, _ = fmt.Fprint(index, strings.TrimSpace(cat.Description)+"\n\n")
+	}
+	for _, doc := range cat.Docs {
+		_, _ = fmt.Fprint(index, docListItem(doc, doc.RenderedName(opts.Format)))
+	}
+
+	if opts.Format == HTML {
+		page, err := formatHTML(index.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		pagePath := opts.Format.IndexFile()
+		if !opts.Flat {
+			pagePath = path.Join(cat.dirName(), pagePath)
+		}
+		return docs.htmlPage(pagePath, cat.Title, page, opts)
+	}
+	return formatMarkdown(index.Bytes())
+}
+
+// docListItem produces a markdown list item linking the document, followed by its summary.
+func docListItem(doc *Document, docPath string) string {
+	item := md.Link(doc.Title, "./"+docPath)
+	if summary := doc.summary(); summary != "" {
+		item += " - " + summary
+	}
+	return "- " + item + "\n"
+}
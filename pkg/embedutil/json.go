@@ -0,0 +1,181 @@
+package embedutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/cobrautil"
+)
+
+// jsonFile is the name of the file containing JSON documentation.
+const jsonFile = "docs.json"
+
+// DocumentationInfo is a machine-readable description of the documentation.
+type DocumentationInfo struct {
+	Title      string                 `json:"title"`
+	Command    *cobrautil.CommandInfo `json:"command,omitempty"`
+	Groups     []*GroupInfo           `json:"groups,omitempty"`
+	Categories []*CategoryInfo        `json:"categories,omitempty"`
+}
+
+// GroupInfo is a machine-readable description of an option group.
+type GroupInfo struct {
+	Key         string `json:"key"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	JSON        string `json:"json,omitempty"`
+}
+
+// CategoryInfo is a machine-readable description of a documentation category.
+type CategoryInfo struct {
+	Key         string          `json:"key"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Docs        []*DocumentInfo `json:"docs"`
+}
+
+// DocumentInfo is a machine-readable description of a document.
+type DocumentInfo struct {
+	Key      string         `json:"key"`
+	Title    string         `json:"title"`
+	Summary  string         `json:"summary,omitempty"`
+	Encoding Encoding       `json:"encoding"`
+	Metadata map[string]any `json:"metadata,omitempty"` // Front-matter of markdown documents
+	Contents string         `json:"contents"`
+}
+
+// Info describes the requested documentation.
+//
+// Commands are described by [cobrautil.ExportCommandTree], with the option
+// groups of their flags listed in Groups.
+func (docs *Documentation) Info(opts *Options) (*DocumentationInfo, error) {
+	info := &DocumentationInfo{Title: docs.Title}
+
+	if opts.TypeRequested(TypeCommands) && docs.Command != nil {
+		info.Command = cobrautil.ExportCommandTree(docs.Command)
+		info.Groups = commandGroups(docs.Command)
+	}
+
+	if opts.TypeRequested(TypeGeneral) {
+		for _, cat := range docs.OrderedCategories() {
+			catInfo := &CategoryInfo{
+				Key:         cat.dirName(),
+				Title:       cat.Title,
+				Description: cat.Description,
+				Docs:        make([]*DocumentInfo, 0, len(cat.Docs)),
+			}
+			for _, doc := range cat.Docs {
+				doc, err := docs.Expand(doc)
+				if err != nil {
+					return nil, err
+				}
+				docInfo, err := doc.info()
+				if err != nil {
+					return nil, err
+				}
+				catInfo.Docs = append(catInfo.Docs, docInfo)
+			}
+			info.Categories = append(info.Categories, catInfo)
+		}
+	}
+
+	return info, nil
+}
+
+// info describes the document, parsing front-matter from markdown documents.
+func (doc *Document) info() (*DocumentInfo, error) {
+	info := &DocumentInfo{
+		Key:      doc.Key,
+		Title:    doc.Title,
+		Summary:  doc.Summary,
+		Encoding: doc.encoding,
+		Contents: string(doc.Contents),
+	}
+	if doc.encoding != EncodingMarkdown {
+		return info, nil
+	}
+
+	rest, ok := strings.CutPrefix(info.Contents, "---\n")
+	if !ok {
+		return info, nil
+	}
+	frontmatter, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return info, nil
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &info.Metadata); err != nil {
+		return nil, fmt.Errorf("parsing front-matter of %q: %w", doc.name, err)
+	}
+	info.Contents = strings.TrimLeft(body, "\n")
+	if description, ok := info.Metadata["description"].(string); ok && info.Summary == "" {
+		info.Summary = description
+	}
+	return info, nil
+}
+
+// summary produces the document's summary, defaulting to the "description" of its front-matter.
+func (doc *Document) summary() string {
+	if doc.Summary != "" {
+		return doc.Summary
+	}
+	info, err := doc.info()
+	if err != nil {
+		return ""
+	}
+	return info.Summary
+}
+
+// commandGroups lists the option groups of the flags of the command and its subcommands.
+func commandGroups(root *cobra.Command) []*GroupInfo {
+	var groups []*GroupInfo
+	seen := map[string]bool{}
+	cobrautil.WalkCommands(root, func(cmd *cobra.Command) {
+		cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			g, ok := options.GroupOf(f)
+			if !ok || seen[g.Key] {
+				return
+			}
+			seen[g.Key] = true
+			groups = append(groups, &GroupInfo{
+				Key:         g.Key,
+				Title:       g.Title,
+				Description: g.Description,
+				JSON:        g.JSON,
+			})
+		})
+	})
+	return groups
+}
+
+// writeJSON writes the documentation as a single JSON file.
+func (docs *Documentation) writeJSON(ctx context.Context, outputDir string, opts *Options) error {
+	info, err := docs.Info(opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding documentation: %w", err)
+	}
+
+	dest := filepath.Join(outputDir, jsonFile)
+	err = writeFileIfChanged(dest, append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("writing documentation: %w", err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "Generated documentation", slog.String("file", dest), slog.String("format", string(opts.Format)))
+
+	return nil
+}
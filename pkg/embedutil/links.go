@@ -0,0 +1,118 @@
+package embedutil
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ErrBrokenLink is returned by [Documentation.ValidateLinks] for links that cannot be resolved.
+var ErrBrokenLink = errors.New("broken link")
+
+// LinkOptions configures how relative links in embedded markdown documents are rewritten.
+//
+// Relative links are resolved in order:
+//  1. Links with a replacement in Map are replaced.
+//  2. Links to another embedded document, by file name, are rewritten to the
+//     document's location in the output.
+//  3. If BaseURL is set, remaining links are joined to BaseURL, pointing to the source repository.
+//
+// Links with a URL scheme and links to anchors in the same document are not modified.
+type LinkOptions struct {
+	BaseURL string            // Base URL for links to files that are not embedded documents
+	Map     map[string]string // Replacements for link targets, keyed by the original target
+}
+
+// rewriteLinks rewrites relative links in the document's contents to their output locations.
+//
+// Returns the rewritten contents and the links that could not be resolved.
+func (docs *Documentation) rewriteLinks(cat *Category, doc *Document, opts *Options) ([]byte, []string) {
+	if doc.encoding != EncodingMarkdown {
+		return doc.Contents, nil
+	}
+
+	docPath := docs.outputPath(cat, doc, opts)
+
+	var broken []string
+	contents := markdownLink.ReplaceAllStringFunc(string(doc.Contents), func(link string) string {
+		match := markdownLink.FindStringSubmatch(link)
+		target, anchor := match[1], match[2]
+		if strings.Contains(target, ":") {
+			return link // URLs with a scheme, such as https: or mailto:
+		}
+
+		if replacement, ok := opts.Links.Map[target]; ok {
+			return "](" + replacement + anchor + ")"
+		}
+
+		if targetCat, targetDoc := docs.findDocumentFile(path.Base(target)); targetDoc != nil {
+			rel := relativePath(path.Dir(docPath), docs.outputPath(targetCat, targetDoc, opts))
+			return "](" + rel + anchor + ")"
+		}
+
+		if opts.Links.BaseURL != "" {
+			return "](" + strings.TrimSuffix(opts.Links.BaseURL, "/") + "/" + strings.TrimPrefix(path.Clean(target), "/") + anchor + ")"
+		}
+
+		broken = append(broken, target)
+		return link
+	})
+
+	return []byte(contents), broken
+}
+
+// ValidateLinks reports relative links in the requested documentation that do not
+// resolve to an embedded document, a mapped link, or a base URL.
+func (docs *Documentation) ValidateLinks(opts *Options) error {
+	if !opts.TypeRequested(TypeGeneral) {
+		return nil
+	}
+	var errs []error
+	for _, cat := range docs.Categories {
+		for _, doc := range cat.Docs {
+			doc, err := docs.Expand(doc)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			_, broken := docs.rewriteLinks(cat, doc, opts)
+			for _, target := range broken {
+				errs = append(errs, fmt.Errorf("%s: %w to %q", path.Join(cat.dirName(), doc.name), ErrBrokenLink, target))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// findDocumentFile finds the embedded document with the given file name.
+func (docs *Documentation) findDocumentFile(name string) (*Category, *Document) {
+	for _, cat := range docs.Categories {
+		for _, doc := range cat.Docs {
+			if doc.name == name {
+				return cat, doc
+			}
+		}
+	}
+	return nil, nil
+}
+
+// outputPath produces the path of the document in the output directory.
+func (docs *Documentation) outputPath(cat *Category, doc *Document, opts *Options) string {
+	if opts.Flat {
+		return doc.RenderedName(opts.Format)
+	}
+	return path.Join(cat.dirName(), doc.RenderedName(opts.Format))
+}
+
+// relativePath produces the path to target relative to the directory dir.
+func relativePath(dir, target string) string {
+	if dir == path.Dir(target) {
+		return path.Base(target)
+	}
+	up := ""
+	if dir != "." {
+		up = strings.Repeat("../", strings.Count(dir, "/")+1)
+	}
+	return up + target
+}
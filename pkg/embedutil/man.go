@@ -0,0 +1,233 @@
+package embedutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cpuguy83/go-md2man/v2/md2man"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/cobrautil"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/termdoc"
+)
+
+// manSection is the manual section for command manpages.
+const manSection = "1"
+
+func renderManTree(cmd *cobra.Command, dir string) error {
+	err := os.MkdirAll(dir, 0o775)
+	if err != nil {
+		return fmt.Errorf("command docs: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	err = GenManCustom(cmd, buf)
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(dir, strings.ReplaceAll(cmd.CommandPath(), " ", "-")+"."+manSection)
+	err = writeFileIfChanged(dest, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("command docs: %w", err)
+	}
+
+	for _, cmdC := range cmd.Commands() {
+		if cmdC.Name() == "help" || !cmdC.IsAvailableCommand() {
+			continue // skip help and hidden commands
+		}
+
+		err = renderManTree(cmdC, dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenManCustom creates a roff manpage for the command.
+//
+// Flags are documented in the same groups as the command's help, using the
+// usage format set by [SetUsageFormat], with their defaults and environment variables.
+func GenManCustom(cmd *cobra.Command, w io.Writer) error {
+	cmd.InitDefaultHelpFlag()
+
+	if termdoc.HasLazyLongMessage(cmd) {
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.HelpFunc()(cmd, []string{})
+	}
+
+	buf := new(bytes.Buffer)
+	dashPath := strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+
+	fmt.Fprintf(buf, "%% %s %s\n\n", strings.ToUpper(dashPath), manSection)
+
+	buf.WriteString("# NAME\n\n")
+	buf.WriteString(dashPath)
+	if cmd.Short != "" {
+		buf.WriteString(" \\- " + cmd.Short)
+	}
+	buf.WriteString("\n\n")
+
+	if cmd.Runnable() {
+		fmt.Fprintf(buf, "# SYNOPSIS\n\n**%s**\n\n", cmd.UseLine())
+	}
+
+	if cmd.Long != "" {
+		buf.WriteString("# DESCRIPTION\n\n")
+		buf.WriteString(ansi.Strip(cmd.Long) + "\n\n")
+	}
+
+	if len(cmd.Aliases) > 0 {
+		buf.WriteString("# ALIASES\n\n")
+		for _, a := range cmd.Aliases {
+			// Aliases replace the command's name, the root command has no parent
+			alias := a
+			if cmd.HasParent() {
+				alias = cmd.Parent().CommandPath() + " " + a
+			}
+			fmt.Fprintf(buf, "**%s**\n\n", alias)
+		}
+	}
+
+	printManOptions(buf, "OPTIONS", cmd.LocalFlags(), defaultUsageFormat.LocalFlags)
+	printManOptions(buf, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags(), defaultUsageFormat.InheritedFlags)
+
+	if cmd.Example != "" {
+		fmt.Fprintf(buf, "# EXAMPLE\n\n```\n%s\n```\n\n", ansi.Strip(cmd.Example))
+	}
+
+	printManSeeAlso(buf, cmd)
+
+	_, err := w.Write(md2man.Render(buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("command docs: %w", err)
+	}
+
+	return nil
+}
+
+// printManOptions writes a section documenting the flags, with a subsection for each flag group.
+func printManOptions(buf *bytes.Buffer, title string, f *pflag.FlagSet, gopts cobrautil.FlagGroupingOptions) {
+	if !f.HasAvailableFlags() {
+		return
+	}
+
+	buf.WriteString("# " + title + "\n\n")
+
+	if !gopts.GroupFlags {
+		printManFlags(buf, f)
+		return
+	}
+
+	groups, ungrouped := options.ToGroupFlagSets(f)
+	if ungrouped.FlagSet.HasAvailableFlags() {
+		if len(groups) > 0 && gopts.UngroupedHeader != "" {
+			buf.WriteString("### " + manHeader(gopts.UngroupedHeader) + "\n\n")
+		}
+		printManFlags(buf, ungrouped.FlagSet)
+	}
+	for _, group := range groups {
+		if !group.FlagSet.HasAvailableFlags() {
+			continue
+		}
+		header := group.Title
+		if gopts.GroupHeader != nil {
+			header = gopts.GroupHeader(group.Group)
+		}
+		if header = manHeader(header); header != "" {
+			buf.WriteString("### " + header + "\n\n")
+		}
+		printManFlags(buf, group.FlagSet)
+	}
+}
+
+// printManFlags writes a definition list entry for each visible flag.
+func printManFlags(buf *bytes.Buffer, f *pflag.FlagSet) {
+	opts := defaultUsageFormat.FlagOptions
+	f.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+			fmt.Fprintf(buf, "**-%s**, ", flag.Shorthand)
+		}
+		fmt.Fprintf(buf, "**--%s**", flag.Name)
+
+		varname, usage := pflag.UnquoteUsage(flag)
+		if choices := flagutil.GetChoices(flag); len(choices) > 0 && varname == flag.Value.Type() {
+			varname = strings.Join(choices, "|")
+		}
+		if varname != "" {
+			if opts.FormatType != nil {
+				varname = opts.FormatType(flag, varname)
+			}
+			fmt.Fprintf(buf, " *%s*", manEscape(ansi.Strip(varname)))
+		}
+		buf.WriteString("\n")
+
+		if opts.FormatUsage != nil {
+			usage = opts.FormatUsage(flag, usage)
+		}
+		usage = ansi.Strip(usage)
+		lines := []string{manEscape(usage)}
+		if flag.Deprecated != "" {
+			lines = append(lines, "Deprecated: "+manEscape(flag.Deprecated)+".")
+		}
+		if !flagutil.DefaultIsZeroValue(flag) {
+			def := flag.DefValue
+			if opts.FormatValue != nil {
+				def = opts.FormatValue(flag, def)
+			}
+			lines = append(lines, "Default: `"+ansi.Strip(def)+"`.")
+		}
+		// Skip environment variables already documented by the usage format
+		if envName := flagutil.GetEnvName(flag); envName != "" && !strings.Contains(usage, envName) {
+			lines = append(lines, "Environment: `"+envName+"`.")
+		}
+		buf.WriteString(": " + strings.Join(lines, "\n  ") + "\n\n")
+	})
+}
+
+// printManSeeAlso writes references to the parent and child command manpages.
+func printManSeeAlso(buf *bytes.Buffer, cmd *cobra.Command) {
+	var related []string
+	if cmd.HasParent() {
+		related = append(related, manRef(cmd.Parent()))
+	}
+	for _, cmdC := range cmd.Commands() {
+		if cmdC.Name() == "help" || !cmdC.IsAvailableCommand() {
+			continue
+		}
+		related = append(related, manRef(cmdC))
+	}
+	if len(related) == 0 {
+		return
+	}
+	buf.WriteString("# SEE ALSO\n\n")
+	buf.WriteString(strings.Join(related, ", ") + "\n")
+}
+
+// manRef formats a reference to the command's manpage.
+func manRef(cmd *cobra.Command) string {
+	return fmt.Sprintf("**%s(%s)**", strings.ReplaceAll(cmd.CommandPath(), " ", "-"), manSection)
+}
+
+// manHeader formats a flag group header as a manpage subsection title.
+func manHeader(header string) string {
+	return strings.TrimRight(ansi.Strip(header), ".: ")
+}
+
+// manEscape escapes markdown emphasis characters in plain text.
+var manEscape = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`").Replace
@@ -0,0 +1,192 @@
+package embedutil
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+)
+
+// section is a section of a single-page document.
+type section struct {
+	anchor   string // Anchor ID of the section
+	title    string // Title of the section in the table of contents
+	depth    int    // Nesting depth in the table of contents
+	filePath string // Path of the section's file in multi-page output, used to resolve links
+	contents []byte // Markdown contents of the section
+}
+
+// SinglePage renders all requested documentation as a single markdown document
+// with a table of contents, in the requested format.
+//
+// Headings in each document are nested under the document's section and links
+// between documents are replaced with links to the corresponding sections.
+func (docs *Documentation) SinglePage(opts *Options) ([]byte, error) {
+	var sections []section
+
+	if opts.TypeRequested(TypeGeneral) {
+		for _, cat := range docs.OrderedCategories() {
+			if len(cat.Docs) == 0 {
+				continue
+			}
+			catSection := section{
+				anchor: cat.dirName(),
+				title:  cat.Title,
+			}
+			if cat.Description != "" {
+				catSection.contents = []byte("# " + cat.Title + "\n\n" + cat.Description)
+			}
+			sections = append(sections, catSection)
+			for _, doc := range cat.Docs {
+				// Rewrite links for the default directory structure, resolved to sections below
+				contents, err := docs.renderDocument(cat, doc, &Options{Format: Markdown, Links: opts.Links})
+				if err != nil {
+					return nil, err
+				}
+				sections = append(sections, section{
+					anchor:   cat.dirName() + "-" + doc.Key,
+					title:    doc.Title,
+					depth:    1,
+					filePath: path.Join(cat.dirName(), doc.RenderedName(Markdown)),
+					contents: contents,
+				})
+			}
+		}
+	}
+
+	if opts.TypeRequested(TypeCommands) && docs.Command != nil {
+		// Disable the cobra-generated footer while rendering
+		disableAutoGenTag := docs.Command.DisableAutoGenTag
+		docs.Command.DisableAutoGenTag = true
+		cmdSections, err := commandSections(docs.Command, 0)
+		docs.Command.DisableAutoGenTag = disableAutoGenTag
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, cmdSections...)
+	}
+
+	page := renderSinglePage(docs.Title, sections)
+
+	if opts.Format == HTML {
+		page, err := formatHTML(page)
+		if err != nil {
+			return nil, err
+		}
+		return docs.htmlPage(opts.Format.IndexFile(), docs.Title, page, opts)
+	}
+	return formatMarkdown(page)
+}
+
+// commandSections produces a section for the command and each of its subcommands.
+func commandSections(cmd *cobra.Command, depth int) ([]section, error) {
+	buf := new(bytes.Buffer)
+	err := GenMarkdownCustom(cmd, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []section{{
+		anchor: strings.ReplaceAll(cmd.CommandPath(), " ", "-"),
+		title:  cmd.CommandPath(),
+		depth:  depth,
+		// Resolve subcommand links the same as the default directory structure
+		filePath: path.Join("cli", filepath.ToSlash(commandFilePath(cmd, &Options{}))),
+		contents: []byte(ansi.Strip(buf.String())),
+	}}
+
+	for _, cmdC := range cmd.Commands() {
+		if cmdC.Name() == "help" {
+			continue // skip help commands
+		}
+		children, err := commandSections(cmdC, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, children...)
+	}
+
+	return sections, nil
+}
+
+// renderSinglePage concatenates the sections with a table of contents.
+func renderSinglePage(title string, sections []section) []byte {
+	anchors := make(map[string]string, len(sections))
+	for _, s := range sections {
+		if s.filePath != "" {
+			anchors[s.filePath] = s.anchor
+		}
+	}
+
+	page := new(bytes.Buffer)
+	fmt.Fprintf(page, "# %s\n\n## Contents\n\n", title)
+	for _, s := range sections {
+		fmt.Fprintf(page, "%s- [%s](#%s)\n", strings.Repeat("  ", s.depth), s.title, s.anchor)
+	}
+
+	for _, s := range sections {
+		fmt.Fprintf(page, "\n<a id=%q></a>\n\n", s.anchor)
+		if len(s.contents) == 0 {
+			// Sections without contents only group other sections
+			fmt.Fprintf(page, "## %s\n", s.title)
+			continue
+		}
+		contents := stripFrontmatter(string(s.contents))
+		contents = nestHeadings(contents)
+		contents = replaceLinks(contents, s.filePath, anchors)
+		page.WriteString(strings.TrimSpace(contents) + "\n")
+	}
+
+	return page.Bytes()
+}
+
+// stripFrontmatter removes YAML frontmatter from a markdown document.
+func stripFrontmatter(contents string) string {
+	rest, ok := strings.CutPrefix(contents, "---\n")
+	if !ok {
+		return contents
+	}
+	_, after, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return contents
+	}
+	return after
+}
+
+// nestHeadings increases the level of each markdown heading outside of code blocks.
+func nestHeadings(contents string) string {
+	lines := strings.Split(contents, "\n")
+	var fenced bool
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			fenced = !fenced
+			continue
+		}
+		if !fenced && strings.HasPrefix(line, "#") {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownLink matches the target of an inline markdown link.
+var markdownLink = regexp.MustCompile(`\]\(([^)#\s]+)(#[^)\s]*)?\)`)
+
+// replaceLinks replaces links to other files in the page with links to their sections.
+func replaceLinks(contents, filePath string, anchors map[string]string) string {
+	return markdownLink.ReplaceAllStringFunc(contents, func(link string) string {
+		target := markdownLink.FindStringSubmatch(link)[1]
+		if strings.Contains(target, "://") {
+			return link
+		}
+		if anchor, ok := anchors[path.Join(path.Dir(filePath), target)]; ok {
+			return "](#" + anchor + ")"
+		}
+		return link
+	})
+}
@@ -0,0 +1,67 @@
+package embedutil
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"text/template"
+
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// TemplateData is the data available to templated documents.
+type TemplateData struct {
+	Version string         // Version of the tool
+	Command string         // Name of the root command
+	Info    version.Info   // Version information of the tool
+	Vars    map[string]any // Variables from [Documentation.Variables]
+}
+
+// LoadMarkdownTemplate loads a templated markdown file into a Document
+// name must be the path to the document in filesys
+//
+// The document is expanded as a Go template when rendered, with [TemplateData],
+// using the version information and variables of the [Documentation].
+func LoadMarkdownTemplate(key, title, name string, filesys fs.FS) *Document {
+	d := LoadMarkdown(key, title, name, filesys)
+	d.template = true
+	return d
+}
+
+// templateData produces the data for templated documents.
+func (docs *Documentation) templateData() TemplateData {
+	data := TemplateData{
+		Version: docs.Version.Version,
+		Info:    docs.Version,
+		Vars:    docs.Variables,
+	}
+	if docs.Command != nil {
+		data.Command = docs.Command.Name()
+	}
+	return data
+}
+
+// Expand returns the document with template placeholders expanded.
+//
+// Documents that are not templated are returned unmodified.
+func (docs *Documentation) Expand(doc *Document) (*Document, error) {
+	if !doc.template {
+		return doc, nil
+	}
+
+	tmpl, err := template.New(doc.name).Option("missingkey=error").Parse(string(doc.Contents))
+	if err != nil {
+		return nil, fmt.Errorf("parsing document template %q: %w", doc.name, err)
+	}
+
+	buf := new(bytes.Buffer)
+	err = tmpl.Execute(buf, docs.templateData())
+	if err != nil {
+		return nil, fmt.Errorf("expanding document template %q: %w", doc.name, err)
+	}
+
+	expanded := *doc
+	expanded.Contents = buf.Bytes()
+	expanded.template = false
+	return &expanded, nil
+}
@@ -0,0 +1,168 @@
+// Package errdefs defines categories of CLI errors that carry process exit
+// codes and hints for users.
+//
+// Commands wrap errors to categorize them:
+//
+//	if err := cfg.Load(); err != nil {
+//		return errdefs.Config(err, "Run `sample config init` to create a configuration file.")
+//	}
+//
+// and the program's main function reports them with [runner.HandleError].
+package errdefs
+
+import (
+	"errors"
+)
+
+// Category is a category of errors.
+type Category string
+
+// Error categories.
+const (
+	CategoryUsage      Category = "usage"      // invalid arguments or flags
+	CategoryConfig     Category = "config"     // invalid or missing configuration
+	CategoryNetwork    Category = "network"    // a remote service is unreachable or failed
+	CategoryPermission Category = "permission" // insufficient permissions or credentials
+	CategoryInternal   Category = "internal"   // a bug or unexpected condition
+)
+
+// Exit codes of the error categories, from sysexits.h.
+const (
+	ExitUsage      = 64 // EX_USAGE
+	ExitConfig     = 78 // EX_CONFIG
+	ExitNetwork    = 69 // EX_UNAVAILABLE
+	ExitPermission = 77 // EX_NOPERM
+	ExitInternal   = 70 // EX_SOFTWARE
+)
+
+// ExitCode returns the process exit code for the category, or 1 for unknown categories.
+func (c Category) ExitCode() int {
+	switch c {
+	case CategoryUsage:
+		return ExitUsage
+	case CategoryConfig:
+		return ExitConfig
+	case CategoryNetwork:
+		return ExitNetwork
+	case CategoryPermission:
+		return ExitPermission
+	case CategoryInternal:
+		return ExitInternal
+	default:
+		return 1
+	}
+}
+
+// Error is a categorized error with an optional hint for the user.
+// It implements otel.ExitCoder.
+type Error struct {
+	Category Category
+
+	// Hint is a Markdown message suggesting how the user can resolve the error.
+	Hint string
+
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit code of the error's category.
+func (e *Error) ExitCode() int {
+	return e.Category.ExitCode()
+}
+
+// New wraps err in the category with a hint, which may be empty.
+// It returns nil if err is nil.
+func New(category Category, err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Category: category, Hint: hint, Err: err}
+}
+
+// Usage wraps err as a usage error.
+func Usage(err error, hint string) error {
+	return New(CategoryUsage, err, hint)
+}
+
+// Config wraps err as a configuration error.
+func Config(err error, hint string) error {
+	return New(CategoryConfig, err, hint)
+}
+
+// Network wraps err as a network error.
+func Network(err error, hint string) error {
+	return New(CategoryNetwork, err, hint)
+}
+
+// Permission wraps err as a permission error.
+func Permission(err error, hint string) error {
+	return New(CategoryPermission, err, hint)
+}
+
+// Internal wraps err as an internal error.
+func Internal(err error, hint string) error {
+	return New(CategoryInternal, err, hint)
+}
+
+// CategoryOf returns the category of the first [Error] in err's chain,
+// or [CategoryInternal] if there is none.
+func CategoryOf(err error) Category {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Category
+	}
+	return CategoryInternal
+}
+
+// Hint returns the first non-empty hint of an [Error] in err's chain.
+func Hint(err error) string {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			return ""
+		}
+		if e.Hint != "" {
+			return e.Hint
+		}
+		err = e.Err
+	}
+	return ""
+}
+
+// Is reports whether err has an [Error] of the category in its chain.
+func Is(err error, category Category) bool {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			return false
+		}
+		if e.Category == category {
+			return true
+		}
+		err = e.Err
+	}
+	return false
+}
+
+// ExitCode returns the process exit code for err: 0 for nil, the exit code
+// of the first error in the chain with an ExitCode method (such as [Error]),
+// or 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitCoder interface{ ExitCode() int }
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
+	}
+	return 1
+}
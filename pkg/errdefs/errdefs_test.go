@@ -0,0 +1,43 @@
+package errdefs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	base := errors.New("connection refused")
+	err := fmt.Errorf("fetching index: %w", Network(base, "Check your proxy settings."))
+
+	assert.Equal(t, "fetching index: connection refused", err.Error())
+	assert.ErrorIs(t, err, base)
+	assert.Equal(t, CategoryNetwork, CategoryOf(err))
+	assert.Equal(t, "Check your proxy settings.", Hint(err))
+	assert.Equal(t, ExitNetwork, ExitCode(err))
+	assert.True(t, Is(err, CategoryNetwork))
+	assert.False(t, Is(err, CategoryUsage))
+
+	assert.NoError(t, Usage(nil, "hint"))
+}
+
+func TestNested(t *testing.T) {
+	inner := Permission(errors.New("403"), "")
+	outer := Config(fmt.Errorf("loading registry credentials: %w", inner), "")
+	withHint := Internal(fmt.Errorf("wrapped: %w", Usage(errors.New("x"), "Pass --help.")), "")
+
+	assert.Equal(t, CategoryConfig, CategoryOf(outer))
+	assert.True(t, Is(outer, CategoryPermission))
+	assert.Empty(t, Hint(outer))
+	assert.Equal(t, "Pass --help.", Hint(withHint))
+	assert.Equal(t, ExitInternal, ExitCode(withHint))
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(errors.New("plain")))
+	assert.Equal(t, ExitUsage, ExitCode(Usage(errors.New("bad flag"), "")))
+	assert.Equal(t, 1, Category("other").ExitCode())
+}
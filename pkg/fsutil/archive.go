@@ -0,0 +1,387 @@
+package fsutil
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/act3-ai/go-common/pkg/ioutil"
+)
+
+// ArchiveFormat is an archive file format.
+type ArchiveFormat string
+
+// Supported archive formats.
+const (
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	ArchiveZip   ArchiveFormat = "zip"
+)
+
+// ErrUnsafePath is returned by [Unarchive] for entries that would be written outside of the destination.
+var ErrUnsafePath = errors.New("unsafe path in archive")
+
+// zipEpoch is the earliest time representable in a zip archive.
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ArchiveOptions stores options for creating an archive.
+type ArchiveOptions struct {
+	// ZeroTimes sets the modification times of all entries to the epoch of
+	// the format, so the archive only depends on the file contents and modes.
+	ZeroTimes bool
+
+	// Progress is called with the bytes of file content archived so far.
+	// The total is unknown.
+	Progress ioutil.ProgressFunc
+}
+
+// Archive writes the files in fsys to w as an archive.
+// Entries are sorted by path and ownership is omitted, so the output is
+// deterministic for the same files and, with ZeroTimes, modification times.
+// Symbolic links are archived as links if fsys implements [fs.ReadLinkFS].
+func Archive(fsys fs.FS, w io.Writer, format ArchiveFormat, opts ArchiveOptions) error {
+	var done int64
+	progress := func(n int64) {
+		done += n
+		if opts.Progress != nil {
+			opts.Progress(done, -1)
+		}
+	}
+
+	switch format {
+	case ArchiveTarGz:
+		return archiveTarGz(fsys, w, opts, progress)
+	case ArchiveZip:
+		return archiveZip(fsys, w, opts, progress)
+	default:
+		return fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+// archiveEntry is called for each file to archive, in path order.
+type archiveEntry func(name string, info fs.FileInfo) error
+
+// walkArchive calls fn for each file and directory in fsys.
+func walkArchive(fsys fs.FS, fn archiveEntry) error {
+	// WalkDir visits entries in lexical order within each directory
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error { //nolint:wrapcheck
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("getting file info for %s: %w", name, err)
+		}
+		return fn(name, info)
+	})
+}
+
+// copyFileTo copies the contents of a file in fsys to w.
+func copyFileTo(w io.Writer, fsys fs.FS, name string, progress func(int64)) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", name, err)
+	}
+	defer f.Close()
+	n, err := io.Copy(w, f)
+	progress(n)
+	if err != nil {
+		return fmt.Errorf("archiving %s: %w", name, err)
+	}
+	return nil
+}
+
+func archiveTarGz(fsys fs.FS, w io.Writer, opts ArchiveOptions, progress func(int64)) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	err := walkArchive(fsys, func(name string, info fs.FileInfo) error {
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			var err error
+			if link, err = fs.ReadLink(fsys, name); err != nil {
+				return fmt.Errorf("reading symbolic link %s: %w", name, err)
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("creating header for %s: %w", name, err)
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		hdr.AccessTime, hdr.ChangeTime = time.Time{}, time.Time{}
+		hdr.Format = tar.FormatPAX
+		if opts.ZeroTimes {
+			hdr.ModTime = time.Unix(0, 0)
+		} else {
+			hdr.ModTime = hdr.ModTime.Truncate(time.Second)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing header for %s: %w", name, err)
+		}
+		if info.Mode().IsRegular() {
+			return copyFileTo(tw, fsys, name, progress)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar archive: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("closing gzip stream: %w", err)
+	}
+	return nil
+}
+
+func archiveZip(fsys fs.FS, w io.Writer, opts ArchiveOptions, progress func(int64)) error {
+	zw := zip.NewWriter(w)
+	err := walkArchive(fsys, func(name string, info fs.FileInfo) error {
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return fmt.Errorf("creating header for %s: %w", name, err)
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		} else {
+			hdr.Method = zip.Deflate
+		}
+		if opts.ZeroTimes {
+			hdr.Modified = zipEpoch
+		}
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return fmt.Errorf("writing header for %s: %w", name, err)
+		}
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			// zip stores the link target as the content
+			link, err := fs.ReadLink(fsys, name)
+			if err != nil {
+				return fmt.Errorf("reading symbolic link %s: %w", name, err)
+			}
+			_, err = io.WriteString(fw, link)
+			return err //nolint:wrapcheck
+		case info.Mode().IsRegular():
+			return copyFileTo(fw, fsys, name, progress)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("closing zip archive: %w", err)
+	}
+	return nil
+}
+
+// UnarchiveOptions stores options for extracting an archive.
+type UnarchiveOptions struct {
+	// Format is the archive format, detected from the content if empty.
+	Format ArchiveFormat
+
+	// Progress is called with the bytes of the archive read so far and the
+	// size of the archive, or -1 if unknown.
+	Progress ioutil.ProgressFunc
+}
+
+// Unarchive extracts the archive read from r to dstDir, creating dstDir if needed.
+// Entries with absolute paths, paths outside of dstDir, or symbolic links
+// pointing outside of dstDir are rejected with [ErrUnsafePath]. Entries are
+// written with an [os.Root], so entries written through symbolic links
+// extracted earlier cannot escape dstDir either.
+func Unarchive(r io.Reader, dstDir string, opts UnarchiveOptions) error {
+	if opts.Progress != nil {
+		r = ioutil.NewProgressReader(r, -1, opts.Progress)
+	}
+	br := bufio.NewReader(r)
+	format := opts.Format
+	if format == "" {
+		magic, _ := br.Peek(4)
+		switch {
+		case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+			format = ArchiveTarGz
+		case bytes.HasPrefix(magic, []byte("PK\x03\x04")):
+			format = ArchiveZip
+		default:
+			return errors.New("unrecognized archive format")
+		}
+	}
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return fmt.Errorf("creating destination directory: %w", err)
+	}
+	root, err := os.OpenRoot(dstDir)
+	if err != nil {
+		return fmt.Errorf("opening destination directory: %w", err)
+	}
+	defer root.Close()
+
+	switch format {
+	case ArchiveTarGz:
+		return unarchiveTarGz(br, root)
+	case ArchiveZip:
+		return unarchiveZip(br, root)
+	default:
+		return fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+// safePath returns the local path of an archive entry, or an error if it is outside the destination.
+func safePath(name string) (string, error) {
+	name = path.Clean(name)
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+	return filepath.FromSlash(name), nil
+}
+
+// extractSymlink creates a symbolic link for an archive entry, rejecting targets outside the destination.
+func extractSymlink(root *os.Root, name, target string) error {
+	if path.IsAbs(target) || filepath.IsAbs(target) ||
+		!filepath.IsLocal(filepath.Join(filepath.Dir(name), filepath.FromSlash(target))) {
+		return fmt.Errorf("%w: %s links to %s", ErrUnsafePath, filepath.ToSlash(name), target)
+	}
+	if err := root.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if err := root.Symlink(target, name); err != nil {
+		return fmt.Errorf("creating symbolic link: %w", err)
+	}
+	return nil
+}
+
+// extractFile writes the content of an archive entry to name.
+func extractFile(root *os.Root, name string, r io.Reader, mode fs.FileMode) (err error) {
+	if err := root.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	f, err := root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.ToSlash(name), err)
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	if _, err := io.Copy(f, r); err != nil { //nolint:gosec // size is bounded by the archive
+		return fmt.Errorf("extracting %s: %w", filepath.ToSlash(name), err)
+	}
+	return nil
+}
+
+// extractDir creates the directory of an archive entry.
+func extractDir(root *os.Root, name string) error {
+	if err := root.MkdirAll(name, 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	return nil
+}
+
+func unarchiveTarGz(r io.Reader, root *os.Root) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("opening gzip stream: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar archive: %w", err)
+		}
+		name, err := safePath(hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = extractDir(root, name)
+		case tar.TypeReg:
+			err = extractFile(root, name, tr, hdr.FileInfo().Mode())
+		case tar.TypeSymlink:
+			err = extractSymlink(root, name, hdr.Linkname)
+		default:
+			// skip hard links, devices, and other special files
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func unarchiveZip(r io.Reader, root *os.Root) error {
+	// zip requires random access, so buffer the archive in a temporary file
+	tmp, err := os.CreateTemp("", "unarchive-*.zip")
+	if err != nil {
+		return fmt.Errorf("buffering zip archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return fmt.Errorf("buffering zip archive: %w", err)
+	}
+
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return fmt.Errorf("reading zip archive: %w", err)
+	}
+	for _, zf := range zr.File {
+		if err := extractZipEntry(root, zf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractZipEntry extracts a file from a zip archive.
+func extractZipEntry(root *os.Root, zf *zip.File) error {
+	name, err := safePath(zf.Name)
+	if err != nil {
+		return err
+	}
+	mode := zf.Mode()
+	if mode.IsDir() {
+		return extractDir(root, name)
+	}
+	if !mode.IsRegular() && mode&fs.ModeSymlink == 0 {
+		return nil
+	}
+
+	rc, err := zf.Open()
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", zf.Name, err)
+	}
+	defer rc.Close()
+
+	if mode&fs.ModeSymlink != 0 {
+		target, err := io.ReadAll(io.LimitReader(rc, 4096))
+		if err != nil {
+			return fmt.Errorf("reading symbolic link %s: %w", zf.Name, err)
+		}
+		return extractSymlink(root, name, string(target))
+	}
+	return extractFile(root, name, rc, mode)
+}
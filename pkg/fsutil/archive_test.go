@@ -0,0 +1,90 @@
+package fsutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	src := fstest.MapFS{
+		"b.txt":     &fstest.MapFile{Data: []byte("bee"), Mode: 0o644, ModTime: time.Now()},
+		"dir/a.txt": &fstest.MapFile{Data: []byte("ay"), Mode: 0o600, ModTime: time.Now()},
+		"link":      &fstest.MapFile{Data: []byte("b.txt"), Mode: fs.ModeSymlink | 0o777},
+	}
+
+	for _, format := range []ArchiveFormat{ArchiveTarGz, ArchiveZip} {
+		t.Run(string(format), func(t *testing.T) {
+			var first, second bytes.Buffer
+			var archived int64
+			require.NoError(t, Archive(src, &first, format, ArchiveOptions{
+				ZeroTimes: true,
+				Progress:  func(done, _ int64) { archived = done },
+			}))
+			assert.Equal(t, int64(5), archived)
+
+			// deterministic output
+			src["b.txt"].ModTime = time.Now().Add(time.Hour)
+			require.NoError(t, Archive(src, &second, format, ArchiveOptions{ZeroTimes: true}))
+			assert.Equal(t, first.Bytes(), second.Bytes())
+
+			dst := t.TempDir()
+			require.NoError(t, Unarchive(&first, dst, UnarchiveOptions{}))
+			got, err := os.ReadFile(filepath.Join(dst, "dir", "a.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "ay", string(got))
+			info, err := os.Stat(filepath.Join(dst, "dir", "a.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+			target, err := os.Readlink(filepath.Join(dst, "link"))
+			require.NoError(t, err)
+			assert.Equal(t, "b.txt", target)
+		})
+	}
+}
+
+func TestUnarchiveUnsafe(t *testing.T) {
+	archive := func(hdrs ...*tar.Header) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		gw := gzip.NewWriter(buf)
+		tw := tar.NewWriter(gw)
+		for _, hdr := range hdrs {
+			require.NoError(t, tw.WriteHeader(hdr))
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gw.Close())
+		return buf
+	}
+
+	err := Unarchive(archive(&tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644}), t.TempDir(), UnarchiveOptions{})
+	assert.ErrorIs(t, err, ErrUnsafePath)
+
+	err = Unarchive(archive(&tar.Header{Name: "/etc/evil", Typeflag: tar.TypeReg, Mode: 0o644}), t.TempDir(), UnarchiveOptions{})
+	assert.ErrorIs(t, err, ErrUnsafePath)
+
+	err = Unarchive(archive(&tar.Header{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"}), t.TempDir(), UnarchiveOptions{})
+	assert.ErrorIs(t, err, ErrUnsafePath)
+
+	err = Unarchive(archive(&tar.Header{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "../ok"}), t.TempDir(), UnarchiveOptions{})
+	assert.NoError(t, err)
+
+	// Entries written through earlier links must not escape the destination
+	parent := t.TempDir()
+	dst := filepath.Join(parent, "dst")
+	err = Unarchive(archive(
+		&tar.Header{Name: "d", Typeflag: tar.TypeSymlink, Linkname: "."},
+		&tar.Header{Name: "d/e", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		&tar.Header{Name: "d/e/pwned", Typeflag: tar.TypeReg, Mode: 0o644},
+	), dst, UnarchiveOptions{})
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(parent, "pwned"))
+}
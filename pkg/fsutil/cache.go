@@ -0,0 +1,237 @@
+package fsutil
+
+import (
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ErrDigestMismatch is returned by [Cache.Put] when the content does not match the digest.
+var ErrDigestMismatch = errors.New("digest mismatch")
+
+// Cache is a content-addressable file cache bounded by size. Entries are
+// keyed by digest, e.g. "sha256:2c26b4...", and the least recently used
+// entries are evicted when the cache exceeds its maximum size.
+//
+// Cache is safe for concurrent use, including by multiple processes sharing
+// the cache directory.
+type Cache struct {
+	dir     string
+	maxSize int64
+	mu      sync.RWMutex
+}
+
+// NewCache creates a cache in dir, holding up to maxSize bytes.
+// A zero maxSize disables eviction.
+//
+// Applications should keep caches in their user cache directory:
+//
+//	cache, err := fsutil.NewCache(config.CacheDir("ace", "dt", "blobs"), resource.MustParse("1Gi"))
+func NewCache(dir string, maxSize resource.Quantity) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "blobs"), 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &Cache{dir: dir, maxSize: maxSize.Value()}, nil
+}
+
+// path returns the path of the entry for dgst.
+func (c *Cache) path(dgst string) (string, crypto.Hash, error) {
+	name, encoded, ok := strings.Cut(dgst, ":")
+	var algo crypto.Hash
+	for a, n := range hashNames {
+		if n == name {
+			algo = a
+		}
+	}
+	if !ok || algo == 0 {
+		return "", 0, fmt.Errorf("invalid digest %q: unsupported algorithm", dgst)
+	}
+	if _, err := hex.DecodeString(encoded); err != nil || len(encoded) != 2*algo.Size() {
+		return "", 0, fmt.Errorf("invalid digest %q: malformed hex", dgst)
+	}
+	return filepath.Join(c.dir, "blobs", name, encoded), algo, nil
+}
+
+// Get opens the entry for dgst, marking it as recently used.
+// Returns an error wrapping [fs.ErrNotExist] if the entry is not cached.
+func (c *Cache) Get(dgst string) (*os.File, error) {
+	p, _, err := c.path(dgst)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	unlock, err := c.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache entry: %w", err)
+	}
+	// the modification time records use, access times are unreliable on noatime mounts
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+	return f, nil
+}
+
+// Put adds the content of r to the cache as dgst, verifying the digest,
+// and evicts entries if the cache exceeds its maximum size.
+// Returns [ErrDigestMismatch] if the content does not match.
+func (c *Cache) Put(dgst string, r io.Reader) error {
+	p, algo, err := c.path(dgst)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	// write to a temporary file so readers never see partial entries
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := algo.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), r)
+	err = errors.Join(err, tmp.Close())
+	if err != nil {
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	if got := hashNames[algo] + ":" + hex.EncodeToString(h.Sum(nil)); got != dgst {
+		return fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, dgst, got)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	unlock, err := c.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("adding cache entry: %w", err)
+	}
+	return c.evict()
+}
+
+// Size returns the total size of the cached entries in bytes.
+func (c *Cache) Size() (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries, err := c.entries()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, e := range entries {
+		size += e.Size()
+	}
+	return size, nil
+}
+
+// Evict removes the least recently used entries until the cache is within its maximum size.
+func (c *Cache) Evict() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	unlock, err := c.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return c.evict()
+}
+
+// evict removes the least recently used entries, the caller must hold the exclusive lock.
+func (c *Cache) evict() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	var size int64
+	for _, e := range entries {
+		size += e.Size()
+	}
+
+	// oldest first
+	slices.SortFunc(entries, func(a, b cacheEntry) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+	var errs []error
+	for _, e := range entries {
+		if size <= c.maxSize {
+			break
+		}
+		if err := os.Remove(e.path); err != nil {
+			errs = append(errs, fmt.Errorf("evicting cache entry: %w", err))
+			continue
+		}
+		size -= e.Size()
+	}
+	return errors.Join(errs...)
+}
+
+// cacheEntry is a cached file.
+type cacheEntry struct {
+	fs.FileInfo
+	path string
+}
+
+// entries lists the cached files.
+func (c *Cache) entries() ([]cacheEntry, error) {
+	var entries []cacheEntry
+	err := filepath.WalkDir(filepath.Join(c.dir, "blobs"), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		entries = append(entries, cacheEntry{info, p})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing cache entries: %w", err)
+	}
+	return entries, nil
+}
+
+// lock locks the cache directory between processes, returning the function to unlock it.
+func (c *Cache) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(filepath.Join(c.dir, ".lock"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache lock: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking cache: %w", err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
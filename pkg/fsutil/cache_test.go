@@ -0,0 +1,73 @@
+package fsutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func sha256Digest(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCache(dir, resource.MustParse("10"))
+	require.NoError(t, err)
+
+	// backdate entries so the access order is unambiguous
+	put := func(data string, age time.Duration) {
+		t.Helper()
+		dgst := sha256Digest(data)
+		require.NoError(t, cache.Put(dgst, strings.NewReader(data)))
+		p, _, err := cache.path(dgst)
+		require.NoError(t, err)
+		mtime := time.Now().Add(-age)
+		require.NoError(t, os.Chtimes(p, mtime, mtime))
+	}
+	get := func(data string) (string, error) {
+		t.Helper()
+		f, err := cache.Get(sha256Digest(data))
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		return string(b), err
+	}
+
+	put("aaaa", 3*time.Hour)
+	put("bbbb", 2*time.Hour)
+	got, err := get("aaaa") // a is now the most recently used
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa", got)
+
+	put("cccc", time.Hour) // exceeds 10 bytes, evicting b
+	_, err = get("bbbb")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = get("aaaa")
+	assert.NoError(t, err)
+
+	size, err := cache.Size()
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), size)
+
+	err = cache.Put(sha256Digest("x"), strings.NewReader("y"))
+	assert.ErrorIs(t, err, ErrDigestMismatch)
+	entries, err := os.ReadDir(filepath.Join(dir, "blobs", "sha256"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Error(t, cache.Put("md5:abc", strings.NewReader("")))
+}
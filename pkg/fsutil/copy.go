@@ -0,0 +1,249 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/act3-ai/go-common/pkg/ioutil"
+)
+
+// SymlinkMode controls how [CopyDir] handles symbolic links.
+type SymlinkMode int
+
+const (
+	// SymlinkSkip skips symbolic links.
+	SymlinkSkip SymlinkMode = iota
+	// SymlinkFollow copies the files and directories symbolic links point to.
+	// Links that form a cycle are not detected.
+	SymlinkFollow
+	// SymlinkCopy recreates symbolic links with the same target.
+	// The source filesystem must implement [fs.ReadLinkFS].
+	SymlinkCopy
+)
+
+// CopyOptions stores options for copying a filesystem.
+type CopyOptions struct {
+	// Include are glob patterns (see [path.Match]) of files to copy, matched
+	// against the slash-separated path and the base name. All files are copied if empty.
+	Include []string
+
+	// Exclude are glob patterns of files and directories to skip, matched like Include.
+	Exclude []string
+
+	// Symlinks controls how symbolic links are handled.
+	Symlinks SymlinkMode
+
+	// PreserveMode copies the permission bits of files and directories.
+	PreserveMode bool
+
+	// PreserveTimes copies the modification times of files and directories.
+	PreserveTimes bool
+
+	// Progress is called with the bytes copied so far and the total bytes to copy.
+	Progress ioutil.ProgressFunc
+}
+
+// matches reports whether name matches any of the patterns.
+func matches(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	})
+}
+
+// CopyDir copies the files in src to dstDir, creating dstDir if needed.
+// Existing files are overwritten. Directories that are not excluded are
+// created even if they contain no included files. Devices, sockets, and
+// named pipes are skipped.
+func CopyDir(dstDir string, src fs.FS, opts CopyOptions) error {
+	c := &copier{dstDir: dstDir, src: src, opts: opts, total: -1}
+	if opts.Progress != nil {
+		if err := c.walk(".", c.addSize); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return fmt.Errorf("creating destination directory: %w", err)
+	}
+	if err := c.walk(".", c.copy); err != nil {
+		return err
+	}
+
+	// set directory times last, since copying their contents changes them
+	for _, d := range slices.Backward(c.dirTimes) {
+		if err := os.Chtimes(d.path, d.mtime, d.mtime); err != nil {
+			return fmt.Errorf("setting directory times: %w", err)
+		}
+	}
+	return nil
+}
+
+// dirTime is the modification time to set on a copied directory.
+type dirTime struct {
+	path  string
+	mtime time.Time
+}
+
+// copier copies a filesystem.
+type copier struct {
+	dstDir   string
+	src      fs.FS
+	opts     CopyOptions
+	done     int64
+	total    int64
+	dirTimes []dirTime
+}
+
+// walk walks the source from root, calling fn with the filtered files and
+// directories, resolving symbolic links according to the symlink mode.
+func (c *copier) walk(root string, fn func(name string, info fs.FileInfo) error) error {
+	return fs.WalkDir(c.src, root, func(name string, d fs.DirEntry, err error) error { //nolint:wrapcheck
+		if err != nil {
+			return err
+		}
+		if name != "." && matches(c.opts.Exclude, name) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 && name != root {
+			switch c.opts.Symlinks {
+			case SymlinkSkip:
+				return nil
+			case SymlinkFollow:
+				info, err := fs.Stat(c.src, name)
+				if err != nil {
+					return fmt.Errorf("following symbolic link %s: %w", name, err)
+				}
+				if info.IsDir() {
+					return c.walk(name, fn)
+				}
+				return c.file(name, info, fn)
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("getting file info for %s: %w", name, err)
+		}
+		if d.IsDir() {
+			return fn(name, info)
+		}
+		return c.file(name, info, fn)
+	})
+}
+
+// file calls fn for a file that matches the include patterns.
+func (c *copier) file(name string, info fs.FileInfo, fn func(name string, info fs.FileInfo) error) error {
+	if len(c.opts.Include) > 0 && !matches(c.opts.Include, name) {
+		return nil
+	}
+	return fn(name, info)
+}
+
+// addSize adds the size of a regular file to the total.
+func (c *copier) addSize(_ string, info fs.FileInfo) error {
+	if info.Mode().IsRegular() {
+		c.total = max(c.total, 0) + info.Size()
+	}
+	return nil
+}
+
+// copy copies a file, directory, or symbolic link.
+func (c *copier) copy(name string, info fs.FileInfo) error {
+	dst := filepath.Join(c.dstDir, filepath.FromSlash(name))
+	switch {
+	case info.IsDir():
+		if err := os.MkdirAll(dst, 0o755); err != nil {
+			return fmt.Errorf("creating directory: %w", err)
+		}
+	case info.Mode()&fs.ModeSymlink != 0:
+		return c.copySymlink(dst, name)
+	case info.Mode().IsRegular():
+		if err := c.copyFile(dst, name, info); err != nil {
+			return err
+		}
+	default:
+		// skip devices, sockets, and named pipes
+		return nil
+	}
+
+	if c.opts.PreserveMode {
+		if err := os.Chmod(dst, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("setting mode of %s: %w", dst, err)
+		}
+	}
+	if c.opts.PreserveTimes {
+		if info.IsDir() {
+			c.dirTimes = append(c.dirTimes, dirTime{dst, info.ModTime()})
+		} else if err := os.Chtimes(dst, info.ModTime(), info.ModTime()); err != nil {
+			return fmt.Errorf("setting times of %s: %w", dst, err)
+		}
+	}
+	return nil
+}
+
+// copyFile copies the contents of a regular file.
+func (c *copier) copyFile(dst, name string, info fs.FileInfo) (err error) {
+	// parent directories may have been skipped by include filters
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	in, err := c.src.Open(name)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", name, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", dst, err)
+	}
+	defer func() {
+		err = errors.Join(err, out.Close())
+	}()
+
+	var r io.Reader = in
+	if c.opts.Progress != nil {
+		r = ioutil.NewProgressReader(in, info.Size(), func(done, _ int64) {
+			c.opts.Progress(c.done+done, c.total)
+		})
+	}
+	n, err := io.Copy(out, r)
+	c.done += n
+	if err != nil {
+		return fmt.Errorf("copying %s: %w", name, err)
+	}
+	return nil
+}
+
+// copySymlink recreates a symbolic link.
+func (c *copier) copySymlink(dst, name string) error {
+	target, err := fs.ReadLink(c.src, name)
+	if err != nil {
+		return fmt.Errorf("reading symbolic link %s: %w", name, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("replacing %s: %w", dst, err)
+	}
+	if err := os.Symlink(target, dst); err != nil {
+		return fmt.Errorf("creating symbolic link: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyDir(t *testing.T) {
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := fstest.MapFS{
+		"a.txt":         &fstest.MapFile{Data: []byte("a"), Mode: 0o600, ModTime: mtime},
+		"b.log":         &fstest.MapFile{Data: []byte("bb")},
+		"sub/c.txt":     &fstest.MapFile{Data: []byte("ccc")},
+		"skip/d.txt":    &fstest.MapFile{Data: []byte("dddd")},
+		"sub":           &fstest.MapFile{Mode: os.ModeDir | 0o700, ModTime: mtime},
+		"link.txt":      &fstest.MapFile{Data: []byte("a.txt"), Mode: os.ModeSymlink},
+		"sub/other.txt": &fstest.MapFile{Data: []byte("other")},
+	}
+
+	t.Run("filters and attributes", func(t *testing.T) {
+		dst := t.TempDir()
+		var done, total int64
+		err := CopyDir(dst, src, CopyOptions{
+			Include:       []string{"*.txt"},
+			Exclude:       []string{"skip", "sub/other.txt"},
+			PreserveMode:  true,
+			PreserveTimes: true,
+			Progress:      func(d, tot int64) { done, total = d, tot },
+		})
+		require.NoError(t, err)
+
+		got, err := os.ReadFile(filepath.Join(dst, "sub", "c.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "ccc", string(got))
+		assert.NoFileExists(t, filepath.Join(dst, "b.log"))
+		assert.NoDirExists(t, filepath.Join(dst, "skip"))
+		assert.NoFileExists(t, filepath.Join(dst, "sub", "other.txt"))
+		assert.NoFileExists(t, filepath.Join(dst, "link.txt"))
+
+		info, err := os.Stat(filepath.Join(dst, "a.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		assert.True(t, mtime.Equal(info.ModTime()))
+
+		info, err = os.Stat(filepath.Join(dst, "sub"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+		assert.True(t, mtime.Equal(info.ModTime()))
+
+		assert.Equal(t, int64(4), total)
+		assert.Equal(t, int64(4), done)
+	})
+
+	t.Run("copy symlinks", func(t *testing.T) {
+		dst := t.TempDir()
+		require.NoError(t, CopyDir(dst, src, CopyOptions{Symlinks: SymlinkCopy}))
+		target, err := os.Readlink(filepath.Join(dst, "link.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "a.txt", target)
+	})
+
+	t.Run("follow symlinks", func(t *testing.T) {
+		dst := t.TempDir()
+		require.NoError(t, CopyDir(dst, src, CopyOptions{Symlinks: SymlinkFollow}))
+		got, err := os.ReadFile(filepath.Join(dst, "link.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "a", string(got))
+		info, err := os.Lstat(filepath.Join(dst, "link.txt"))
+		require.NoError(t, err)
+		assert.True(t, info.Mode().IsRegular())
+	})
+}
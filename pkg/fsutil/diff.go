@@ -0,0 +1,182 @@
+package fsutil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DiffKind is the kind of difference for a path.
+type DiffKind string
+
+// Kinds of differences.
+const (
+	DiffAdded    DiffKind = "added"    // only in fsB
+	DiffRemoved  DiffKind = "removed"  // only in fsA
+	DiffModified DiffKind = "modified" // in both but different
+)
+
+// DiffEntry describes the difference for a path between two filesystems.
+type DiffEntry struct {
+	Path string
+	Kind DiffKind
+
+	// A and B are the file info of the path in fsA and fsB, nil if absent.
+	A, B fs.FileInfo
+
+	// Changes describe the differing attributes of a modified path,
+	// e.g. "size: 3 -> 5" or "content: differs at byte 2".
+	Changes []string
+}
+
+// String formats the entry as a line of a tree diff.
+func (e DiffEntry) String() string {
+	switch e.Kind {
+	case DiffAdded:
+		return "+ " + e.Path
+	case DiffRemoved:
+		return "- " + e.Path
+	default:
+		return "~ " + e.Path + " (" + strings.Join(e.Changes, ", ") + ")"
+	}
+}
+
+// DiffFSEntries returns the differences between two filesystems, sorted by path.
+// Hidden files and directories are ignored, as in [DiffFS].
+func DiffFSEntries(fsA, fsB fs.FS, opts ComparisonOpts) ([]DiffEntry, error) {
+	fsInfoA, err := getFSInfo(fsA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fsInfo for fsA: %w", err)
+	}
+	fsInfoB, err := getFSInfo(fsB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fsInfo for fsB: %w", err)
+	}
+
+	infosA := fsInfoA.all()
+	infosB := fsInfoB.all()
+	paths := slices.Sorted(maps.Keys(infosA))
+	for p := range infosB {
+		if _, ok := infosA[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+
+	var diffs []DiffEntry
+	for _, p := range paths {
+		a, inA := infosA[p]
+		b, inB := infosB[p]
+		switch {
+		case !inB:
+			diffs = append(diffs, DiffEntry{Path: p, Kind: DiffRemoved, A: a})
+		case !inA:
+			diffs = append(diffs, DiffEntry{Path: p, Kind: DiffAdded, B: b})
+		default:
+			changes, err := describeChanges(fsA, fsB, p, a, b, opts)
+			if err != nil {
+				return nil, err
+			}
+			if len(changes) > 0 {
+				diffs = append(diffs, DiffEntry{Path: p, Kind: DiffModified, A: a, B: b, Changes: changes})
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// all returns the files and directories.
+func (fsI *fsInfo) all() map[string]fs.FileInfo {
+	all := make(map[string]fs.FileInfo, len(fsI.files)+len(fsI.dirs))
+	maps.Copy(all, fsI.files)
+	maps.Copy(all, fsI.dirs)
+	return all
+}
+
+// describeChanges describes the differing attributes of a path.
+func describeChanges(fsA, fsB fs.FS, path string, a, b fs.FileInfo, opts ComparisonOpts) ([]string, error) {
+	var changes []string
+	if a.IsDir() != b.IsDir() {
+		return []string{fmt.Sprintf("type: %s -> %s", fileType(a), fileType(b))}, nil
+	}
+	if opts.Name && a.Name() != b.Name() {
+		changes = append(changes, fmt.Sprintf("name: %s -> %s", a.Name(), b.Name()))
+	}
+	if !a.IsDir() && opts.Size && a.Size() != b.Size() {
+		changes = append(changes, fmt.Sprintf("size: %d -> %d", a.Size(), b.Size()))
+	}
+	if opts.Mode && a.Mode() != b.Mode() {
+		changes = append(changes, fmt.Sprintf("mode: %v -> %v", a.Mode(), b.Mode()))
+	}
+	if !a.IsDir() && opts.Content {
+		offset, err := firstDifference(fsA, fsB, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare file contents for path %s: %w", path, err)
+		}
+		if offset >= 0 {
+			changes = append(changes, fmt.Sprintf("content: differs at byte %d", offset))
+		}
+	}
+	return changes, nil
+}
+
+// fileType names the type of a file.
+func fileType(info fs.FileInfo) string {
+	if info.IsDir() {
+		return "dir"
+	}
+	return "file"
+}
+
+// firstDifference returns the offset of the first differing byte of the
+// file in both filesystems, or -1 if the contents are equal.
+func firstDifference(fsA, fsB fs.FS, path string) (int64, error) {
+	fA, err := fsA.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file in fsA: %w", err)
+	}
+	defer fA.Close()
+	fB, err := fsB.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file in fsB: %w", err)
+	}
+	defer fB.Close()
+
+	rA, rB := bufio.NewReader(fA), bufio.NewReader(fB)
+	for offset := int64(0); ; offset++ {
+		byteA, errA := rA.ReadByte()
+		byteB, errB := rB.ReadByte()
+		switch {
+		case errors.Is(errA, io.EOF) && errors.Is(errB, io.EOF):
+			return -1, nil
+		case errA != nil && !errors.Is(errA, io.EOF):
+			return 0, fmt.Errorf("failed to read from fileA: %w", errA)
+		case errB != nil && !errors.Is(errB, io.EOF):
+			return 0, fmt.Errorf("failed to read from fileB: %w", errB)
+		case errA != nil || errB != nil || byteA != byteB:
+			return offset, nil
+		}
+	}
+}
+
+// FormatDiff renders the differences as a tree diff for test failure messages, e.g.:
+//
+//	--- a
+//	+++ b
+//	- removed.txt
+//	+ dir/added.txt
+//	~ changed.txt (size: 3 -> 5, content: differs at byte 2)
+func FormatDiff(nameA, nameB string, diffs []DiffEntry) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "--- %s\n+++ %s\n", nameA, nameB)
+	for _, d := range diffs {
+		b.WriteString(d.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package fsutil
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFSEntries(t *testing.T) {
+	fsA := fstest.MapFS{
+		"same.txt":    &fstest.MapFile{Data: []byte("same")},
+		"changed.txt": &fstest.MapFile{Data: []byte("abc")},
+		"mode.txt":    &fstest.MapFile{Data: []byte("m"), Mode: 0o644},
+		"removed.txt": &fstest.MapFile{Data: []byte("r")},
+		"kind":        &fstest.MapFile{Data: []byte("k")},
+	}
+	fsB := fstest.MapFS{
+		"same.txt":      &fstest.MapFile{Data: []byte("same")},
+		"changed.txt":   &fstest.MapFile{Data: []byte("abxde")},
+		"mode.txt":      &fstest.MapFile{Data: []byte("m"), Mode: 0o600},
+		"dir/added.txt": &fstest.MapFile{Data: []byte("a")},
+		"kind/file":     &fstest.MapFile{Data: []byte("k")},
+		"kind":          &fstest.MapFile{Mode: fs.ModeDir | 0o755},
+	}
+
+	diffs, err := DiffFSEntries(fsA, fsB, DefaultComparisonOpts)
+	require.NoError(t, err)
+	assert.Equal(t, `--- a
++++ b
+~ changed.txt (size: 3 -> 5, content: differs at byte 2)
++ dir
++ dir/added.txt
+~ kind (type: file -> dir)
++ kind/file
+~ mode.txt (mode: -rw-r--r-- -> -rw-------)
+- removed.txt
+`, FormatDiff("a", "b", diffs))
+
+	diffs, err = DiffFSEntries(fsA, fsA, DefaultComparisonOpts)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
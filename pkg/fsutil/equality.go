@@ -70,6 +70,7 @@ func EqualFilesystem(fsA, fsB fs.FS, opts ComparisonOpts) error {
 }
 
 // DiffFS returns the differences between two filesystems. (A-B)
+// Use [DiffFSEntries] for the kind of each difference and the attributes that differ.
 func DiffFS(fsA, fsB fs.FS, opts ComparisonOpts) ([]fs.FileInfo, error) {
 	fsInfoA, err := getFSInfo(fsA)
 	if err != nil {
@@ -0,0 +1,25 @@
+//go:build (!unix || aix) && !windows
+
+package fsutil
+
+import (
+	"os"
+	"sync"
+)
+
+// cacheLock stands in for file locks on platforms without flock (including AIX), so caches
+// are only locked between goroutines of this process.
+var cacheLock sync.Mutex
+
+// lockFile locks the in-process cache lock, waiting until it is available.
+// Shared locks are exclusive here.
+func lockFile(_ *os.File, _ bool) error {
+	cacheLock.Lock()
+	return nil
+}
+
+// unlockFile removes the lock placed by lockFile.
+func unlockFile(_ *os.File) error {
+	cacheLock.Unlock()
+	return nil
+}
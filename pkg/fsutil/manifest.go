@@ -0,0 +1,190 @@
+package fsutil
+
+import (
+	"crypto"
+	_ "crypto/sha256" // register hash functions
+	_ "crypto/sha512" // register hash functions
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+// hashNames are the digest prefixes of the supported hash algorithms.
+var hashNames = map[crypto.Hash]string{
+	crypto.SHA256: "sha256",
+	crypto.SHA384: "sha384",
+	crypto.SHA512: "sha512",
+}
+
+// ManifestEntry describes a file or directory in a [Manifest].
+type ManifestEntry struct {
+	// Path is the slash-separated path of the file.
+	Path string `json:"path"`
+
+	// Digest is the digest of the file contents, or the target of a symbolic
+	// link, in the form "algorithm:hex", e.g. "sha256:2c26b4...". Empty for directories.
+	Digest string `json:"digest,omitempty"`
+
+	// Size is the size of a regular file in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// Mode is the file type and permission bits.
+	Mode fs.FileMode `json:"mode"`
+}
+
+// Manifest describes the contents of a filesystem, sorted by path.
+type Manifest []ManifestEntry
+
+// HashTree produces a manifest of the files and directories in fsys.
+// The manifest is deterministic, so manifests of identical trees are equal.
+// Supported algorithms are SHA-256, SHA-384, and SHA-512.
+func HashTree(fsys fs.FS, algo crypto.Hash) (Manifest, error) {
+	if _, ok := hashNames[algo]; !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", algo)
+	}
+
+	var manifest Manifest
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("getting file info for %s: %w", name, err)
+		}
+		entry := ManifestEntry{
+			Path: name,
+			Mode: info.Mode() & (fs.ModeType | fs.ModePerm),
+		}
+
+		switch {
+		case info.Mode().IsRegular():
+			entry.Size = info.Size()
+			entry.Digest, err = digestFile(fsys, name, algo)
+		case info.Mode()&fs.ModeSymlink != 0:
+			var target string
+			target, err = fs.ReadLink(fsys, name)
+			entry.Digest = digest(algo, strings.NewReader(target))
+		}
+		if err != nil {
+			return fmt.Errorf("hashing %s: %w", name, err)
+		}
+		manifest = append(manifest, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk filesystem: %w", err)
+	}
+	// WalkDir order differs from path order for names sorting before "/", e.g. "a-b" and "a/b"
+	slices.SortFunc(manifest, func(a, b ManifestEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return manifest, nil
+}
+
+// digestFile computes the digest of a file's contents.
+func digestFile(fsys fs.FS, name string, algo crypto.Hash) (string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	defer f.Close()
+
+	h := algo.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	return hashNames[algo] + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// digest computes the digest of r, which must not fail.
+func digest(algo crypto.Hash, r io.Reader) string {
+	h := algo.New()
+	_, _ = io.Copy(h, r)
+	return hashNames[algo] + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// algorithm returns the hash algorithm used by the manifest, SHA-256 if it has no digests.
+func (m Manifest) algorithm() (crypto.Hash, error) {
+	for _, entry := range m {
+		name, _, ok := strings.Cut(entry.Digest, ":")
+		if !ok {
+			continue
+		}
+		for algo, n := range hashNames {
+			if n == name {
+				return algo, nil
+			}
+		}
+		return 0, fmt.Errorf("unsupported digest algorithm %q for %s", name, entry.Path)
+	}
+	return crypto.SHA256, nil
+}
+
+// ManifestDiff is the difference between two manifests.
+type ManifestDiff struct {
+	Added   []string // paths only in the second manifest
+	Removed []string // paths only in the first manifest
+	Changed []string // paths with different digests, sizes, or modes
+}
+
+// Empty reports whether the manifests are identical.
+func (d ManifestDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffManifests compares manifests a and b. The paths in the diff are sorted.
+func DiffManifests(a, b Manifest) ManifestDiff {
+	var diff ManifestDiff
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j >= len(b) || (i < len(a) && a[i].Path < b[j].Path):
+			diff.Removed = append(diff.Removed, a[i].Path)
+			i++
+		case i >= len(a) || b[j].Path < a[i].Path:
+			diff.Added = append(diff.Added, b[j].Path)
+			j++
+		default:
+			if a[i] != b[j] {
+				diff.Changed = append(diff.Changed, a[i].Path)
+			}
+			i++
+			j++
+		}
+	}
+	return diff
+}
+
+// VerifyTree checks that fsys matches the manifest, using the manifest's hash algorithm.
+// The error describes all the differences.
+func VerifyTree(fsys fs.FS, manifest Manifest) error {
+	algo, err := manifest.algorithm()
+	if err != nil {
+		return err
+	}
+	actual, err := HashTree(fsys, algo)
+	if err != nil {
+		return err
+	}
+
+	diff := DiffManifests(manifest, actual)
+	var errs []error
+	for _, p := range diff.Removed {
+		errs = append(errs, fmt.Errorf("missing: %s", p))
+	}
+	for _, p := range diff.Added {
+		errs = append(errs, fmt.Errorf("unexpected: %s", p))
+	}
+	for _, p := range diff.Changed {
+		errs = append(errs, fmt.Errorf("modified: %s", p))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package fsutil
+
+import (
+	"crypto"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashTree(t *testing.T) {
+	fsys := fstest.MapFS{
+		"b.txt":     &fstest.MapFile{Data: []byte("foo"), Mode: 0o644},
+		"a/c.txt":   &fstest.MapFile{Data: []byte("bar"), Mode: 0o600},
+		"a":         &fstest.MapFile{Mode: fs.ModeDir | 0o755},
+		"link":      &fstest.MapFile{Data: []byte("b.txt"), Mode: fs.ModeSymlink | 0o777},
+		"a/empty/x": &fstest.MapFile{Data: []byte{}, Mode: 0o644},
+	}
+	manifest, err := HashTree(fsys, crypto.SHA256)
+	require.NoError(t, err)
+
+	paths := make([]string, len(manifest))
+	for i, e := range manifest {
+		paths[i] = e.Path
+	}
+	assert.Equal(t, []string{"a", "a/c.txt", "a/empty", "a/empty/x", "b.txt", "link"}, paths)
+	assert.Equal(t, ManifestEntry{
+		Path:   "b.txt",
+		Digest: "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:   3,
+		Mode:   0o644,
+	}, manifest[4])
+	assert.Empty(t, manifest[0].Digest)
+
+	require.NoError(t, VerifyTree(fsys, manifest))
+
+	modified := fstest.MapFS{
+		"b.txt":     &fstest.MapFile{Data: []byte("FOO"), Mode: 0o644},
+		"a/c.txt":   &fstest.MapFile{Data: []byte("bar"), Mode: 0o600},
+		"a":         &fstest.MapFile{Mode: fs.ModeDir | 0o755},
+		"new.txt":   &fstest.MapFile{Data: []byte("new")},
+		"link":      &fstest.MapFile{Data: []byte("b.txt"), Mode: fs.ModeSymlink | 0o777},
+		"a/empty/x": &fstest.MapFile{Data: []byte{}, Mode: 0o644},
+	}
+	assert.EqualError(t, VerifyTree(modified, manifest), "unexpected: new.txt\nmodified: b.txt")
+
+	other, err := HashTree(modified, crypto.SHA256)
+	require.NoError(t, err)
+	assert.Equal(t, ManifestDiff{Added: []string{"new.txt"}, Changed: []string{"b.txt"}}, DiffManifests(manifest, other))
+	assert.True(t, DiffManifests(manifest, manifest).Empty())
+}
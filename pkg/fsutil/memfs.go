@@ -0,0 +1,129 @@
+package fsutil
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sync"
+	"testing/fstest"
+	"time"
+)
+
+// WritableFS is a filesystem that test fixtures can be added to.
+type WritableFS interface {
+	fs.FS
+
+	// AddDir creates a directory and any missing parents.
+	AddDir(name string) error
+
+	// AddFileWithData creates or replaces a file with the given contents,
+	// creating any missing parent directories.
+	AddFileWithData(name string, data []byte) error
+
+	// AddFileOfSize creates or replaces a file of the given size,
+	// creating any missing parent directories.
+	AddFileOfSize(name string, size int64) error
+}
+
+// MemFS is an in-memory [WritableFS] for building fixture trees without disk IO.
+// Files are created with mode 0644 and directories with mode 0755, matching
+// files created on disk with the common umask, so [EqualFilesystem] and [DiffFS]
+// can compare a MemFS to a directory on disk.
+// It is safe for concurrent use.
+type MemFS struct {
+	mu    sync.RWMutex
+	files fstest.MapFS
+}
+
+var _ WritableFS = (*MemFS)(nil)
+
+// NewMemFS creates an empty in-memory filesystem.
+func NewMemFS() *MemFS {
+	return &MemFS{files: fstest.MapFS{}}
+}
+
+// AddDir implements [WritableFS].
+func (m *MemFS) AddDir(name string) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.mkdirAll("mkdir", name)
+}
+
+// AddFileWithData implements [WritableFS].
+func (m *MemFS) AddFileWithData(name string, data []byte) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if f, ok := m.files[name]; ok && f.Mode.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrExist}
+	}
+	if err := m.mkdirAll("write", path.Dir(name)); err != nil {
+		return err
+	}
+	m.files[name] = &fstest.MapFile{
+		Data:    append([]byte(nil), data...),
+		Mode:    0o644,
+		ModTime: time.Now(),
+	}
+	return nil
+}
+
+// AddFileOfSize implements [WritableFS].
+// The contents are a repeating byte pattern, so files of the same size are equal.
+func (m *MemFS) AddFileOfSize(name string, size int64) error {
+	if size < 0 {
+		return fmt.Errorf("invalid file size %d", size)
+	}
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return m.AddFileWithData(name, data)
+}
+
+// mkdirAll creates the directory name and its parents. The lock must be held.
+func (m *MemFS) mkdirAll(op, name string) error {
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		f, ok := m.files[dir]
+		switch {
+		case !ok:
+			m.files[dir] = &fstest.MapFile{Mode: fs.ModeDir | 0o755, ModTime: time.Now()}
+		case !f.Mode.IsDir():
+			return &fs.PathError{Op: op, Path: dir, Err: fs.ErrExist}
+		}
+	}
+	return nil
+}
+
+// Open implements [fs.FS].
+func (m *MemFS) Open(name string) (fs.File, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.Open(name) //nolint:wrapcheck
+}
+
+// ReadFile implements [fs.ReadFileFS].
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.ReadFile(name) //nolint:wrapcheck
+}
+
+// ReadDir implements [fs.ReadDirFS].
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.ReadDir(name) //nolint:wrapcheck
+}
+
+// Stat implements [fs.StatFS].
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.Stat(name) //nolint:wrapcheck
+}
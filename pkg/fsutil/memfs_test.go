@@ -0,0 +1,52 @@
+package fsutil
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemFS(t *testing.T) {
+	m := NewMemFS()
+	require.NoError(t, m.AddDir("empty/nested"))
+	require.NoError(t, m.AddFileWithData("dir/sub/a.txt", []byte("hello")))
+	require.NoError(t, m.AddFileOfSize("big.bin", 1000))
+
+	require.NoError(t, fstest.TestFS(m, "empty/nested", "dir/sub/a.txt", "big.bin"))
+
+	data, err := fs.ReadFile(m, "dir/sub/a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	info, err := fs.Stat(m, "big.bin")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), info.Size())
+
+	assert.ErrorIs(t, m.AddFileWithData("dir/sub", nil), fs.ErrExist)
+	assert.ErrorIs(t, m.AddDir("dir/sub/a.txt/x"), fs.ErrExist)
+	assert.ErrorIs(t, m.AddFileWithData("../escape", nil), fs.ErrInvalid)
+	assert.Error(t, m.AddFileOfSize("neg", -1))
+}
+
+func TestMemFSEqualDisk(t *testing.T) {
+	m := NewMemFS()
+	require.NoError(t, m.AddFileWithData("dir/a.txt", []byte("hello")))
+	require.NoError(t, m.AddFileOfSize("b.bin", 300))
+
+	dir := t.TempDir()
+	require.NoError(t, CopyDir(dir, m, CopyOptions{PreserveMode: true}))
+
+	opts := ComparisonOpts{Name: true, Size: true, Mode: true, Content: true}
+	assert.NoError(t, EqualFilesystem(m, os.DirFS(dir), opts))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dir", "a.txt"), []byte("world"), 0o644))
+	diffs, err := DiffFSEntries(m, os.DirFS(dir), opts)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "dir/a.txt", diffs[0].Path)
+}
@@ -0,0 +1,491 @@
+package genschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// ChangeKind classifies a change between two versions of a schema.
+type ChangeKind string
+
+// Defined change kinds.
+const (
+	ChangeFieldAdded          ChangeKind = "added optional field"
+	ChangeRequiredFieldAdded  ChangeKind = "added required field"
+	ChangeFieldRemoved        ChangeKind = "removed field"
+	ChangeTypeChanged         ChangeKind = "changed type"
+	ChangeConstraintTightened ChangeKind = "tightened constraint"
+	ChangeConstraintLoosened  ChangeKind = "loosened constraint"
+	ChangeDefinitionAdded     ChangeKind = "added definition"
+	ChangeDefinitionRemoved   ChangeKind = "removed definition"
+	ChangeSchemaAdded         ChangeKind = "added schema"
+	ChangeSchemaRemoved       ChangeKind = "removed schema"
+)
+
+// Breaking reports whether documents valid under the old schema may be invalid under the new schema.
+func (k ChangeKind) Breaking() bool {
+	switch k {
+	case ChangeRequiredFieldAdded, ChangeFieldRemoved, ChangeTypeChanged,
+		ChangeConstraintTightened, ChangeDefinitionRemoved, ChangeSchemaRemoved:
+		return true
+	default:
+		return false
+	}
+}
+
+// Change is a difference between two versions of a schema.
+type Change struct {
+	File   string     // Schema file, set by [CompareDirs]
+	Path   string     // Location of the change in documents, such as "$.spec.name"
+	Kind   ChangeKind // Classification of the change
+	Detail string     // Description of the change
+}
+
+// String formats the change as a single line.
+func (c Change) String() string {
+	s := c.Path + ": " + string(c.Kind)
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+	if c.File != "" {
+		s = c.File + ": " + s
+	}
+	return s
+}
+
+// Report groups changes between schemas by whether they are breaking.
+type Report struct {
+	Breaking    []Change
+	NonBreaking []Change
+}
+
+// NewReport classifies the changes as breaking or non-breaking.
+func NewReport(changes []Change) *Report {
+	r := &Report{}
+	for _, c := range changes {
+		if c.Kind.Breaking() {
+			r.Breaking = append(r.Breaking, c)
+		} else {
+			r.NonBreaking = append(r.NonBreaking, c)
+		}
+	}
+	return r
+}
+
+// IsBreaking reports whether the report contains breaking changes.
+func (r *Report) IsBreaking() bool {
+	return len(r.Breaking) > 0
+}
+
+// Err returns an error listing the breaking changes, or nil if there are none.
+// It is intended as a guard in release pipelines.
+func (r *Report) Err() error {
+	if !r.IsBreaking() {
+		return nil
+	}
+	errs := make([]error, 0, len(r.Breaking))
+	for _, c := range r.Breaking {
+		errs = append(errs, errors.New(c.String()))
+	}
+	return fmt.Errorf("found %d breaking schema changes:\n%w", len(r.Breaking), errors.Join(errs...))
+}
+
+// String formats the report as a list of breaking and non-breaking changes.
+func (r *Report) String() string {
+	b := &strings.Builder{}
+	for _, section := range []struct {
+		title   string
+		changes []Change
+	}{
+		{"Breaking changes", r.Breaking},
+		{"Non-breaking changes", r.NonBreaking},
+	} {
+		if len(section.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "%s:\n", section.title)
+		for _, c := range section.changes {
+			fmt.Fprintf(b, "  - %s\n", c)
+		}
+	}
+	return b.String()
+}
+
+// Compare classifies the changes from the old to the new version of a schema.
+//
+// References to definitions ("#/$defs/...") are followed, and definitions
+// of the schemas are compared by name.
+func Compare(oldSchema, newSchema *jsonschema.Schema) []Change {
+	c := &comparer{
+		oldRoot:  oldSchema,
+		newRoot:  newSchema,
+		visited:  map[[2]*jsonschema.Schema]bool{},
+		compared: map[*jsonschema.Schema]bool{},
+	}
+	c.compare("$", oldSchema, newSchema)
+	c.compareDefinitions("$defs", oldSchema.Definitions, newSchema.Definitions)
+	return c.changes
+}
+
+// CompareDirs compares the JSON Schema files in oldDir to those in newDir,
+// such as the schemas of a previous release to freshly generated schemas.
+//
+// Files are matched by their path relative to the directories.
+func CompareDirs(oldDir, newDir string) (*Report, error) {
+	oldSchemas, err := readSchemaDir(oldDir)
+	if err != nil {
+		return nil, err
+	}
+	newSchemas, err := readSchemaDir(newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, file := range sortedKeys(oldSchemas, newSchemas) {
+		oldSchema, inOld := oldSchemas[file]
+		newSchema, inNew := newSchemas[file]
+		switch {
+		case !inNew:
+			changes = append(changes, Change{File: file, Path: "$", Kind: ChangeSchemaRemoved})
+		case !inOld:
+			changes = append(changes, Change{File: file, Path: "$", Kind: ChangeSchemaAdded})
+		default:
+			for _, c := range Compare(oldSchema, newSchema) {
+				c.File = file
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	return NewReport(changes), nil
+}
+
+// readSchemaDir reads the JSON Schema files in dir, keyed by their slash-separated relative path.
+func readSchemaDir(dir string) (map[string]*jsonschema.Schema, error) {
+	schemas := map[string]*jsonschema.Schema{}
+	fsys := os.DirFS(dir)
+	if err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" || path == IndexFile {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("could not read schema definition %q: %w", path, err)
+		}
+		schema := &jsonschema.Schema{}
+		if err := json.Unmarshal(data, schema); err != nil {
+			return fmt.Errorf("could not parse schema definition %q: %w", path, err)
+		}
+		schemas[path] = schema
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error reading schema directory %q: %w", dir, err)
+	}
+	return schemas, nil
+}
+
+// comparer accumulates the changes between two schemas.
+type comparer struct {
+	oldRoot, newRoot *jsonschema.Schema
+	visited          map[[2]*jsonschema.Schema]bool
+	compared         map[*jsonschema.Schema]bool // old and new schemas reached from the roots
+	changes          []Change
+}
+
+func (c *comparer) add(path string, kind ChangeKind, format string, args ...any) {
+	c.changes = append(c.changes, Change{Path: path, Kind: kind, Detail: fmt.Sprintf(format, args...)})
+}
+
+// compare compares the old and new schemas for the value at path.
+func (c *comparer) compare(path string, oldSchema, newSchema *jsonschema.Schema) {
+	oldSchema = resolveRef(c.oldRoot, oldSchema)
+	newSchema = resolveRef(c.newRoot, newSchema)
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+
+	// Schemas may be reached from multiple references
+	key := [2]*jsonschema.Schema{oldSchema, newSchema}
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+	c.compared[oldSchema] = true
+	c.compared[newSchema] = true
+
+	switch {
+	case oldSchema.Type == newSchema.Type:
+	case oldSchema.Type == "":
+		c.add(path, ChangeConstraintTightened, "type restricted to %s", newSchema.Type)
+	case newSchema.Type == "":
+		c.add(path, ChangeConstraintLoosened, "type %s no longer restricted", oldSchema.Type)
+	default:
+		c.add(path, ChangeTypeChanged, "%s to %s", oldSchema.Type, newSchema.Type)
+	}
+
+	c.compareConstraints(path, oldSchema, newSchema)
+	c.compareProperties(path, oldSchema, newSchema)
+
+	switch {
+	case oldSchema.Items != nil && newSchema.Items != nil:
+		c.compare(path+"[]", oldSchema.Items, newSchema.Items)
+	case newSchema.Items != nil:
+		c.add(path, ChangeConstraintTightened, "items restricted")
+	case oldSchema.Items != nil:
+		c.add(path, ChangeConstraintLoosened, "items no longer restricted")
+	}
+
+	// Documents must match all of allOf, any of anyOf, and exactly one of oneOf,
+	// so both adding and removing oneOf subschemas may invalidate documents
+	c.compareSubschemas(path, "allOf", oldSchema.AllOf, newSchema.AllOf, ChangeConstraintTightened, ChangeConstraintLoosened)
+	c.compareSubschemas(path, "anyOf", oldSchema.AnyOf, newSchema.AnyOf, ChangeConstraintLoosened, ChangeConstraintTightened)
+	c.compareSubschemas(path, "oneOf", oldSchema.OneOf, newSchema.OneOf, ChangeConstraintTightened, ChangeConstraintTightened)
+	switch oldAdd, newAdd := oldSchema.AdditionalProperties, newSchema.AdditionalProperties; {
+	case !isFalse(oldAdd) && isFalse(newAdd):
+		c.add(path, ChangeConstraintTightened, "additional properties no longer allowed")
+	case isFalse(oldAdd) && !isFalse(newAdd):
+		c.add(path, ChangeConstraintLoosened, "additional properties allowed")
+	case oldAdd != nil && newAdd != nil:
+		c.compare(path+".*", oldAdd, newAdd)
+	}
+}
+
+// compareProperties compares the fields of object schemas.
+func (c *comparer) compareProperties(path string, oldSchema, newSchema *jsonschema.Schema) {
+	oldProps := propertyMap(oldSchema)
+	newProps := propertyMap(newSchema)
+
+	for _, name := range sortedKeys(oldProps, newProps) {
+		fieldPath := path + "." + name
+		oldProp, inOld := oldProps[name]
+		newProp, inNew := newProps[name]
+		oldRequired := slices.Contains(oldSchema.Required, name)
+		newRequired := slices.Contains(newSchema.Required, name)
+		switch {
+		case !inNew:
+			c.add(fieldPath, ChangeFieldRemoved, "")
+		case !inOld && newRequired:
+			c.add(fieldPath, ChangeRequiredFieldAdded, "")
+		case !inOld:
+			c.add(fieldPath, ChangeFieldAdded, "")
+		default:
+			if !oldRequired && newRequired {
+				c.add(fieldPath, ChangeConstraintTightened, "field is now required")
+			} else if oldRequired && !newRequired {
+				c.add(fieldPath, ChangeConstraintLoosened, "field is no longer required")
+			}
+			c.compare(fieldPath, oldProp, newProp)
+		}
+	}
+}
+
+// compareSubschemas compares the subschemas of an applicator keyword, such as allOf, by position.
+// Adding the keyword tightens the schema and removing it loosens the schema, while
+// changes to the number of subschemas are classified as added or removed.
+func (c *comparer) compareSubschemas(path, keyword string, oldSubs, newSubs []*jsonschema.Schema, added, removed ChangeKind) {
+	for i := range min(len(oldSubs), len(newSubs)) {
+		c.compare(path, oldSubs[i], newSubs[i])
+	}
+
+	switch {
+	case len(oldSubs) == len(newSubs):
+	case len(oldSubs) == 0:
+		c.add(path, ChangeConstraintTightened, "%s added", keyword)
+	case len(newSubs) == 0:
+		c.add(path, ChangeConstraintLoosened, "%s removed", keyword)
+	case len(newSubs) > len(oldSubs):
+		c.add(path, added, "%s subschemas changed from %d to %d", keyword, len(oldSubs), len(newSubs))
+	default:
+		c.add(path, removed, "%s subschemas changed from %d to %d", keyword, len(oldSubs), len(newSubs))
+	}
+}
+
+// compareConstraints compares the validation keywords of the schemas.
+func (c *comparer) compareConstraints(path string, oldSchema, newSchema *jsonschema.Schema) {
+	// Lower bounds are tightened by increasing them
+	c.compareBound(path, "minimum", numberBound(oldSchema.Minimum), numberBound(newSchema.Minimum), 1)
+	c.compareBound(path, "exclusiveMinimum", numberBound(oldSchema.ExclusiveMinimum), numberBound(newSchema.ExclusiveMinimum), 1)
+	c.compareBound(path, "minLength", uintBound(oldSchema.MinLength), uintBound(newSchema.MinLength), 1)
+	c.compareBound(path, "minItems", uintBound(oldSchema.MinItems), uintBound(newSchema.MinItems), 1)
+	c.compareBound(path, "minProperties", uintBound(oldSchema.MinProperties), uintBound(newSchema.MinProperties), 1)
+
+	// Upper bounds are tightened by decreasing them
+	c.compareBound(path, "maximum", numberBound(oldSchema.Maximum), numberBound(newSchema.Maximum), -1)
+	c.compareBound(path, "exclusiveMaximum", numberBound(oldSchema.ExclusiveMaximum), numberBound(newSchema.ExclusiveMaximum), -1)
+	c.compareBound(path, "maxLength", uintBound(oldSchema.MaxLength), uintBound(newSchema.MaxLength), -1)
+	c.compareBound(path, "maxItems", uintBound(oldSchema.MaxItems), uintBound(newSchema.MaxItems), -1)
+	c.compareBound(path, "maxProperties", uintBound(oldSchema.MaxProperties), uintBound(newSchema.MaxProperties), -1)
+
+	// Any change to these keywords may invalidate documents
+	c.compareExact(path, "pattern", oldSchema.Pattern, newSchema.Pattern)
+	c.compareExact(path, "format", oldSchema.Format, newSchema.Format)
+	c.compareExact(path, "const", oldSchema.Const, newSchema.Const)
+	if !oldSchema.UniqueItems && newSchema.UniqueItems {
+		c.add(path, ChangeConstraintTightened, "items must be unique")
+	} else if oldSchema.UniqueItems && !newSchema.UniqueItems {
+		c.add(path, ChangeConstraintLoosened, "items no longer must be unique")
+	}
+
+	// Enums are tightened by removing values
+	switch {
+	case len(oldSchema.Enum) == 0 && len(newSchema.Enum) > 0:
+		c.add(path, ChangeConstraintTightened, "enum added")
+	case len(oldSchema.Enum) > 0 && len(newSchema.Enum) == 0:
+		c.add(path, ChangeConstraintLoosened, "enum removed")
+	default:
+		for _, v := range oldSchema.Enum {
+			if !slices.ContainsFunc(newSchema.Enum, func(n any) bool { return reflect.DeepEqual(v, n) }) {
+				c.add(path, ChangeConstraintTightened, "enum value %v removed", v)
+			}
+		}
+		for _, v := range newSchema.Enum {
+			if !slices.ContainsFunc(oldSchema.Enum, func(o any) bool { return reflect.DeepEqual(v, o) }) {
+				c.add(path, ChangeConstraintLoosened, "enum value %v added", v)
+			}
+		}
+	}
+}
+
+// bound is an optional numeric bound of a schema.
+type bound struct {
+	value float64
+	set   bool
+}
+
+func numberBound(n json.Number) bound {
+	f, err := n.Float64()
+	return bound{f, err == nil}
+}
+
+func uintBound(n *uint64) bound {
+	if n == nil {
+		return bound{}
+	}
+	return bound{float64(*n), true}
+}
+
+// compareBound compares a numeric bound of the schemas.
+// The direction is positive if the bound is tightened by increasing it.
+func (c *comparer) compareBound(path, keyword string, oldBound, newBound bound, direction float64) {
+	switch {
+	case !oldBound.set && !newBound.set:
+	case !oldBound.set:
+		c.add(path, ChangeConstraintTightened, "%s %v added", keyword, newBound.value)
+	case !newBound.set:
+		c.add(path, ChangeConstraintLoosened, "%s %v removed", keyword, oldBound.value)
+	case (newBound.value-oldBound.value)*direction > 0:
+		c.add(path, ChangeConstraintTightened, "%s changed from %v to %v", keyword, oldBound.value, newBound.value)
+	case (newBound.value-oldBound.value)*direction < 0:
+		c.add(path, ChangeConstraintLoosened, "%s changed from %v to %v", keyword, oldBound.value, newBound.value)
+	}
+}
+
+// compareExact compares a keyword where any addition or change is a tightened constraint.
+func (c *comparer) compareExact(path, keyword string, oldValue, newValue any) {
+	oldSet := oldValue != nil && oldValue != ""
+	newSet := newValue != nil && newValue != ""
+	switch {
+	case !oldSet && !newSet:
+	case !oldSet:
+		c.add(path, ChangeConstraintTightened, "%s %v added", keyword, newValue)
+	case !newSet:
+		c.add(path, ChangeConstraintLoosened, "%s %v removed", keyword, oldValue)
+	case !reflect.DeepEqual(oldValue, newValue):
+		c.add(path, ChangeConstraintTightened, "%s changed from %v to %v", keyword, oldValue, newValue)
+	}
+}
+
+// compareDefinitions compares definitions by name, including nested definitions.
+//
+// Definitions that were compared through references, such as those of renamed
+// types, are not reported as added or removed.
+func (c *comparer) compareDefinitions(path string, oldDefs, newDefs jsonschema.Definitions) {
+	for _, name := range sortedKeys(oldDefs, newDefs) {
+		defPath := path + "." + name
+		oldDef, inOld := oldDefs[name]
+		newDef, inNew := newDefs[name]
+		switch {
+		case !inNew:
+			if !c.compared[oldDef] {
+				c.add(defPath, ChangeDefinitionRemoved, "")
+			}
+		case !inOld:
+			if !c.compared[newDef] {
+				c.add(defPath, ChangeDefinitionAdded, "")
+			}
+		default:
+			c.compare(defPath, oldDef, newDef)
+			c.compareDefinitions(defPath+".$defs", oldDef.Definitions, newDef.Definitions)
+		}
+	}
+}
+
+// resolveRef follows a reference to a definition of the root schema, such as
+// "#/$defs/Config" or "#/$defs/v1/$defs/Config".
+//
+// Schemas without a local reference are returned unchanged.
+func resolveRef(root, schema *jsonschema.Schema) *jsonschema.Schema {
+	if schema == nil || !strings.HasPrefix(schema.Ref, defsRefPrefix) {
+		return schema
+	}
+
+	resolved := root
+	segments := strings.Split(strings.TrimPrefix(schema.Ref, "#/"), "/")
+	for i := 0; i+1 < len(segments); i += 2 {
+		if segments[i] != "$defs" || resolved.Definitions[segments[i+1]] == nil {
+			return schema
+		}
+		resolved = resolved.Definitions[segments[i+1]]
+	}
+	return resolved
+}
+
+// propertyMap returns the properties of the schema by name.
+func propertyMap(schema *jsonschema.Schema) map[string]*jsonschema.Schema {
+	props := map[string]*jsonschema.Schema{}
+	if schema.Properties == nil {
+		return props
+	}
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		props[pair.Key] = pair.Value
+	}
+	return props
+}
+
+// isFalse reports whether the schema is the boolean schema false, which matches no values.
+func isFalse(schema *jsonschema.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	data, err := json.Marshal(schema)
+	return err == nil && string(data) == "false"
+}
+
+// sortedKeys returns the sorted union of the keys of the maps.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
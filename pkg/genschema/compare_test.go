@@ -0,0 +1,277 @@
+package genschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/genschema"
+)
+
+func parseSchema(t *testing.T, data string) *jsonschema.Schema {
+	t.Helper()
+	schema := &jsonschema.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(data), schema))
+	return schema
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{
+			name: "unchanged",
+			old:  `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+			new:  `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+		},
+		{
+			name: "optional field added",
+			old:  `{"type": "object"}`,
+			new:  `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+			want: []string{"$.name: added optional field"},
+		},
+		{
+			name: "required field added",
+			old:  `{"type": "object"}`,
+			new:  `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`,
+			want: []string{"$.name: added required field"},
+		},
+		{
+			name: "field removed",
+			old:  `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+			new:  `{"type": "object"}`,
+			want: []string{"$.name: removed field"},
+		},
+		{
+			name: "field required",
+			old:  `{"properties": {"name": {"type": "string"}}}`,
+			new:  `{"properties": {"name": {"type": "string"}}, "required": ["name"]}`,
+			want: []string{"$.name: tightened constraint (field is now required)"},
+		},
+		{
+			name: "field no longer required",
+			old:  `{"properties": {"name": {"type": "string"}}, "required": ["name"]}`,
+			new:  `{"properties": {"name": {"type": "string"}}}`,
+			want: []string{"$.name: loosened constraint (field is no longer required)"},
+		},
+		{
+			name: "type changed",
+			old:  `{"type": "string"}`,
+			new:  `{"type": "integer"}`,
+			want: []string{"$: changed type (string to integer)"},
+		},
+		{
+			name: "type restricted",
+			old:  `{}`,
+			new:  `{"type": "string"}`,
+			want: []string{"$: tightened constraint (type restricted to string)"},
+		},
+		{
+			name: "type no longer restricted",
+			old:  `{"type": "string"}`,
+			new:  `{}`,
+			want: []string{"$: loosened constraint (type string no longer restricted)"},
+		},
+		{
+			name: "lower bound increased",
+			old:  `{"minimum": 1}`,
+			new:  `{"minimum": 2}`,
+			want: []string{"$: tightened constraint (minimum changed from 1 to 2)"},
+		},
+		{
+			name: "upper bound increased",
+			old:  `{"maxLength": 1}`,
+			new:  `{"maxLength": 2}`,
+			want: []string{"$: loosened constraint (maxLength changed from 1 to 2)"},
+		},
+		{
+			name: "bound added",
+			old:  `{}`,
+			new:  `{"maxItems": 3}`,
+			want: []string{"$: tightened constraint (maxItems 3 added)"},
+		},
+		{
+			name: "bound removed",
+			old:  `{"minProperties": 1}`,
+			new:  `{}`,
+			want: []string{"$: loosened constraint (minProperties 1 removed)"},
+		},
+		{
+			name: "pattern changed",
+			old:  `{"pattern": "^a"}`,
+			new:  `{"pattern": "^b"}`,
+			want: []string{"$: tightened constraint (pattern changed from ^a to ^b)"},
+		},
+		{
+			name: "unique items",
+			old:  `{}`,
+			new:  `{"uniqueItems": true}`,
+			want: []string{"$: tightened constraint (items must be unique)"},
+		},
+		{
+			name: "enum values",
+			old:  `{"enum": ["a", "b"]}`,
+			new:  `{"enum": ["b", "c"]}`,
+			want: []string{
+				"$: tightened constraint (enum value a removed)",
+				"$: loosened constraint (enum value c added)",
+			},
+		},
+		{
+			name: "enum added",
+			old:  `{}`,
+			new:  `{"enum": ["a"]}`,
+			want: []string{"$: tightened constraint (enum added)"},
+		},
+		{
+			name: "additional properties disallowed",
+			old:  `{"type": "object"}`,
+			new:  `{"type": "object", "additionalProperties": false}`,
+			want: []string{"$: tightened constraint (additional properties no longer allowed)"},
+		},
+		{
+			name: "additional properties allowed",
+			old:  `{"type": "object", "additionalProperties": false}`,
+			new:  `{"type": "object"}`,
+			want: []string{"$: loosened constraint (additional properties allowed)"},
+		},
+		{
+			name: "additional properties changed",
+			old:  `{"additionalProperties": {"type": "string"}}`,
+			new:  `{"additionalProperties": {"type": "integer"}}`,
+			want: []string{"$.*: changed type (string to integer)"},
+		},
+		{
+			name: "items changed",
+			old:  `{"type": "array", "items": {"type": "string"}}`,
+			new:  `{"type": "array", "items": {"type": "integer"}}`,
+			want: []string{"$[]: changed type (string to integer)"},
+		},
+		{
+			name: "items restricted",
+			old:  `{"type": "array"}`,
+			new:  `{"type": "array", "items": {"type": "string"}}`,
+			want: []string{"$: tightened constraint (items restricted)"},
+		},
+		{
+			name: "items no longer restricted",
+			old:  `{"type": "array", "items": {"type": "string"}}`,
+			new:  `{"type": "array"}`,
+			want: []string{"$: loosened constraint (items no longer restricted)"},
+		},
+		{
+			name: "allOf added",
+			old:  `{}`,
+			new:  `{"allOf": [{"required": ["name"]}]}`,
+			want: []string{"$: tightened constraint (allOf added)"},
+		},
+		{
+			name: "allOf subschema added",
+			old:  `{"allOf": [{"minLength": 1}]}`,
+			new:  `{"allOf": [{"minLength": 1}, {"maxLength": 5}]}`,
+			want: []string{"$: tightened constraint (allOf subschemas changed from 1 to 2)"},
+		},
+		{
+			name: "allOf subschema removed",
+			old:  `{"allOf": [{"minLength": 1}, {"maxLength": 5}]}`,
+			new:  `{"allOf": [{"minLength": 1}]}`,
+			want: []string{"$: loosened constraint (allOf subschemas changed from 2 to 1)"},
+		},
+		{
+			name: "anyOf subschema added",
+			old:  `{"anyOf": [{"type": "string"}]}`,
+			new:  `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			want: []string{"$: loosened constraint (anyOf subschemas changed from 1 to 2)"},
+		},
+		{
+			name: "anyOf subschema removed",
+			old:  `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			new:  `{"anyOf": [{"type": "string"}]}`,
+			want: []string{"$: tightened constraint (anyOf subschemas changed from 2 to 1)"},
+		},
+		{
+			name: "anyOf removed",
+			old:  `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			new:  `{}`,
+			want: []string{"$: loosened constraint (anyOf removed)"},
+		},
+		{
+			name: "oneOf subschema added",
+			old:  `{"oneOf": [{"type": "string"}]}`,
+			new:  `{"oneOf": [{"type": "string"}, {"minLength": 1}]}`,
+			want: []string{"$: tightened constraint (oneOf subschemas changed from 1 to 2)"},
+		},
+		{
+			name: "oneOf subschema removed",
+			old:  `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`,
+			new:  `{"oneOf": [{"type": "string"}]}`,
+			want: []string{"$: tightened constraint (oneOf subschemas changed from 2 to 1)"},
+		},
+		{
+			name: "oneOf subschema changed",
+			old:  `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`,
+			new:  `{"oneOf": [{"type": "string"}, {"type": "boolean"}]}`,
+			want: []string{"$: changed type (integer to boolean)"},
+		},
+		{
+			name: "definitions",
+			old: `{"$ref": "#/$defs/Config", "$defs": {
+				"Config": {"properties": {"name": {"type": "string"}}},
+				"Old": {}
+			}}`,
+			new: `{"$ref": "#/$defs/Config", "$defs": {
+				"Config": {"properties": {"name": {"type": "integer"}}},
+				"New": {}
+			}}`,
+			want: []string{
+				"$.name: changed type (string to integer)",
+				"$defs.New: added definition",
+				"$defs.Old: removed definition",
+			},
+		},
+		{
+			name: "renamed definition",
+			old:  `{"$ref": "#/$defs/Old", "$defs": {"Old": {"type": "string"}}}`,
+			new:  `{"$ref": "#/$defs/New", "$defs": {"New": {"type": "string"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range genschema.Compare(parseSchema(t, tt.old), parseSchema(t, tt.new)) {
+				got = append(got, c.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompareDirs(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	write := func(dir, name, data string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600))
+	}
+	write(oldDir, "config.json", `{"type": "object", "properties": {"name": {"type": "string"}}}`)
+	write(newDir, "config.json", `{"type": "object", "properties": {"name": {"type": "string"}, "port": {"type": "integer"}}}`)
+	write(oldDir, "removed.json", `{}`)
+	write(newDir, "added.json", `{}`)
+
+	report, err := genschema.CompareDirs(oldDir, newDir)
+	require.NoError(t, err)
+	assert.True(t, report.IsBreaking())
+	assert.Equal(t, []genschema.Change{
+		{File: "removed.json", Path: "$", Kind: genschema.ChangeSchemaRemoved},
+	}, report.Breaking)
+	assert.Equal(t, []genschema.Change{
+		{File: "added.json", Path: "$", Kind: genschema.ChangeSchemaAdded},
+		{File: "config.json", Path: "$.port", Kind: genschema.ChangeFieldAdded},
+	}, report.NonBreaking)
+	require.ErrorContains(t, report.Err(), "removed.json: $: removed schema")
+}
@@ -88,5 +88,21 @@ And finally, embedding the JSON Schema definitions in a CLI and adding the "gens
 	}
 
 Now, running "go generate ./..." before running "go build ./cmd/example" results in a CLI with a "genschema" command that will generate accurate JSON Schema definitions for the provided schemas.
+
+# Hosting Schemas
+
+[PublishGroupSchemas] writes the schemas of API groups into a versioned directory layout with an index.json manifest, with $id values for hosting on a static site. Pass the hosted URL to the "genschema" command's --schema-url flag to reference the hosted schemas in editor settings instead of local files.
+
+# Detecting Breaking Changes
+
+[Compare] classifies the changes between two versions of a schema, such as removed fields, type changes, and tightened constraints. [CompareDirs] compares a directory of previously released schemas to newly generated ones, and the resulting [Report] can guard a release pipeline:
+
+	report, err := genschema.CompareDirs("old/schemas", "cmd/example/schemas")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := report.Err(); err != nil {
+		log.Fatal(err)
+	}
 */
 package genschema
@@ -0,0 +1,169 @@
+package genschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/act3-ai/go-common/pkg/md"
+	"github.com/act3-ai/go-common/pkg/termdoc/mdfmt"
+)
+
+// defsRefPrefix is the prefix of references to schema definitions.
+const defsRefPrefix = "#/$defs/"
+
+// SchemaDocs renders markdown reference documentation for a JSON Schema definition.
+//
+// The root schema and each object definition are documented in a section
+// with a table of their fields, listing each field's type, whether it is required,
+// its default value, and its description. Fields referencing other definitions
+// link to the definition's section.
+func SchemaDocs(schema *jsonschema.Schema, title string) string {
+	w := &schemaDocsWriter{defs: schema.Definitions}
+	if w.defs == nil {
+		w.defs = jsonschema.Definitions{}
+	}
+
+	buf := new(strings.Builder)
+	buf.WriteString(md.Header(1, title) + "\n")
+
+	root := schema
+	rootName := ""
+	if name, ok := strings.CutPrefix(schema.Ref, defsRefPrefix); ok && w.defs[name] != nil {
+		root = w.defs[name]
+		rootName = name
+	}
+	if root.Description != "" {
+		buf.WriteString("\n" + root.Description + "\n")
+	}
+	if table := w.fieldTable(root); table != "" {
+		buf.WriteString("\n" + table)
+	}
+
+	// Document definitions in a stable order
+	names := make([]string, 0, len(w.defs))
+	for name := range w.defs {
+		if name != rootName {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		def := w.defs[name]
+		buf.WriteString("\n" + md.Header(2, name) + "\n")
+		if def.Description != "" {
+			buf.WriteString("\n" + def.Description + "\n")
+		}
+		if table := w.fieldTable(def); table != "" {
+			buf.WriteString("\n" + table)
+		} else {
+			buf.WriteString("\nType: " + w.typeName(def) + "\n")
+		}
+	}
+
+	return buf.String()
+}
+
+// schemaDocsWriter renders the fields of schema definitions.
+type schemaDocsWriter struct {
+	defs jsonschema.Definitions
+}
+
+// fieldTable renders a table of the schema's properties.
+func (w *schemaDocsWriter) fieldTable(schema *jsonschema.Schema) string {
+	if schema.Properties == nil || schema.Properties.Len() == 0 {
+		return ""
+	}
+	rows := [][]string{}
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		field, prop := pair.Key, pair.Value
+		required := ""
+		if slices.Contains(schema.Required, field) {
+			required = "yes"
+		}
+		rows = append(rows, []string{
+			md.Code(field),
+			md.TableCell(w.typeName(prop)),
+			required,
+			md.TableCell(defaultValue(prop)),
+			md.TableCell(description(prop)),
+		})
+	}
+	return mdfmt.WriteTable([]string{"Field", "Type", "Required", "Default", "Description"}, rows)
+}
+
+// typeName describes the type of the schema, linking to referenced definitions.
+func (w *schemaDocsWriter) typeName(schema *jsonschema.Schema) string {
+	switch {
+	case schema == nil:
+		return ""
+	case schema.Ref != "":
+		name, ok := strings.CutPrefix(schema.Ref, defsRefPrefix)
+		if !ok {
+			return md.Code(schema.Ref)
+		}
+		if _, found := w.defs[name]; found {
+			return md.Link(name, md.HeaderLinkTarget(name))
+		}
+		return name
+	case len(schema.OneOf) > 0:
+		return w.typeNames(schema.OneOf)
+	case len(schema.AnyOf) > 0:
+		return w.typeNames(schema.AnyOf)
+	case len(schema.Enum) > 0:
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, md.Code(jsonString(v)))
+		}
+		return strings.Join(values, ", ")
+	case schema.Type == "array":
+		return "array of " + w.typeName(schema.Items)
+	case schema.Type == "object" && schema.AdditionalProperties != nil && !isFalse(schema.AdditionalProperties):
+		return "map of " + w.typeName(schema.AdditionalProperties)
+	case schema.Type != "":
+		if schema.Format != "" {
+			return schema.Type + " (" + schema.Format + ")"
+		}
+		return schema.Type
+	default:
+		return "any"
+	}
+}
+
+// typeNames describes the alternative types of the schemas.
+func (w *schemaDocsWriter) typeNames(schemas []*jsonschema.Schema) string {
+	names := make([]string, 0, len(schemas))
+	for _, s := range schemas {
+		names = append(names, w.typeName(s))
+	}
+	return strings.Join(names, " or ")
+}
+
+// defaultValue formats the schema's default value.
+func defaultValue(schema *jsonschema.Schema) string {
+	if schema.Default == nil {
+		return ""
+	}
+	return md.Code(jsonString(schema.Default))
+}
+
+// description produces the schema's description, including deprecation.
+func description(schema *jsonschema.Schema) string {
+	desc := schema.Description
+	if schema.Deprecated {
+		desc = strings.TrimSpace(md.Bold("Deprecated.") + " " + desc)
+	}
+	return desc
+}
+
+// jsonString formats the value as JSON.
+func jsonString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
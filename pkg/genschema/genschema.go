@@ -12,12 +12,24 @@ import (
 
 // GenerateTypeSchemas generates JSON Schema definitions for internal Go types
 //
+// Constraints are added from struct tags and enum types, see [TagHooks].
+//
 // - schemas is a list of types (schema) to a generate schema for.
 // - baseSchemaID is the base name for the schema definitions. Use "apiVersion" values for KRM file schemas.
 // - moduleName is used to add Go comments to the schema as descriptions, pass an empty string to disable this.
 //
 //	GenerateTypeSchemas("schemas", []any{&v1alpha1.Configuration{}, &v1alpha1.Data{}}, "example.act3-ace.io/v1alpha1", "git.act3-ace.com/ace/example")
 func GenerateTypeSchemas(schemaDir string, types []any, baseSchemaID string, moduleName string) error {
+	return GenerateTypeSchemasWithHooks(schemaDir, types, baseSchemaID, moduleName, SchemaHooks{})
+}
+
+// GenerateTypeSchemasWithHooks generates JSON Schema definitions for internal Go types,
+// calling the hooks to mutate each schema before it is written.
+//
+// The hooks are called after [TagHooks], so they can override constraints from struct tags.
+//
+// See [GenerateTypeSchemas] and [SchemaHooks].
+func GenerateTypeSchemasWithHooks(schemaDir string, types []any, baseSchemaID string, moduleName string, hooks SchemaHooks) error {
 	if err := os.MkdirAll(schemaDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create schema directory: %w", err)
 	}
@@ -49,10 +61,12 @@ func GenerateTypeSchemas(schemaDir string, types []any, baseSchemaID string, mod
 	}
 	r.SetBaseSchemaID(baseSchemaID)
 
+	hooks = ChainHooks(TagHooks(), hooks)
+
 	// Iterate over each schema that needs generated
 	for _, schema := range types {
 		// Create the JSON Schema
-		_, err := generateSchema(r, schemaDir, schema)
+		_, err := generateSchema(r, schemaDir, schema, hooks)
 		if err != nil {
 			return err
 		}
@@ -61,9 +75,10 @@ func GenerateTypeSchemas(schemaDir string, types []any, baseSchemaID string, mod
 	return nil
 }
 
-func generateSchema(r *jsonschema.Reflector, dir string, schemaType any) (string, error) {
+func generateSchema(r *jsonschema.Reflector, dir string, schemaType any, hooks SchemaHooks) (string, error) {
 	// Create the JSON Schema
 	schema := r.Reflect(schemaType)
+	ApplyHooks(r, schemaType, schema, hooks)
 
 	data, err := json.MarshalIndent(schema, "", "  ")
 	if err != nil {
@@ -0,0 +1,161 @@
+package genschema
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// SchemaHooks mutate reflected schemas before they are written, to inject defaults,
+// examples, deprecation markers, or custom keywords.
+//
+// Example:
+//
+//	hooks := SchemaHooks{
+//		Field: func(parent reflect.Type, field reflect.StructField, schema *jsonschema.Schema) {
+//			if field.Name == "Replicas" {
+//				schema.Default = 1
+//				schema.Extras = map[string]any{"x-kubernetes-int-or-string": true}
+//			}
+//		},
+//	}
+type SchemaHooks struct {
+	// Type is called with the schema of each struct type.
+	Type func(t reflect.Type, schema *jsonschema.Schema)
+	// Field is called with the schema of each field of a struct type.
+	Field func(parent reflect.Type, field reflect.StructField, schema *jsonschema.Schema)
+}
+
+// ApplyHooks calls the hooks for the schemas of the struct type v and every struct type it references.
+//
+// The schema must have been reflected from v by r.
+func ApplyHooks(r *jsonschema.Reflector, v any, schema *jsonschema.Schema, hooks SchemaHooks) {
+	if hooks.Type == nil && hooks.Field == nil {
+		return
+	}
+	w := &hookWalker{r: r, hooks: hooks, defs: schema.Definitions, seen: map[reflect.Type]bool{}}
+	w.walkType(reflect.TypeOf(v), schema)
+}
+
+// hookWalker walks struct types and their schemas in parallel.
+type hookWalker struct {
+	r     *jsonschema.Reflector
+	hooks SchemaHooks
+	defs  jsonschema.Definitions
+	seen  map[reflect.Type]bool
+}
+
+// walkType applies the hooks to the struct type t, using schema if it is not a reference to a definition.
+func (w *hookWalker) walkType(t reflect.Type, schema *jsonschema.Schema) {
+	t = elemType(t)
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	if def, ok := w.defs[w.typeName(t)]; ok {
+		schema = def
+	} else if schema == nil || schema.Properties == nil {
+		return
+	}
+	if w.seen[t] {
+		return
+	}
+	w.seen[t] = true
+
+	if w.hooks.Type != nil {
+		w.hooks.Type(t, schema)
+	}
+	w.walkFields(t, t, schema)
+}
+
+// walkFields applies the hooks to the fields of the struct type t, which are
+// properties of the schema of parent. Embedded struct fields are inlined.
+func (w *hookWalker) walkFields(parent, t reflect.Type, schema *jsonschema.Schema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, inline := w.fieldName(field)
+		if inline {
+			w.walkFields(parent, elemType(field.Type), schema)
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		prop, ok := schema.Properties.Get(name)
+		if !ok {
+			continue
+		}
+		if w.hooks.Field != nil {
+			w.hooks.Field(parent, field, prop)
+		}
+		w.walkType(field.Type, propertyItems(prop))
+	}
+}
+
+// fieldName produces the property name of the field, matching the reflector's naming rules.
+func (w *hookWalker) fieldName(field reflect.StructField) (name string, inline bool) {
+	tag := w.r.FieldNameTag
+	if tag == "" {
+		tag = "json"
+	}
+	jsonTags := strings.Split(field.Tag.Get(tag), ",")
+	if jsonTags[0] == "-" || field.Tag.Get("jsonschema") == "-" {
+		return "", false
+	}
+	if field.Anonymous && jsonTags[0] == "" && elemType(field.Type).Kind() == reflect.Struct {
+		return "", true
+	}
+	if slices.Contains(jsonTags[1:], "inline") {
+		return "", true
+	}
+	if !field.IsExported() {
+		return "", false
+	}
+	name = field.Name
+	if jsonTags[0] != "" {
+		name = jsonTags[0]
+	}
+	if w.r.KeyNamer != nil {
+		name = w.r.KeyNamer(name)
+	}
+	return name, false
+}
+
+// typeName produces the definition name of the type, matching the reflector's naming rules.
+func (w *hookWalker) typeName(t reflect.Type) string {
+	if w.r.Namer != nil {
+		if name := w.r.Namer(t); name != "" {
+			return name
+		}
+	}
+	return t.Name()
+}
+
+// propertyItems returns the schema describing the values of a property,
+// entering array items and map values.
+func propertyItems(schema *jsonschema.Schema) *jsonschema.Schema {
+	for schema != nil {
+		switch {
+		case schema.Items != nil:
+			schema = schema.Items
+		case schema.AdditionalProperties != nil && schema.Properties.Len() == 0 && !isFalse(schema.AdditionalProperties):
+			schema = schema.AdditionalProperties
+		default:
+			return schema
+		}
+	}
+	return nil
+}
+
+// elemType dereferences pointer, slice, array, and map types to their element type.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
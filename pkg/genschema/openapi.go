@@ -0,0 +1,122 @@
+package genschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/invopop/jsonschema"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification used by [GenerateOpenAPI].
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPIDocument is an OpenAPI document describing schema components.
+type OpenAPIDocument struct {
+	OpenAPI           string            `json:"openapi"`
+	Info              OpenAPIInfo       `json:"info"`
+	JSONSchemaDialect string            `json:"jsonSchemaDialect,omitempty"`
+	Components        OpenAPIComponents `json:"components"`
+}
+
+// OpenAPIInfo is the info block of an OpenAPI document.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIComponents holds the reusable components of an OpenAPI document.
+type OpenAPIComponents struct {
+	Schemas map[string]json.RawMessage `json:"schemas"`
+}
+
+// GenerateOpenAPI generates an OpenAPI 3.1 document containing schema components for internal Go types
+//
+// - file is the path of the OpenAPI document to write.
+// - types is a list of types to generate schema components for.
+// - info sets the info block of the OpenAPI document.
+// - moduleName is used to add Go comments to the schema as descriptions, pass an empty string to disable this.
+//
+//	GenerateOpenAPI("api/openapi.json", []any{&v1alpha1.Configuration{}}, OpenAPIInfo{Title: "Example", Version: "v1alpha1"}, "git.act3-ace.com/ace/example")
+func GenerateOpenAPI(file string, types []any, info OpenAPIInfo, moduleName string) error {
+	r := new(jsonschema.Reflector)
+
+	if moduleName != "" {
+		// WARNING: because of the "./" argument, this only works when running on the source files.
+		// See GenerateTypeSchemas.
+		err := r.AddGoComments(moduleName, "./")
+		if err != nil {
+			return fmt.Errorf("could not add comments to schema generator: %w", err)
+		}
+	}
+
+	doc, err := OpenAPIFor(r, types, info)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to create OpenAPI document: %w", err)
+	}
+
+	// Add newline
+	data = append(data, []byte("\n")...)
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create OpenAPI directory: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0o666); err != nil {
+		return fmt.Errorf("failed to write OpenAPI document: %w", err)
+	}
+
+	return nil
+}
+
+// OpenAPIFor creates an OpenAPI document with a schema component for each of the types
+// and the types they reference, using the reflector.
+//
+// References between schemas are rewritten to point to "#/components/schemas".
+// Constraints are added from struct tags and enum types, see [TagHooks].
+func OpenAPIFor(r *jsonschema.Reflector, types []any, info OpenAPIInfo) (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{
+		OpenAPI:           OpenAPIVersion,
+		Info:              info,
+		JSONSchemaDialect: jsonschema.Version,
+		Components: OpenAPIComponents{
+			Schemas: map[string]json.RawMessage{},
+		},
+	}
+
+	for _, t := range types {
+		schema := r.Reflect(t)
+		ApplyHooks(r, t, schema, TagHooks())
+		for name, def := range schema.Definitions {
+			data, err := json.Marshal(def)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create schema component %q: %w", name, err)
+			}
+			doc.Components.Schemas[name] = bytes.ReplaceAll(data,
+				[]byte(`"$ref":"#/$defs/`), []byte(`"$ref":"#/components/schemas/`))
+		}
+		if schema.Ref == "" {
+			// Reflectors with DoNotReference set produce schemas without definitions
+			data, err := json.Marshal(schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create schema component: %w", err)
+			}
+			rt := reflect.TypeOf(t)
+			for rt.Kind() == reflect.Pointer {
+				rt = rt.Elem()
+			}
+			doc.Components.Schemas[rt.Name()] = data
+		}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,108 @@
+package genschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// IndexFile is the name of the manifest written by [PublishGroupSchemas].
+const IndexFile = "index.json"
+
+// SchemaIndex is the manifest of schemas published by [PublishGroupSchemas].
+type SchemaIndex struct {
+	BaseURL string             `json:"baseURL"`
+	Schemas []SchemaIndexEntry `json:"schemas"`
+}
+
+// SchemaIndexEntry describes a published schema for a single API kind.
+type SchemaIndexEntry struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Path    string `json:"path"` // Path of the schema relative to the index
+	URL     string `json:"url"`  // Absolute URL of the hosted schema, also used as its $id
+}
+
+// PublishGroupSchemas writes a schema for each kind of the API groups into dir,
+// in a layout suitable for hosting on a static site:
+//
+//	<dir>/<group>/<version>/<kind>.json
+//	<dir>/index.json
+//
+// Kinds are lowercased in file names. Each schema's $id is its absolute URL
+// under baseURL, where dir is expected to be served. The index.json manifest
+// lists every published schema, see [SchemaIndex].
+//
+//	PublishGroupSchemas("public/schemas", "https://example.com/schemas", scheme, []string{"example.act3-ace.io"}, "git.act3-ace.com/ace/example")
+func PublishGroupSchemas(dir, baseURL string, scheme *runtime.Scheme, apiGroups []string, moduleName string) error {
+	if _, err := url.Parse(baseURL); err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	r := new(jsonschema.Reflector)
+	r.DoNotReference = true
+
+	if moduleName != "" {
+		// See GenerateGroupSchemas, this only works when running on the source files
+		if err := r.AddGoComments(moduleName, "./"); err != nil {
+			return fmt.Errorf("could not add comments to schema generator: %w", err)
+		}
+	}
+
+	index := SchemaIndex{BaseURL: baseURL, Schemas: []SchemaIndexEntry{}}
+	for _, group := range apiGroups {
+		for _, gv := range scheme.PrioritizedVersionsForGroup(group) {
+			for _, kind := range kindNames(scheme, gv) {
+				entry := SchemaIndexEntry{
+					Group:   gv.Group,
+					Version: gv.Version,
+					Kind:    kind,
+					Path:    path.Join(gv.Group, gv.Version, strings.ToLower(kind)+".json"),
+				}
+				entry.URL = baseURL + "/" + entry.Path
+
+				schema := forAPIKind(r, scheme, gv.WithKind(kind))
+				schema.Version = jsonschema.Version
+				schema.ID = jsonschema.ID(entry.URL)
+
+				file := filepath.Join(dir, filepath.FromSlash(entry.Path))
+				if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+					return fmt.Errorf("failed to create schema directory: %w", err)
+				}
+				if err := writeIndented(schema, file); err != nil {
+					return err
+				}
+
+				index.Schemas = append(index.Schemas, entry)
+			}
+		}
+	}
+
+	return writeIndented(index, filepath.Join(dir, IndexFile))
+}
+
+// writeIndented marshals v to indented JSON and writes it to file.
+func writeIndented(v any, file string) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(file), err)
+	}
+
+	// Add newline
+	data = append(data, []byte("\n")...)
+
+	if err := os.WriteFile(file, data, 0o666); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(file), err)
+	}
+
+	return nil
+}
@@ -100,13 +100,7 @@ func forAPIVersion(r *jsonschema.Reflector, scheme *runtime.Scheme, gv schema.Gr
 		Definitions: make(jsonschema.Definitions),
 	}
 
-	// Sort names so resulting schema is stable
-	knownTypes := scheme.KnownTypes(gv)
-	typeNames := make([]string, 0, len(knownTypes))
-	for name := range knownTypes {
-		typeNames = append(typeNames, name)
-	}
-	slices.Sort(typeNames)
+	typeNames := kindNames(scheme, gv)
 
 	// Iterate over each defined kind for this version of this group
 	for _, name := range typeNames {
@@ -116,6 +110,18 @@ func forAPIVersion(r *jsonschema.Reflector, scheme *runtime.Scheme, gv schema.Gr
 	return versionSchema, typeNames, nil
 }
 
+// kindNames returns the sorted names of the kinds recognized by a runtime.Scheme
+// as part of the GroupVersion, so resulting schemas are stable.
+func kindNames(scheme *runtime.Scheme, gv schema.GroupVersion) []string {
+	knownTypes := scheme.KnownTypes(gv)
+	typeNames := make([]string, 0, len(knownTypes))
+	for name := range knownTypes {
+		typeNames = append(typeNames, name)
+	}
+	slices.Sort(typeNames)
+	return typeNames
+}
+
 // forAPIKind creates a JSONSchema validator for an API GroupVersionKind recognized by a runtime.Scheme.
 func forAPIKind(r *jsonschema.Reflector, scheme *runtime.Scheme, gvk schema.GroupVersionKind) *jsonschema.Schema {
 	r.SetBaseSchemaID(jsonschema.ID("https://" + gvk.Group).Add(gvk.Version).String())
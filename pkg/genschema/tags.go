@@ -0,0 +1,220 @@
+package genschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// Enumer is implemented by types with a fixed set of values, such as the
+// constants declared for a string or integer type.
+//
+// Fields of Enumer types are documented with an "enum" keyword.
+type Enumer interface {
+	Enum() []any
+}
+
+var enumerType = reflect.TypeFor[Enumer]()
+
+// tagValidate is the struct tag read for validation rules.
+const tagValidate = "validate"
+
+// TagHooks returns hooks adding constraints from struct tags and enum types to field schemas.
+//
+// The following rules from the "validate" tag (go-playground/validator syntax) are supported:
+//   - oneof: enum
+//   - min, max, len: minimum/maximum for numbers, length for strings, items for slices, properties for maps
+//   - gte, lte, gt, lt: minimum/maximum and exclusive variants for numbers
+//   - dive: rules after dive apply to slice items and map values
+//
+// Fields with types implementing [Enumer] are given the values of their Enum method.
+//
+// Rules in the "jsonschema" tag, such as minimum=1 or pattern=^[a-z]+$, are handled by the reflector.
+func TagHooks() SchemaHooks {
+	return SchemaHooks{
+		Field: func(_ reflect.Type, field reflect.StructField, schema *jsonschema.Schema) {
+			t, s := derefPointer(field.Type), schema
+			rules := strings.Split(field.Tag.Get(tagValidate), ",")
+			for {
+				addEnumerValues(t, s)
+				dive := len(rules)
+				for i, rule := range rules {
+					if rule == "dive" {
+						dive = i
+						break
+					}
+				}
+				for _, rule := range rules[:dive] {
+					addValidateRule(t, s, rule)
+				}
+				if t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map {
+					return
+				}
+				// Continue with the schema of the items
+				t, s = derefPointer(t.Elem()), propertyItems(s)
+				if s == nil || s == schema {
+					return
+				}
+				if dive < len(rules) {
+					rules = rules[dive+1:]
+				} else {
+					rules = nil
+				}
+			}
+		},
+	}
+}
+
+// ChainHooks combines hooks, calling them in order.
+func ChainHooks(hooks ...SchemaHooks) SchemaHooks {
+	return SchemaHooks{
+		Type: func(t reflect.Type, schema *jsonschema.Schema) {
+			for _, h := range hooks {
+				if h.Type != nil {
+					h.Type(t, schema)
+				}
+			}
+		},
+		Field: func(parent reflect.Type, field reflect.StructField, schema *jsonschema.Schema) {
+			for _, h := range hooks {
+				if h.Field != nil {
+					h.Field(parent, field, schema)
+				}
+			}
+		},
+	}
+}
+
+// addEnumerValues sets the enum values of the schema if t implements [Enumer].
+func addEnumerValues(t reflect.Type, schema *jsonschema.Schema) {
+	if schema.Ref != "" || schema.Enum != nil {
+		return
+	}
+	var v reflect.Value
+	switch {
+	case t.Implements(enumerType):
+		v = reflect.Zero(t)
+	case reflect.PointerTo(t).Implements(enumerType):
+		v = reflect.New(t)
+	default:
+		return
+	}
+	schema.Enum = v.Interface().(Enumer).Enum()
+}
+
+// addValidateRule adds the constraint for a validate tag rule to the schema.
+// Unsupported rules are ignored.
+func addValidateRule(t reflect.Type, schema *jsonschema.Schema, rule string) {
+	name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+	switch name {
+	case "oneof":
+		var values []any
+		for _, s := range splitOneOf(param) {
+			if v, ok := parseScalar(t, s); ok {
+				values = append(values, v)
+			}
+		}
+		schema.Enum = values
+	case "min", "gte":
+		setBound(t, schema, param, name == "min", &schema.Minimum, &schema.MinLength, &schema.MinItems, &schema.MinProperties)
+	case "max", "lte":
+		setBound(t, schema, param, name == "max", &schema.Maximum, &schema.MaxLength, &schema.MaxItems, &schema.MaxProperties)
+	case "len":
+		setBound(t, schema, param, true, &schema.Minimum, &schema.MinLength, &schema.MinItems, &schema.MinProperties)
+		setBound(t, schema, param, true, &schema.Maximum, &schema.MaxLength, &schema.MaxItems, &schema.MaxProperties)
+	case "gt":
+		if isNumber(t) {
+			schema.ExclusiveMinimum = json.Number(param)
+		}
+	case "lt":
+		if isNumber(t) {
+			schema.ExclusiveMaximum = json.Number(param)
+		}
+	}
+}
+
+// setBound sets the numeric bound for numbers, or the size bound for strings, slices, and maps.
+// Size bounds are only set if sizes is true.
+func setBound(t reflect.Type, schema *jsonschema.Schema, param string, sizes bool, number *json.Number, length, items, properties **uint64) {
+	if isNumber(t) {
+		if _, err := strconv.ParseFloat(param, 64); err == nil {
+			*number = json.Number(param)
+		}
+		return
+	}
+	if !sizes {
+		return
+	}
+	n, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.String:
+		*length = &n
+	case reflect.Slice, reflect.Array:
+		*items = &n
+	case reflect.Map:
+		*properties = &n
+	}
+}
+
+// splitOneOf splits the space-separated values of a oneof rule, which may be single-quoted.
+func splitOneOf(param string) []string {
+	var values []string
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if rest, ok := strings.CutPrefix(param, "'"); ok {
+			value, after, _ := strings.Cut(rest, "'")
+			values = append(values, value)
+			param = after
+			continue
+		}
+		value, after, _ := strings.Cut(param, " ")
+		values = append(values, value)
+		param = after
+	}
+	return values
+}
+
+// parseScalar parses a rule parameter as a value of type t.
+func parseScalar(t reflect.Type, s string) (any, bool) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return v, err == nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, 64)
+		return v, err == nil
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, 64)
+		return v, err == nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(s)
+		return v, err == nil
+	default:
+		return s, true
+	}
+}
+
+// isNumber reports whether t is a numeric type.
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// derefPointer dereferences pointer types.
+func derefPointer(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -3,47 +3,102 @@ package httputil
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/redact"
 )
 
-// contextInstanceKey is how we find the unique instance ID in a context.Context.
-type contextInstanceKey struct{}
+// contextRequestIDKey is how we find the request ID in a context.Context.
+type contextRequestIDKey struct{}
 
-// InstanceFromContext returns the instance for this request to uniquely identify the request
+// maxRequestIDLength is the maximum length of request IDs accepted from clients.
+const maxRequestIDLength = 128
+
+// RequestIDFromContext returns the ID of the request set by [TracingMiddleware],
+// or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextRequestIDKey{}).(string)
+	return id
+}
+
+// InstanceFromContext returns the instance for this request to uniquely identify the request.
+// It returns [uuid.Nil] if the request ID is not a UUID.
+//
+// Deprecated: Use [RequestIDFromContext], request IDs from clients are not always UUIDs.
 func InstanceFromContext(ctx context.Context) uuid.UUID {
-	if v := ctx.Value(contextInstanceKey{}); v != nil {
-		return v.(uuid.UUID)
+	id, err := uuid.Parse(RequestIDFromContext(ctx))
+	if err != nil {
+		return uuid.Nil
 	}
-	// panic("instance missing from context")
-	return uuid.Nil
+	return id
 }
 
-// TracingMiddleware injects a tracing ID into the context
+// TracingMiddleware identifies each request with a request ID, retrieved with [RequestIDFromContext].
+//
+// The ID is propagated from the [HeaderRequestID] request header, so requests can be traced
+// across services, or generated as a UUID if the header is missing or invalid. IDs from
+// clients are only accepted if they are at most 128 characters of letters, digits, and
+// "-._~:+/=", so they are safe to log and return. The ID is set in the [HeaderRequestID]
+// response header and on the request's trace span.
+//
+// [LoggingMiddleware] adds the ID to the context logger, it must be listed before
+// TracingMiddleware in [WrapHandler].
 func TracingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		id, err := uuid.NewV7()
-		if err != nil {
-			log := logger.FromContext(r.Context())
-			log.ErrorContext(ctx, "Failed to generate UUID", "error", err)
-			w.WriteHeader(http.StatusInternalServerError)
+		id := r.Header.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			uid, err := uuid.NewV7()
+			if err != nil {
+				log := logger.FromContext(r.Context())
+				log.ErrorContext(ctx, "Failed to generate UUID", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			id = uid.String()
 		}
-		ctx = context.WithValue(ctx, contextInstanceKey{}, id)
+
+		w.Header().Set(HeaderRequestID, id)
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.StringSlice("http.request.header.x-request-id", []string{id}),
+		)
+		ctx = context.WithValue(ctx, contextRequestIDKey{}, id)
 		// Call the next handler
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// validRequestID reports whether a request ID from a client is safe to use.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("-._~:+/=", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 var _ MiddlewareFunc = TracingMiddleware
 
-// LoggingMiddleware injects a logger into the context.
+// LoggingMiddleware injects a logger into the context, with the request path, query
+// parameters, and ID from [TracingMiddleware].
+// Sensitive query parameters are redacted with [redact.Default].
 //
 // A previous implementation contained a memory leak because the tracing attributes were always appended to the given logger.
 func LoggingMiddleware(log *slog.Logger) MiddlewareFunc {
@@ -51,11 +106,10 @@ func LoggingMiddleware(log *slog.Logger) MiddlewareFunc {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 			path := r.URL.Path
-			id := InstanceFromContext(ctx)
 			ctx = logger.NewContext(ctx, log.With(
 				slog.String("path", path),
-				slog.Any("qs", r.URL.Query()),
-				slog.String("instance", id.String()),
+				slog.Any("qs", redact.Default.Values(r.URL.Query())),
+				slog.String("requestID", RequestIDFromContext(ctx)),
 			))
 			// Call the next handler
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -63,6 +117,53 @@ func LoggingMiddleware(log *slog.Logger) MiddlewareFunc {
 	}
 }
 
+// LoggingAccessMiddleware logs the status, size, and duration of each response
+// with the logger from the request context, and records them on the request's
+// trace span. It must be wrapped by [LoggingMiddleware] so the access log
+// includes the request attributes, i.e. listed before it in [WrapHandler].
+//
+// Responses with a server error status are logged at error level, all others
+// at info level.
+func LoggingAccessMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := NewResponseRecorder(w)
+
+		// Call the next handler
+		next.ServeHTTP(rec, r)
+
+		// This must be done after calling next.ServeHTTP()
+		ctx := r.Context()
+		duration := time.Since(start)
+		route := strings.TrimPrefix(r.Pattern, r.Method+" ")
+
+		level := slog.LevelInfo
+		if rec.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.FromContext(ctx).Log(ctx, level, "Request completed",
+			slog.String("method", r.Method),
+			slog.String("route", route),
+			slog.Int("status", rec.Status),
+			slog.Int64("size", rec.Size),
+			slog.Duration("duration", duration),
+		)
+
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", rec.Status),
+			attribute.Int64("http.response.body.size", rec.Size),
+		)
+		if rec.Status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.Status))
+		}
+	})
+}
+
+var _ MiddlewareFunc = LoggingAccessMiddleware
+
 // ServerHeaderMiddleware injects the Server into the response headers
 func ServerHeaderMiddleware(server string) MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
@@ -96,7 +197,7 @@ func RecovererMiddleware(next http.Handler) http.Handler {
 				default:
 					log.ErrorContext(ctx, "Handler panic-ed with unknown error", "value", rvr)
 				}
-				w.WriteHeader(http.StatusInternalServerError)
+				WriteError(w, r, NewProblem(http.StatusInternalServerError, "", ""))
 			}
 		}()
 
@@ -141,6 +242,7 @@ func AllowContentTypeMiddleware(next http.Handler, contentTypes ...string) http.
 			next.ServeHTTP(w, r)
 			return
 		}
-		w.WriteHeader(http.StatusUnsupportedMediaType)
+		WriteError(w, r, NewProblem(http.StatusUnsupportedMediaType, "",
+			fmt.Sprintf("content type %q is not allowed", s)))
 	})
 }
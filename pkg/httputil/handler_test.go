@@ -1,15 +1,20 @@
 package httputil_test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/logger"
 )
 
 func pathMW(pattern string, next http.Handler) http.Handler {
@@ -75,3 +80,65 @@ func Test_WrapRouter(t *testing.T) {
 		})
 	}
 }
+
+func Test_LoggingAccessMiddleware(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := slog.New(slog.NewTextHandler(buf, nil))
+
+	mux := http.NewServeMux()
+	router := httputil.WrapHandler(mux, httputil.LoggingAccessMiddleware, httputil.LoggingMiddleware(log))
+	router.Handle("GET /items/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, "created")
+	}))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, buf.String(), `msg="Request completed" path=/items/1`)
+	assert.Contains(t, buf.String(), `method=GET route=/items/{id} status=201 size=7`)
+}
+
+func Test_TracingMiddleware(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := slog.New(slog.NewTextHandler(buf, nil))
+
+	mux := http.NewServeMux()
+	router := httputil.WrapHandler(mux, httputil.LoggingMiddleware(log), httputil.TracingMiddleware)
+	router.Handle("GET /", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).InfoContext(r.Context(), "handled")
+		fmt.Fprint(w, httputil.RequestIDFromContext(r.Context()))
+	}))
+
+	tests := []struct {
+		name      string
+		requestID string
+		propagate bool
+	}{
+		{"generated", "", false},
+		{"propagated", "abc-123", true},
+		{"too long", strings.Repeat("a", 129), false},
+		{"unsafe characters", "abc\" injected=\"true", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.requestID != "" {
+				req.Header.Set(httputil.HeaderRequestID, tt.requestID)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			id := rec.Body.String()
+			if tt.propagate {
+				assert.Equal(t, tt.requestID, id)
+			} else {
+				_, err := uuid.Parse(id)
+				assert.NoError(t, err, "generated ID is a UUID")
+			}
+			assert.Equal(t, id, rec.Header().Get(httputil.HeaderRequestID))
+			assert.Contains(t, buf.String(), "requestID="+id)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/version"
+)
+
+// DefaultCheckTimeout is the timeout of health checks registered without one.
+const DefaultCheckTimeout = 5 * time.Second
+
+// Health check statuses.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckFunc checks the health of a dependency or subsystem, returning an
+// error if it is unhealthy.
+type CheckFunc func(ctx context.Context) error
+
+// HealthStatus is the response body of the health endpoints.
+type HealthStatus struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckStatus `json:"checks,omitempty"`
+}
+
+// CheckStatus is the result of a single health check.
+type CheckStatus struct {
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// healthCheck is a registered health check.
+type healthCheck struct {
+	name    string
+	check   CheckFunc
+	timeout time.Duration
+}
+
+// HealthHandler serves liveness and readiness endpoints backed by named checks.
+//
+// Liveness checks report whether the process is functioning and should be
+// restarted if failing. Readiness checks report whether the process can
+// accept traffic, e.g. database connections are established. All liveness
+// checks are also readiness checks.
+//
+// Example:
+//
+//	health := httputil.NewHealthHandler()
+//	health.AddReadinessCheck("database", db.PingContext, time.Second)
+//
+//	mux := http.NewServeMux()
+//	health.Register(mux)
+//	mux.Handle("GET /buildinfo", httputil.BuildInfoHandler(version.Get()))
+//
+//	// health endpoints are registered before middleware is applied to avoid logging probes
+//	// middleware listed first is innermost
+//	router := httputil.WrapHandler(mux, httputil.LoggingAccessMiddleware, httputil.LoggingMiddleware(log), httputil.TracingMiddleware)
+//	router.Handle("/", appHandler)
+type HealthHandler struct {
+	mu        sync.RWMutex
+	liveness  []healthCheck
+	readiness []healthCheck
+}
+
+// NewHealthHandler creates a HealthHandler without checks, which always reports healthy.
+func NewHealthHandler() *HealthHandler {
+	return &HealthHandler{}
+}
+
+// AddLivenessCheck registers a liveness check. A timeout of 0 uses [DefaultCheckTimeout].
+func (h *HealthHandler) AddLivenessCheck(name string, check CheckFunc, timeout time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.liveness = append(h.liveness, healthCheck{name, check, timeout})
+}
+
+// AddReadinessCheck registers a readiness check. A timeout of 0 uses [DefaultCheckTimeout].
+func (h *HealthHandler) AddReadinessCheck(name string, check CheckFunc, timeout time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.readiness = append(h.readiness, healthCheck{name, check, timeout})
+}
+
+// Register registers the liveness handler at "GET /healthz" and the readiness handler at "GET /readyz".
+func (h *HealthHandler) Register(mux Router) {
+	mux.Handle("GET /healthz", h.LivenessHandler())
+	mux.Handle("GET /readyz", h.ReadinessHandler())
+}
+
+// LivenessHandler serves the result of the liveness checks.
+func (h *HealthHandler) LivenessHandler() http.Handler {
+	return RootHandler(func(w http.ResponseWriter, r *http.Request) error {
+		h.mu.RLock()
+		checks := h.liveness
+		h.mu.RUnlock()
+		return serveHealth(w, r, checks)
+	})
+}
+
+// ReadinessHandler serves the result of the liveness and readiness checks.
+func (h *HealthHandler) ReadinessHandler() http.Handler {
+	return RootHandler(func(w http.ResponseWriter, r *http.Request) error {
+		h.mu.RLock()
+		checks := append(h.liveness[:len(h.liveness):len(h.liveness)], h.readiness...)
+		h.mu.RUnlock()
+		return serveHealth(w, r, checks)
+	})
+}
+
+// serveHealth runs checks concurrently and writes the results, with status
+// 503 Service Unavailable if any check failed.
+func serveHealth(w http.ResponseWriter, r *http.Request, checks []healthCheck) error {
+	ctx := r.Context()
+	status := HealthStatus{
+		Status: StatusOK,
+		Checks: make(map[string]CheckStatus, len(checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Go(func() {
+			result := runCheck(ctx, c)
+			mu.Lock()
+			defer mu.Unlock()
+			status.Checks[c.name] = result
+			if result.Status != StatusOK {
+				status.Status = StatusFail
+			}
+		})
+	}
+	wg.Wait()
+
+	if status.Status != StatusOK {
+		logger.FromContext(ctx).WarnContext(ctx, "Health check failed", "path", r.URL.Path, "checks", status.Checks)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	return WriteJSON(w, status)
+}
+
+// runCheck runs a check with its timeout. Panics are reported as failures.
+func runCheck(ctx context.Context, c healthCheck) (result CheckStatus) {
+	timeout := c.timeout
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		if p := recover(); p != nil {
+			result.Status = StatusFail
+			result.Error = fmt.Sprintf("panic: %v", p)
+		}
+	}()
+
+	if err := c.check(ctx); err != nil {
+		return CheckStatus{Status: StatusFail, Error: err.Error()}
+	}
+	return CheckStatus{Status: StatusOK}
+}
+
+// BuildInfoHandler serves the build information as JSON.
+func BuildInfoHandler(info version.Info) http.Handler {
+	return RootHandler(func(w http.ResponseWriter, r *http.Request) error {
+		return WriteJSON(w, info)
+	})
+}
@@ -0,0 +1,50 @@
+package httputil_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+func TestHealthHandler(t *testing.T) {
+	health := httputil.NewHealthHandler()
+	health.AddLivenessCheck("alive", func(ctx context.Context) error { return nil }, 0)
+	health.AddReadinessCheck("database", func(ctx context.Context) error { return errors.New("connection refused") }, time.Second)
+	health.AddReadinessCheck("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, time.Millisecond)
+
+	mux := http.NewServeMux()
+	health.Register(mux)
+
+	get := func(path string) (int, httputil.HealthStatus) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var status httputil.HealthStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		return rec.Code, status
+	}
+
+	code, status := get("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, httputil.StatusOK, status.Status)
+	assert.Len(t, status.Checks, 1)
+
+	code, status = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, httputil.StatusFail, status.Status)
+	assert.Equal(t, httputil.StatusOK, status.Checks["alive"].Status)
+	assert.Equal(t, "connection refused", status.Checks["database"].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), status.Checks["slow"].Error)
+}
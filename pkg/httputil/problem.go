@@ -0,0 +1,216 @@
+package httputil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/logger/logutil"
+)
+
+// Error categories mapped to status codes by [StatusCodeForError].
+// Wrap them to categorize errors, e.g. fmt.Errorf("item %q: %w", id, ErrNotFound).
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("unavailable")
+)
+
+// Problem is an error with the details to share with the client, written as
+// application/problem+json as described by https://datatracker.ietf.org/doc/html/rfc7807.
+type Problem struct {
+	// Type is a URI identifying the problem type, "about:blank" if empty.
+	Type string `json:"type,omitempty"`
+
+	// Title is a short summary of the problem type, defaults to the status text.
+	Title string `json:"title"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+
+	// Detail is an explanation of this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifies this occurrence of the problem, set from the
+	// request ID by [WriteError].
+	Instance string `json:"instance,omitempty"`
+
+	// Code is an application specific error code.
+	Code string `json:"code,omitempty"`
+
+	// Details are additional members describing the problem.
+	Details map[string]any `json:"details,omitempty"`
+
+	// Cause is the underlying error, logged but not shared with the client.
+	Cause error `json:"-"`
+}
+
+// NewProblem creates a Problem with the given status, application error code, and detail.
+func NewProblem(status int, code, detail string) *Problem {
+	return &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// WithCause sets the underlying error of the problem.
+func (p *Problem) WithCause(err error) *Problem {
+	p.Cause = err
+	return p
+}
+
+// Error implements error.
+func (p *Problem) Error() string {
+	msg := p.Title
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	if p.Cause != nil {
+		msg += ": " + p.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (p *Problem) Unwrap() error {
+	return p.Cause
+}
+
+// ErrorArgs returns extra KV args for logging the error.
+func (p *Problem) ErrorArgs() []any {
+	if p.Code == "" {
+		return nil
+	}
+	return []any{"code", p.Code}
+}
+
+// ResponseBody returns the problem as JSON.
+func (p *Problem) ResponseBody() ([]byte, error) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error while marshalling response body: %w", err)
+	}
+	return body, nil
+}
+
+// ResponseHeaders returns http status code and headers.
+func (p *Problem) ResponseHeaders() (int, map[string]string) {
+	return p.Status, map[string]string{
+		"Content-Type": MediaTypeProblem,
+	}
+}
+
+// ensure Problem implements ClientError
+var _ ClientError = &Problem{}
+
+// StatusCodeForError maps an error to a status code by its category:
+//
+//   - [ErrBadRequest]: 400 Bad Request
+//   - [ErrUnauthorized]: 401 Unauthorized
+//   - [ErrForbidden]: 403 Forbidden
+//   - [ErrNotFound]: 404 Not Found
+//   - [ErrConflict]: 409 Conflict
+//   - [ErrUnavailable]: 503 Service Unavailable
+//   - [context.DeadlineExceeded]: 504 Gateway Timeout
+//
+// Other errors are 500 Internal Server Error. Errors such as fs.ErrNotExist are
+// not mapped, since their messages describe the server; wrap them with a category
+// to share them with the client, e.g. fmt.Errorf("item %q: %w", id, ErrNotFound).
+func StatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WriteError writes err to the response and logs it with the logger from the request context.
+//
+// A [ClientError] in the chain of err is written with its own body and headers.
+// Other errors are written as a [Problem] with the status code from
+// [StatusCodeForError]. The error message is only shared with the client for
+// the 4xx status codes of this package's error categories.
+//
+// The request ID from [TracingMiddleware] is set in the [HeaderInstance] header and
+// as the instance of problems, so clients can reference it in reports.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	log := logger.FromContext(ctx)
+
+	id := RequestIDFromContext(ctx)
+	if id != "" {
+		w.Header().Set(HeaderInstance, id)
+	}
+
+	var clientError ClientError
+	if !errors.As(err, &clientError) {
+		status := StatusCodeForError(err)
+		problem := NewProblem(status, "", "").WithCause(err)
+		if status < http.StatusInternalServerError {
+			problem.Detail = err.Error()
+		}
+		clientError = problem
+	}
+
+	if problem, ok := clientError.(*Problem); ok && problem.Instance == "" && id != "" {
+		// copy to avoid modifying a shared problem
+		p := *problem
+		p.Instance = requestIDURN(id)
+		clientError = &p
+	}
+
+	// Provide the error to the client
+	status, headers := clientError.ResponseHeaders()
+	if status >= http.StatusInternalServerError {
+		log.ErrorContext(ctx, "Internal error", slog.Any(logutil.ErrKey(), err), slog.Group("args", clientError.ErrorArgs()...))
+	} else {
+		log.DebugContext(ctx, "ClientError", slog.Any(logutil.ErrKey(), err), slog.Group("args", clientError.ErrorArgs()...))
+	}
+
+	body, err := clientError.ResponseBody()
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to get the response body", logutil.Err(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		log.ErrorContext(ctx, "Failed to write error body", logutil.Err(err))
+	}
+}
+
+// requestIDURN produces a URN identifying the request, "urn:uuid:<id>" for UUIDs
+// or "urn:x-request-id:<id>" otherwise.
+func requestIDURN(id string) string {
+	if uid, err := uuid.Parse(id); err == nil {
+		return uid.URN()
+	}
+	return "urn:x-request-id:" + id
+}
@@ -0,0 +1,51 @@
+package httputil_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantDetail string
+		wantCode   string
+	}{
+		{"category", fmt.Errorf("item %q: %w", "a", httputil.ErrNotFound), http.StatusNotFound, `item "a": not found`, ""},
+		{"internal", errors.New("database password is hunter2"), http.StatusInternalServerError, "", ""},
+		{"file", fmt.Errorf("reading config: %w", &fs.PathError{Op: "open", Path: "/etc/app/config.yaml", Err: fs.ErrNotExist}), http.StatusInternalServerError, "", ""},
+		{"problem", fmt.Errorf("wrapped: %w", httputil.NewProblem(http.StatusConflict, "item_exists", "item a exists")), http.StatusConflict, "item a exists", "item_exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httputil.TracingMiddleware(httputil.RootHandler(func(w http.ResponseWriter, r *http.Request) error {
+				return tt.err
+			}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, httputil.MediaTypeProblem, rec.Header().Get("Content-Type"))
+
+			var problem httputil.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.wantStatus, problem.Status)
+			assert.Equal(t, http.StatusText(tt.wantStatus), problem.Title)
+			assert.Equal(t, tt.wantDetail, problem.Detail)
+			assert.Equal(t, tt.wantCode, problem.Code)
+			assert.Equal(t, "urn:uuid:"+rec.Header().Get(httputil.HeaderInstance), problem.Instance)
+		})
+	}
+}
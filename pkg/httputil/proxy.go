@@ -0,0 +1,137 @@
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	nethttputil "net/http/httputil"
+	"net/url"
+	"slices"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/redact"
+)
+
+// ProxyOptions configures the proxy created by [NewProxy].
+type ProxyOptions struct {
+	// Token returns the token injected in the Authorization header of proxied requests, optional.
+	// It is called for each request, so it may refresh the token, e.g. the Get method of a secret.Value.
+	Token func(ctx context.Context) (redact.Secret, error)
+
+	// AuthScheme is the scheme of the injected Authorization header, defaults to "Bearer".
+	AuthScheme string
+
+	// AllowHeaders are the request headers forwarded to the target, optional.
+	// If set, all other request headers are removed.
+	AllowHeaders []string
+
+	// DenyHeaders are request headers removed before forwarding, such as "Cookie".
+	DenyHeaders []string
+
+	// Transport performs the proxied requests, defaults to [http.DefaultTransport].
+	Transport http.RoundTripper
+}
+
+// NewProxy creates a reverse proxy forwarding requests to the target, for services
+// acting as a gateway to internal services.
+//
+// Request headers are filtered by [ProxyOptions.AllowHeaders] and [ProxyOptions.DenyHeaders],
+// and the X-Forwarded headers are set. If [ProxyOptions.Token] is set, the client's
+// Authorization header is replaced with the token.
+//
+// Each proxied request is traced with a client span, and its trace context is
+// propagated to the target. Failures are logged with the logger from the request
+// context and written with [WriteError] as 502 Bad Gateway.
+//
+//	mux.Handle("/api/", httputil.NewProxy(target, httputil.ProxyOptions{
+//		Token:       tokenSecret.Get,
+//		DenyHeaders: []string{"Cookie"},
+//	}))
+func NewProxy(target *url.URL, opts ProxyOptions) http.Handler {
+	if opts.AuthScheme == "" {
+		opts.AuthScheme = "Bearer"
+	}
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	allow := canonicalHeaders(opts.AllowHeaders)
+	deny := canonicalHeaders(opts.DenyHeaders)
+
+	return &nethttputil.ReverseProxy{
+		Rewrite: func(pr *nethttputil.ProxyRequest) {
+			for name := range pr.Out.Header {
+				if (allow != nil && !slices.Contains(allow, name)) || slices.Contains(deny, name) {
+					pr.Out.Header.Del(name)
+				}
+			}
+			pr.SetURL(target)
+			pr.SetXForwarded()
+		},
+		Transport: &proxyTransport{opts: opts},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			WriteError(w, r, NewProblem(http.StatusBadGateway, "", "").
+				WithCause(fmt.Errorf("proxying request to %s: %w", target.Redacted(), err)))
+		},
+	}
+}
+
+// canonicalHeaders returns the canonical form of the header names, or nil if there are none.
+func canonicalHeaders(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	canonical := make([]string, len(names))
+	for i, name := range names {
+		canonical[i] = http.CanonicalHeaderKey(name)
+	}
+	return canonical
+}
+
+// proxyTransport is an [http.RoundTripper] that traces proxied requests and injects the token.
+type proxyTransport struct {
+	opts ProxyOptions
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.Redacted()),
+			attribute.String("server.address", req.URL.Hostname()),
+		))
+	defer span.End()
+	log := logger.FromContext(ctx)
+
+	// RoundTrippers must not modify the request
+	req = req.Clone(ctx)
+	if t.opts.Token != nil {
+		token, err := t.opts.Token(ctx)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("getting proxy token: %w", err)
+		}
+		req.Header.Set("Authorization", t.opts.AuthScheme+" "+string(token))
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	log.DebugContext(ctx, "Proxying request", "method", req.Method, "url", req.URL.Redacted())
+	resp, err := t.opts.Transport.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err //nolint:wrapcheck
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package httputil_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/redact"
+)
+
+func Test_NewProxy(t *testing.T) {
+	var received http.Header
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("X-Path", r.URL.Path)
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer backend.Close()
+	target, err := url.Parse(backend.URL + "/base")
+	require.NoError(t, err)
+
+	t.Run("token and deny list", func(t *testing.T) {
+		proxy := httputil.NewProxy(target, httputil.ProxyOptions{
+			Token: func(ctx context.Context) (redact.Secret, error) {
+				return "s3cret", nil
+			},
+			DenyHeaders: []string{"cookie"},
+		})
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set("Authorization", "Basic client")
+		req.Header.Set("Cookie", "session=1")
+		req.Header.Set("X-Custom", "value")
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTeapot, rec.Code)
+		assert.Equal(t, "/base/items", rec.Header().Get("X-Path"))
+		assert.Equal(t, "Bearer s3cret", received.Get("Authorization"))
+		assert.Empty(t, received.Get("Cookie"))
+		assert.Equal(t, "value", received.Get("X-Custom"))
+		assert.NotEmpty(t, received.Get("X-Forwarded-For"))
+	})
+
+	t.Run("allow list", func(t *testing.T) {
+		proxy := httputil.NewProxy(target, httputil.ProxyOptions{
+			AllowHeaders: []string{"accept"},
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Basic client")
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTeapot, rec.Code)
+		assert.Equal(t, "application/json", received.Get("Accept"))
+		assert.Empty(t, received.Get("Authorization"))
+	})
+
+	t.Run("token error", func(t *testing.T) {
+		proxy := httputil.NewProxy(target, httputil.ProxyOptions{
+			Token: func(ctx context.Context) (redact.Secret, error) {
+				return "", errors.New("no token")
+			},
+		})
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/problem+json")
+	})
+}
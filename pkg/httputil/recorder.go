@@ -0,0 +1,52 @@
+package httputil
+
+import (
+	"net/http"
+)
+
+// ResponseRecorder wraps a [http.ResponseWriter] to record the status code
+// and size of the response.
+type ResponseRecorder struct {
+	http.ResponseWriter
+
+	// Status is the status code of the response, 200 OK if the handler did
+	// not call WriteHeader.
+	Status int
+
+	// Size is the number of bytes written to the response body.
+	Size int64
+
+	wroteHeader bool
+}
+
+// NewResponseRecorder wraps w in a ResponseRecorder. If w is already a
+// ResponseRecorder it is returned as is, so stacked middleware share one recorder.
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	if rec, ok := w.(*ResponseRecorder); ok {
+		return rec
+	}
+	return &ResponseRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+// WriteHeader implements [http.ResponseWriter].
+func (r *ResponseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.Status = status
+		// informational responses are followed by the final status
+		r.wroteHeader = status >= 200
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements [http.ResponseWriter].
+func (r *ResponseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.Size += int64(n)
+	return n, err //nolint:wrapcheck
+}
+
+// Unwrap returns the underlying ResponseWriter, for use with [http.ResponseController].
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,224 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+)
+
+// instrumentationName identifies the instrumentation scope of the client spans.
+const instrumentationName = "github.com/act3-ai/go-common/pkg/httputil"
+
+// HeaderIdempotencyKey marks a request as safe to retry regardless of its method.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// RetryOptions configures the client created by [NewRetryClient].
+type RetryOptions struct {
+	// MaxRetries is the number of times a request is retried, defaults to 3.
+	// Negative values disable retries.
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry, defaults to 100ms.
+	// The delay doubles with each retry, with jitter.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries, defaults to 10s.
+	// A response with a longer Retry-After is returned without retrying.
+	MaxBackoff time.Duration
+
+	// RetryableStatus are the response status codes to retry, defaults to
+	// 429 Too Many Requests, 502 Bad Gateway, 503 Service Unavailable, and 504 Gateway Timeout.
+	RetryableStatus []int
+
+	// Timeout is the timeout of the client, including retries. Zero means no timeout.
+	Timeout time.Duration
+
+	// Transport performs the requests, defaults to [http.DefaultTransport].
+	Transport http.RoundTripper
+}
+
+// NewRetryClient creates an HTTP client that retries idempotent requests with
+// exponential backoff, honoring the Retry-After header. Requests are idempotent
+// if their method is idempotent or they have an Idempotency-Key header.
+//
+// Each request is traced with a client span, and its trace context is
+// propagated to the server. Retries are logged with the logger from the
+// request context.
+func NewRetryClient(opts RetryOptions) *http.Client {
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 10 * time.Second
+	}
+	if opts.RetryableStatus == nil {
+		opts.RetryableStatus = []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		}
+	}
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: &retryTransport{opts: opts},
+	}
+}
+
+// retryTransport is an [http.RoundTripper] that retries requests.
+type retryTransport struct {
+	opts RetryOptions
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.Redacted()),
+			attribute.String("server.address", req.URL.Hostname()),
+		))
+	defer span.End()
+	log := logger.FromContext(ctx)
+
+	maxRetries := t.opts.MaxRetries
+	if !isIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := cloneRequest(ctx, req, attempt)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(attemptReq.Header))
+
+		resp, err := t.opts.Transport.RoundTrip(attemptReq)
+		delay, retry := t.shouldRetry(ctx, resp, err, attempt)
+		if !retry || attempt >= maxRetries {
+			span.SetAttributes(attribute.Int("http.request.resend_count", attempt))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err //nolint:wrapcheck
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			return resp, nil
+		}
+
+		args := []any{"method", req.Method, "url", req.URL.Redacted(), "attempt", attempt + 1, "delay", delay}
+		if err != nil {
+			args = append(args, "error", err)
+		} else {
+			args = append(args, "status", resp.StatusCode)
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+		}
+		log.InfoContext(ctx, "Retrying request", args...)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1)))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			span.SetStatus(codes.Error, ctx.Err().Error())
+			return nil, ctx.Err() //nolint:wrapcheck
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether the result of an attempt should be retried, and the delay before retrying.
+func (t *retryTransport) shouldRetry(ctx context.Context, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		// do not retry when the caller gave up
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return t.backoff(attempt), true
+	}
+	if !slices.Contains(t.opts.RetryableStatus, resp.StatusCode) {
+		return 0, false
+	}
+	delay := t.backoff(attempt)
+	if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		if after > t.opts.MaxBackoff {
+			return 0, false
+		}
+		delay = max(delay, after)
+	}
+	return delay, true
+}
+
+// backoff computes the exponential backoff with jitter for an attempt.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.opts.MaxBackoff
+	if attempt < 32 {
+		d = min(t.opts.MinBackoff<<attempt, t.opts.MaxBackoff)
+	}
+	// equal jitter, between half and the full backoff
+	half := d / 2
+	return half + rand.N(half+1) //nolint:gosec
+}
+
+// retryAfter parses the Retry-After header, which is either seconds or an HTTP date.
+func retryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(val); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(val); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// isIdempotent reports whether req can be safely retried.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(HeaderIdempotencyKey) != ""
+}
+
+// cloneRequest clones req with ctx for an attempt, with a fresh body for retries.
+func cloneRequest(ctx context.Context, req *http.Request, attempt int) (*http.Request, error) {
+	clone := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("getting request body for retry: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
@@ -0,0 +1,220 @@
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/act3-ai/go-common/pkg/httputil/csp"
+)
+
+// CORSOptions configures [CORSMiddleware].
+type CORSOptions struct {
+	// AllowedOrigins are the origins allowed to make cross-origin requests.
+	// "*" allows all origins, and a wildcard leading subdomain label allows subdomains, e.g. "https://*.example.com".
+	// Wildcards are not allowed anywhere else. Origins are matched case-insensitively.
+	AllowedOrigins []string
+
+	// AllowedMethods are the methods allowed in cross-origin requests, defaults to GET, HEAD, and POST.
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers allowed in cross-origin requests.
+	// "*" allows all headers.
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers readable by the client.
+	ExposedHeaders []string
+
+	// AllowCredentials allows requests with credentials, such as cookies.
+	// It cannot be combined with the "*" origin, which would let any site make authenticated requests.
+	AllowCredentials bool
+
+	// MaxAge is how long clients may cache the result of a preflight request.
+	MaxAge time.Duration
+}
+
+// validOriginPattern reports whether the allowed origin is "*", has no wildcard,
+// or has a wildcard only as its leading subdomain label, as in "https://*.example.com".
+func validOriginPattern(allowed string) bool {
+	if allowed == "*" || !strings.Contains(allowed, "*") {
+		return true
+	}
+	scheme, host, ok := strings.Cut(allowed, "://*.")
+	return ok && scheme != "" && host != "" && !strings.Contains(scheme+host, "*")
+}
+
+// mustValidateOrigins panics if any of the allowed origins is not a valid pattern.
+func mustValidateOrigins(allowedOrigins []string) {
+	for _, allowed := range allowedOrigins {
+		if !validOriginPattern(allowed) {
+			panic(fmt.Sprintf("invalid origin %q: wildcards are only allowed as the leading subdomain label", allowed))
+		}
+	}
+}
+
+// allowsOrigin reports whether origin is allowed.
+func (opts *CORSOptions) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	return slices.ContainsFunc(opts.AllowedOrigins, func(allowed string) bool {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		prefix, suffix, ok := strings.Cut(strings.ToLower(allowed), "*")
+		if !ok || len(origin) <= len(prefix)+len(suffix) ||
+			!strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+			return false
+		}
+		// The wildcard only matches subdomains of the host
+		subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+		return !strings.ContainsAny(subdomain, "/:@")
+	})
+}
+
+// allowsHeaders reports whether all of the comma separated headers are allowed.
+func (opts *CORSOptions) allowsHeaders(headers string) bool {
+	if slices.Contains(opts.AllowedHeaders, "*") {
+		return true
+	}
+	for h := range strings.SplitSeq(headers, ",") {
+		h = strings.TrimSpace(h)
+		if h != "" && !slices.ContainsFunc(opts.AllowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, h)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// CORSMiddleware handles Cross-Origin Resource Sharing, responding to
+// preflight requests and adding the CORS headers to responses for allowed origins.
+// Requests from other origins are served without CORS headers, so browsers
+// block the response.
+//
+// CORSMiddleware panics if an allowed origin has a wildcard other than a leading
+// subdomain label, or if AllowCredentials is set with the "*" origin.
+func CORSMiddleware(opts CORSOptions) MiddlewareFunc {
+	mustValidateOrigins(opts.AllowedOrigins)
+	allowAll := slices.Contains(opts.AllowedOrigins, "*")
+	if allowAll && opts.AllowCredentials {
+		panic("CORS credentials cannot be allowed for all origins")
+	}
+	if len(opts.AllowedMethods) == 0 {
+		opts.AllowedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+	methods := strings.Join(opts.AllowedMethods, ", ")
+	exposed := strings.Join(opts.ExposedHeaders, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			w.Header().Add("Vary", "Origin")
+
+			if origin == "" || !opts.allowsOrigin(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowAll {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			if opts.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposed != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposed)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Preflight request
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			reqHeaders := r.Header.Get("Access-Control-Request-Headers")
+			if !slices.Contains(opts.AllowedMethods, r.Header.Get("Access-Control-Request-Method")) ||
+				!opts.allowsHeaders(reqHeaders) {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			if reqHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", reqHeaders)
+			}
+			if opts.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// SecurityHeadersOptions configures [SecurityHeadersMiddleware].
+type SecurityHeadersOptions struct {
+	// HSTSMaxAge enables Strict-Transport-Security with the given max age.
+	HSTSMaxAge time.Duration
+
+	// HSTSIncludeSubdomains applies Strict-Transport-Security to subdomains.
+	HSTSIncludeSubdomains bool
+
+	// ContentSecurityPolicy sets the Content-Security-Policy header if not empty.
+	ContentSecurityPolicy csp.ContentSecurityPolicy
+
+	// FrameOptions is the X-Frame-Options header, defaults to "DENY".
+	FrameOptions string
+
+	// ReferrerPolicy is the Referrer-Policy header, defaults to "strict-origin-when-cross-origin".
+	ReferrerPolicy string
+}
+
+// SecurityHeadersMiddleware sets security headers in the handler's responses,
+// including X-Content-Type-Options: nosniff.
+func SecurityHeadersMiddleware(opts SecurityHeadersOptions) MiddlewareFunc {
+	if opts.FrameOptions == "" {
+		opts.FrameOptions = "DENY"
+	}
+	if opts.ReferrerPolicy == "" {
+		opts.ReferrerPolicy = "strict-origin-when-cross-origin"
+	}
+	var hsts string
+	if opts.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(opts.HSTSMaxAge.Seconds()))
+		if opts.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	var policy string
+	if len(opts.ContentSecurityPolicy) > 0 {
+		policy = opts.ContentSecurityPolicy.Encoded()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", opts.FrameOptions)
+			h.Set("Referrer-Policy", opts.ReferrerPolicy)
+			if hsts != "" {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+			if policy != "" {
+				h.Set(csp.HeaderKey, policy)
+			}
+
+			// Call the next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package httputil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+	"github.com/act3-ai/go-common/pkg/httputil/csp"
+)
+
+func Test_CORSMiddleware(t *testing.T) {
+	handler := httputil.CORSMiddleware(httputil.CORSOptions{
+		AllowedOrigins: []string{"https://*.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPut},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         time.Hour,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	request := func(method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		req.Header.Set("Origin", origin)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request(http.MethodGet, "https://app.example.com", nil)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = request(http.MethodGet, "HTTPS://App.Example.COM", nil)
+	assert.Equal(t, "HTTPS://App.Example.COM", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	for _, origin := range []string{"https://example.org", "https://example.com", "https://.example.com", "http://app.example.com", "https://evil.com:1@x.example.com"} {
+		rec = request(http.MethodGet, origin, nil)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"), origin)
+	}
+
+	rec = request(http.MethodGet, "https://example.org", nil)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = request(http.MethodOptions, "https://app.example.com", map[string]string{
+		"Access-Control-Request-Method":  http.MethodPut,
+		"Access-Control-Request-Headers": "content-type",
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "GET, PUT", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "content-type", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "3600", rec.Header().Get("Access-Control-Max-Age"))
+
+	rec = request(http.MethodOptions, "https://app.example.com", map[string]string{
+		"Access-Control-Request-Method": http.MethodDelete,
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func Test_CORSMiddlewareAllOrigins(t *testing.T) {
+	handler := httputil.CORSMiddleware(httputil.CORSOptions{
+		AllowedOrigins: []string{"*"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://example.org")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	assert.Panics(t, func() {
+		httputil.CORSMiddleware(httputil.CORSOptions{
+			AllowedOrigins:   []string{"*"},
+			AllowCredentials: true,
+		})
+	}, "credentials are not allowed for all origins")
+}
+
+func Test_CORSMiddlewareInvalidOrigins(t *testing.T) {
+	for _, origin := range []string{"https://*", "*://example.com", "http*", "https://app.*.example.com", "https://*.*.example.com", "https://*example.com", "*.example.com"} {
+		assert.Panics(t, func() {
+			httputil.CORSMiddleware(httputil.CORSOptions{
+				AllowedOrigins:   []string{origin},
+				AllowCredentials: true,
+			})
+		}, origin)
+	}
+	assert.NotPanics(t, func() {
+		httputil.CORSMiddleware(httputil.CORSOptions{
+			AllowedOrigins:   []string{"https://example.com", "https://*.example.com", "http://*.localhost:8080"},
+			AllowCredentials: true,
+		})
+	})
+}
+
+func Test_SecurityHeadersMiddleware(t *testing.T) {
+	handler := httputil.SecurityHeadersMiddleware(httputil.SecurityHeadersOptions{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: csp.ContentSecurityPolicy{csp.DefaultSource: {csp.KeywordSelf}},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", rec.Header().Get("Strict-Transport-Security"))
+	assert.Equal(t, "default-src 'self';", rec.Header().Get(csp.HeaderKey))
+}
@@ -0,0 +1,148 @@
+package httputil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MediaTypeEventStream is the content type of server-sent events.
+const MediaTypeEventStream = "text/event-stream"
+
+// SSEEvent is a server-sent event, see https://html.spec.whatwg.org/multipage/server-sent-events.html.
+type SSEEvent struct {
+	// ID sets the client's last event ID, sent in the Last-Event-ID header when reconnecting.
+	ID string
+
+	// Event is the event type, clients dispatch events without a type as "message".
+	Event string
+
+	// Data is the event payload, sent as one "data" field per line.
+	Data string
+
+	// Retry sets the client's reconnection delay.
+	Retry time.Duration
+}
+
+// SSEWriter writes server-sent events to a response. Each event is flushed as it is
+// written, through any middleware wrapping the response writer. It is safe for
+// concurrent use.
+type SSEWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+	mu sync.Mutex
+}
+
+// NewSSEWriter starts a server-sent event stream, writing the response headers.
+// It returns an error if the response writer does not support flushing.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	s := &SSEWriter{w: w, rc: http.NewResponseController(w)}
+
+	h := w.Header()
+	h.Set("Content-Type", MediaTypeEventStream)
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no") // disable buffering by reverse proxies
+	w.WriteHeader(http.StatusOK)
+	if err := s.rc.Flush(); err != nil {
+		return nil, fmt.Errorf("starting event stream: %w", err)
+	}
+	return s, nil
+}
+
+// Send writes the event and flushes it to the client.
+func (s *SSEWriter) Send(event SSEEvent) error {
+	if strings.ContainsAny(event.ID, "\r\n\x00") || strings.ContainsAny(event.Event, "\r\n") {
+		return errors.New("event ID and type must not contain line breaks")
+	}
+
+	b := &strings.Builder{}
+	if event.ID != "" {
+		b.WriteString("id: " + event.ID + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + event.Event + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	data := strings.ReplaceAll(event.Data, "\r\n", "\n")
+	for line := range strings.SplitSeq(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// SendJSON writes an event of the given type with the JSON encoding of v as its data.
+func (s *SSEWriter) SendJSON(event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding event data: %w", err)
+	}
+	return s.Send(SSEEvent{Event: event, Data: string(data)})
+}
+
+// Heartbeat writes a comment, which clients ignore, to keep the connection open
+// through proxies that close idle connections.
+func (s *SSEWriter) Heartbeat() error {
+	return s.write(":\n\n")
+}
+
+// Stream sends the events until the channel is closed or ctx is done, such as when
+// the client disconnects. A heartbeat is sent after each interval without events,
+// unless interval is zero.
+//
+// It returns nil when the channel is closed, or the context's error.
+//
+//	func (action *Progress) ServeHTTP(w http.ResponseWriter, r *http.Request) error {
+//		sse, err := httputil.NewSSEWriter(w)
+//		if err != nil {
+//			return err
+//		}
+//		return sse.Stream(r.Context(), action.events(), 15*time.Second)
+//	}
+func (s *SSEWriter) Stream(ctx context.Context, events <-chan SSEEvent, interval time.Duration) error {
+	var heartbeat <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := s.Send(event); err != nil {
+				return err
+			}
+		case <-heartbeat:
+			if err := s.Heartbeat(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// write writes and flushes a message.
+func (s *SSEWriter) write(msg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write([]byte(msg)); err != nil {
+		return fmt.Errorf("writing event: %w", err)
+	}
+	if err := s.rc.Flush(); err != nil {
+		return fmt.Errorf("flushing event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package httputil_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+func TestSSEWriter(t *testing.T) {
+	events := make(chan httputil.SSEEvent, 2)
+	events <- httputil.SSEEvent{ID: "1", Event: "progress", Data: "line 1\nline 2", Retry: time.Second}
+	events <- httputil.SSEEvent{Data: "done"}
+	close(events)
+
+	log := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	mux := http.NewServeMux()
+	router := httputil.WrapHandler(mux, httputil.LoggingAccessMiddleware, httputil.LoggingMiddleware(log))
+	router.Handle("GET /events", httputil.RootHandler(func(w http.ResponseWriter, r *http.Request) error {
+		sse, err := httputil.NewSSEWriter(w)
+		if err != nil {
+			return err
+		}
+		return sse.Stream(r.Context(), events, time.Minute)
+	}))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, rec.Flushed, "flushed through the middleware")
+	assert.Equal(t, httputil.MediaTypeEventStream, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "id: 1\nevent: progress\nretry: 1000\ndata: line 1\ndata: line 2\n\ndata: done\n\n", rec.Body.String())
+}
+
+func TestSSEWriterCancel(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sse, err := httputil.NewSSEWriter(rec)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	err = sse.Stream(ctx, make(chan httputil.SSEEvent), 10*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, rec.Body.String(), ":\n\n", "heartbeats are sent")
+
+	assert.Error(t, sse.Send(httputil.SSEEvent{Event: "bad\nevent"}))
+	assert.NoError(t, sse.SendJSON("json", map[string]int{"n": 1}))
+	assert.Contains(t, rec.Body.String(), fmt.Sprintf("event: json\ndata: %s\n\n", `{"n":1}`))
+}
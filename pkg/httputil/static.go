@@ -0,0 +1,261 @@
+package httputil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomarkdown/markdown"
+	mdhtml "github.com/gomarkdown/markdown/html"
+	"github.com/gomarkdown/markdown/parser"
+
+	"github.com/act3-ai/go-common/pkg/md"
+)
+
+// ServeFSOptions configures [ServeFS].
+type ServeFSOptions struct {
+	// CacheControl is the Cache-Control header of responses, defaults to "no-cache",
+	// so clients revalidate with the ETag before using a cached response.
+	CacheControl string
+
+	// Index is the file served for directories, defaults to "index.html".
+	Index string
+
+	// SPA serves the root Index file for paths that do not exist, so a
+	// single-page application can handle client-side routes.
+	SPA bool
+
+	// DirectoryListing renders an HTML listing of directories without an Index file.
+	DirectoryListing bool
+
+	// RenderMarkdown serves markdown (".md") files as HTML pages.
+	RenderMarkdown bool
+}
+
+// precompressed lists the encodings of precompressed variants, in order of preference,
+// with the file extension of the variant.
+var precompressed = []struct {
+	encoding, ext string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// ServeFS returns a handler serving the files of fsys, such as embedded documentation or SPA assets.
+//
+// Responses have an ETag derived from their content, and requests with a matching
+// If-None-Match header are answered with 304 Not Modified. Precompressed variants of
+// files, such as "app.js.br" or "app.js.gz", are served to clients accepting the encoding.
+//
+// The content of fsys must not change while serving, since ETags are computed once.
+//
+//	mux.Handle("GET /docs/", http.StripPrefix("/docs", httputil.ServeFS(docs, httputil.ServeFSOptions{
+//		DirectoryListing: true,
+//		RenderMarkdown:   true,
+//	})))
+func ServeFS(fsys fs.FS, opts ServeFSOptions) http.Handler {
+	if opts.CacheControl == "" {
+		opts.CacheControl = "no-cache"
+	}
+	if opts.Index == "" {
+		opts.Index = "index.html"
+	}
+	s := &fsServer{fsys: fsys, opts: opts}
+	return RootHandler(s.serve)
+}
+
+// fsServer serves the files of an fs.FS.
+type fsServer struct {
+	fsys  fs.FS
+	opts  ServeFSOptions
+	etags sync.Map // ETags of served content, by file
+}
+
+func (s *fsServer) serve(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		return NewProblem(http.StatusMethodNotAllowed, "", "")
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	info, err := fs.Stat(s.fsys, name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && s.opts.SPA:
+		name = s.opts.Index
+	case errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("%s: %w", r.URL.Path, ErrNotFound)
+	case err != nil:
+		return fmt.Errorf("serving %s: %w", r.URL.Path, err)
+	case info.IsDir():
+		// Relative links in directory pages require a trailing slash
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			// Redirect relative to the original request, since a prefix may have been stripped
+			requested := r.URL.Path
+			if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+				requested = u.Path
+			}
+			w.Header().Set("Location", path.Base(requested)+"/")
+			w.WriteHeader(http.StatusMovedPermanently)
+			return nil
+		}
+		index := path.Join(name, s.opts.Index)
+		if _, err := fs.Stat(s.fsys, index); err == nil {
+			name = index
+		} else if s.opts.DirectoryListing {
+			return s.serveListing(w, r, name)
+		} else {
+			return fmt.Errorf("%s: %w", r.URL.Path, ErrNotFound)
+		}
+	}
+
+	if s.opts.RenderMarkdown && path.Ext(name) == ".md" {
+		return s.serveMarkdown(w, r, name)
+	}
+	return s.serveFile(w, r, name)
+}
+
+// serveFile serves the file, or its precompressed variant if the client accepts it.
+func (s *fsServer) serveFile(w http.ResponseWriter, r *http.Request, name string) error {
+	w.Header().Add("Vary", "Accept-Encoding")
+	file, encoding := name, ""
+	for _, variant := range precompressed {
+		if !acceptsEncoding(r, variant.encoding) {
+			continue
+		}
+		if _, err := fs.Stat(s.fsys, name+variant.ext); err == nil {
+			file, encoding = name+variant.ext, variant.encoding
+			break
+		}
+	}
+
+	content, err := fs.ReadFile(s.fsys, file)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+		// The compressed content cannot be sniffed
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w.Header().Set("Content-Type", contentType)
+	}
+	s.serveContent(w, r, name, file, content)
+	return nil
+}
+
+// serveMarkdown serves the markdown file rendered as an HTML page.
+func (s *fsServer) serveMarkdown(w http.ResponseWriter, r *http.Request, name string) error {
+	content, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	s.serveContent(w, r, name, name+"#html", renderHTML(path.Base(name), string(content)))
+	return nil
+}
+
+// serveListing serves an HTML listing of the directory.
+func (s *fsServer) serveListing(w http.ResponseWriter, r *http.Request, dir string) error {
+	entries, err := fs.ReadDir(s.fsys, dir)
+	if err != nil {
+		return fmt.Errorf("listing %s: %w", r.URL.Path, err)
+	}
+
+	var links []string
+	if dir != "." {
+		links = append(links, md.Link("../", "../"))
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		// Precompressed variants are served in place of the original file
+		if slices.ContainsFunc(precompressed, func(v struct{ encoding, ext string }) bool {
+			return strings.HasSuffix(name, v.ext) && slices.ContainsFunc(entries, func(e fs.DirEntry) bool {
+				return e.Name() == strings.TrimSuffix(name, v.ext)
+			})
+		}) {
+			continue
+		}
+		if entry.IsDir() {
+			name += "/"
+		}
+		links = append(links, md.Link(escapeMarkdown(name), "<"+name+">"))
+	}
+
+	title := "Index of " + r.URL.Path
+	doc := md.Header(1, escapeMarkdown(title)) + "\n\n"
+	if len(links) > 0 {
+		doc += md.UList(links...)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	content := renderHTML(title, doc)
+	// Listings are small, so the ETag is computed for each request
+	w.Header().Set("Cache-Control", s.opts.CacheControl)
+	w.Header().Set("ETag", contentETag(content))
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	return nil
+}
+
+// serveContent serves the content with its ETag, computed once per key.
+func (s *fsServer) serveContent(w http.ResponseWriter, r *http.Request, name, key string, content []byte) {
+	etag, ok := s.etags.Load(key)
+	if !ok {
+		etag, _ = s.etags.LoadOrStore(key, contentETag(content))
+	}
+	w.Header().Set("Cache-Control", s.opts.CacheControl)
+	w.Header().Set("ETag", etag.(string))
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+}
+
+// contentETag returns a strong ETag for the content.
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// acceptsEncoding reports whether the request's Accept-Encoding header accepts the encoding.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, accepted := range r.Header.Values("Accept-Encoding") {
+		for item := range strings.SplitSeq(accepted, ",") {
+			name, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+			if strings.EqualFold(strings.TrimSpace(name), encoding) &&
+				strings.ReplaceAll(params, " ", "") != "q=0" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderHTML renders the markdown document as a standalone HTML page.
+func renderHTML(title, doc string) []byte {
+	p := parser.NewWithExtensions(parser.CommonExtensions | parser.AutoHeadingIDs)
+	renderer := mdhtml.NewRenderer(mdhtml.RendererOptions{
+		Title: title,
+		Flags: mdhtml.CommonFlags | mdhtml.CompletePage,
+	})
+	return markdown.Render(p.Parse([]byte(doc)), renderer)
+}
+
+// escapeMarkdown escapes the characters of s that have meaning in inline markdown.
+var escapeMarkdown = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`,
+).Replace
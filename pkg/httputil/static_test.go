@@ -0,0 +1,112 @@
+package httputil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+func Test_ServeFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":      {Data: []byte("<h1>Home</h1>")},
+		"app.js":          {Data: []byte("console.log('app')")},
+		"app.js.gz":       {Data: []byte("gzipped")},
+		"docs/guide.md":   {Data: []byte("# Guide\n\nSome *text*.")},
+		"docs/notes.txt":  {Data: []byte("notes")},
+		"empty/.keep":     {Data: []byte{}},
+		"nested/a/b.html": {Data: []byte("b")},
+	}
+
+	request := func(handler http.Handler, method, target string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	handler := httputil.ServeFS(fsys, httputil.ServeFSOptions{
+		DirectoryListing: true,
+		RenderMarkdown:   true,
+	})
+
+	t.Run("index", func(t *testing.T) {
+		rec := request(handler, http.MethodGet, "/", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "<h1>Home</h1>", rec.Body.String())
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		etag := request(handler, http.MethodGet, "/app.js", nil).Header().Get("ETag")
+		rec := request(handler, http.MethodGet, "/app.js", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("precompressed", func(t *testing.T) {
+		rec := request(handler, http.MethodGet, "/app.js", map[string]string{"Accept-Encoding": "br, gzip"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzipped", rec.Body.String())
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Contains(t, rec.Header().Get("Content-Type"), "javascript")
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+		rec = request(handler, http.MethodGet, "/app.js", map[string]string{"Accept-Encoding": "gzip;q=0"})
+		assert.Equal(t, "console.log('app')", rec.Body.String())
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		rec := request(handler, http.MethodGet, "/docs/guide.md", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "<em>text</em>")
+	})
+
+	t.Run("listing", func(t *testing.T) {
+		rec := request(handler, http.MethodGet, "/docs/", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `<a href="guide.md">guide.md</a>`)
+		assert.Contains(t, rec.Body.String(), `<a href="../">../</a>`)
+
+		rec = request(handler, http.MethodGet, "/", nil)
+		assert.NotContains(t, rec.Body.String(), "app.js.gz")
+	})
+
+	t.Run("directory redirect", func(t *testing.T) {
+		rec := request(handler, http.MethodGet, "/docs", nil)
+		assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+		assert.Equal(t, "docs/", rec.Header().Get("Location"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rec := request(handler, http.MethodGet, "/missing.js", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = request(httputil.ServeFS(fsys, httputil.ServeFSOptions{}), http.MethodGet, "/nested/", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("method", func(t *testing.T) {
+		rec := request(handler, http.MethodPost, "/app.js", nil)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+	})
+
+	t.Run("spa", func(t *testing.T) {
+		spa := httputil.ServeFS(fsys, httputil.ServeFSOptions{SPA: true, CacheControl: "max-age=60"})
+		rec := request(spa, http.MethodGet, "/some/route", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "<h1>Home</h1>", rec.Body.String())
+		assert.Equal(t, "max-age=60", rec.Header().Get("Cache-Control"))
+	})
+}
@@ -13,9 +13,15 @@ const (
 	// HeaderUsername is the header set by the auth system (reverse proxy) to denote the username
 	HeaderUsername = "X-Auth-Username"
 
-	// HeaderInstance is a header used for identify this unique request/response (primitive tracing)
+	// HeaderInstance is a header used for identify this unique request/response (primitive tracing),
+	// set to the request ID by [WriteError].
+	//
+	// Deprecated: Use [HeaderRequestID].
 	HeaderInstance = "X-Instance"
 
+	// HeaderRequestID is the header identifying a request across services, see [TracingMiddleware]
+	HeaderRequestID = "X-Request-ID"
+
 	// HeaderCreationDate denotes the date at which this item was first uploaded to the telemetry server (used for replication purposes)
 	HeaderCreationDate = "X-Creation-Date"
 )
@@ -36,8 +42,7 @@ type ClientError interface {
 	ResponseHeaders() (int, map[string]string)
 }
 
-// TODO we could implement https://datatracker.ietf.org/doc/html/rfc7807
-// This would add fields like type (URI), title, instance (URI) (but we need at least the UUID)
+// See [Problem] for errors with the fields of https://datatracker.ietf.org/doc/html/rfc7807.
 
 // HTTPError implements ClientError interface.
 type HTTPError struct {
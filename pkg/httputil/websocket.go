@@ -0,0 +1,90 @@
+package httputil
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+	"github.com/act3-ai/go-common/pkg/logger/logutil"
+)
+
+// WebSocketFunc handles a websocket connection. The context is the request context, with
+// the logger and values set by middleware. The connection is closed when it returns.
+type WebSocketFunc func(ctx context.Context, conn *websocket.Conn) error
+
+// WebSocketHandler upgrades requests to websocket connections handled by fn.
+//
+// Connections from browsers are only accepted from the same origin or the allowed
+// origins, matched like [CORSOptions.AllowedOrigins]. Requests without an Origin header,
+// from non-browser clients, are accepted. Requests that are not websocket upgrades are
+// rejected with a [Problem]. WebSocketHandler panics if an allowed origin is not a valid pattern.
+//
+// The handler is compatible with middleware wrapping the response writer, such as
+// [LoggingAccessMiddleware]. Errors returned by fn are logged with the context logger.
+func WebSocketHandler(allowedOrigins []string, fn WebSocketFunc) http.Handler {
+	mustValidateOrigins(allowedOrigins)
+	origins := &CORSOptions{AllowedOrigins: allowedOrigins}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !isWebSocketUpgrade(r) {
+			WriteError(w, r, NewProblem(http.StatusBadRequest, "", "expected a websocket upgrade request"))
+			return
+		}
+
+		server := websocket.Server{
+			Handshake: func(_ *websocket.Config, r *http.Request) error {
+				origin := r.Header.Get("Origin")
+				if origin == "" || sameOrigin(origin, r.Host) || origins.allowsOrigin(origin) {
+					return nil
+				}
+				logger.FromContext(ctx).InfoContext(ctx, "Rejected websocket connection", "origin", origin)
+				return fmt.Errorf("origin %q is not allowed", origin)
+			},
+			Handler: func(conn *websocket.Conn) {
+				defer conn.Close()
+				if err := fn(ctx, conn); err != nil && !errors.Is(err, io.EOF) {
+					logger.FromContext(ctx).ErrorContext(ctx, "WebSocket connection failed", logutil.Err(err))
+				}
+			},
+		}
+		server.ServeHTTP(hijackWriter{w}, r)
+	})
+}
+
+// isWebSocketUpgrade reports whether the request is a websocket upgrade request.
+func isWebSocketUpgrade(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// sameOrigin reports whether the origin's host matches the request host.
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, host)
+}
+
+// hijackWriter exposes the [http.Hijacker] of a response writer wrapped by middleware,
+// which the websocket server requires.
+type hijackWriter struct {
+	http.ResponseWriter
+}
+
+// Hijack implements [http.Hijacker].
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if rec, ok := w.ResponseWriter.(*ResponseRecorder); ok && err == nil {
+		// The upgrade response is written to the connection directly
+		rec.Status = http.StatusSwitchingProtocols
+	}
+	return conn, buf, err //nolint:wrapcheck
+}
@@ -0,0 +1,59 @@
+package httputil_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+func TestWebSocketHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := slog.New(slog.NewTextHandler(buf, nil))
+
+	mux := http.NewServeMux()
+	router := httputil.WrapHandler(mux, httputil.LoggingAccessMiddleware, httputil.LoggingMiddleware(log))
+	router.Handle("GET /echo", httputil.WebSocketHandler([]string{"https://allowed.example.com"},
+		func(ctx context.Context, conn *websocket.Conn) error {
+			var msg string
+			if err := websocket.Message.Receive(conn, &msg); err != nil {
+				return err
+			}
+			return websocket.Message.Send(conn, strings.ToUpper(msg))
+		}))
+	server := httptest.NewServer(router)
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/echo"
+
+	t.Run("echo", func(t *testing.T) {
+		conn, err := websocket.Dial(url, "", "https://allowed.example.com")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, websocket.Message.Send(conn, "hello"))
+		var reply string
+		require.NoError(t, websocket.Message.Receive(conn, &reply))
+		assert.Equal(t, "HELLO", reply)
+	})
+
+	t.Run("origin not allowed", func(t *testing.T) {
+		_, err := websocket.Dial(url, "", "https://other.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("not an upgrade", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/echo")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,119 @@
+package ioutil
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"sync/atomic"
+)
+
+// ProgressFunc is called with the number of bytes transferred so far and the
+// total number of bytes expected, or -1 if the total is unknown.
+type ProgressFunc func(done, total int64)
+
+// ProgressReader is an io.Reader that reports progress as data is read.
+type ProgressReader struct {
+	r        io.Reader
+	total    int64
+	done     atomic.Int64
+	progress ProgressFunc
+}
+
+// NewProgressReader wraps r to call progress after each read.
+// If total is negative, it is detected with [DetectSize].
+//
+// Example:
+//
+//	resp, err := http.Get(url)
+//	...
+//	r := ioutil.NewProgressReader(resp.Body, resp.ContentLength, func(done, total int64) {
+//		fmt.Printf("\r%d/%d bytes", done, total)
+//	})
+func NewProgressReader(r io.Reader, total int64, progress ProgressFunc) *ProgressReader {
+	if total < 0 {
+		total = DetectSize(r)
+	}
+	return &ProgressReader{r: r, total: total, progress: progress}
+}
+
+// Read implements the io.Reader interface.
+func (pr *ProgressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if n > 0 {
+		pr.progress(pr.done.Add(int64(n)), pr.total)
+	}
+	return n, err //nolint:wrapcheck
+}
+
+// Done returns the number of bytes read.
+func (pr *ProgressReader) Done() int64 {
+	return pr.done.Load()
+}
+
+// Total returns the number of bytes expected, or -1 if unknown.
+func (pr *ProgressReader) Total() int64 {
+	return pr.total
+}
+
+// ProgressWriter is an io.Writer that reports progress as data is written.
+type ProgressWriter struct {
+	w        io.Writer
+	total    int64
+	done     atomic.Int64
+	progress ProgressFunc
+}
+
+// NewProgressWriter wraps w to call progress after each write.
+// A negative total means the total is unknown.
+func NewProgressWriter(w io.Writer, total int64, progress ProgressFunc) *ProgressWriter {
+	if total < 0 {
+		total = -1
+	}
+	return &ProgressWriter{w: w, total: total, progress: progress}
+}
+
+// Write implements the io.Writer interface.
+func (pw *ProgressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	if n > 0 {
+		pw.progress(pw.done.Add(int64(n)), pw.total)
+	}
+	return n, err //nolint:wrapcheck
+}
+
+// Done returns the number of bytes written.
+func (pw *ProgressWriter) Done() int64 {
+	return pw.done.Load()
+}
+
+// Total returns the number of bytes expected, or -1 if unknown.
+func (pw *ProgressWriter) Total() int64 {
+	return pw.total
+}
+
+// DetectSize returns the number of bytes remaining in r, or -1 if unknown.
+// The size is known for readers with a Len method (e.g. [bytes.Reader]
+// and [strings.Reader]), files and other readers with a Stat method, and
+// [http.Response] and [http.Request] bodies via their ContentLength.
+func DetectSize(r any) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		if s, ok := v.(io.Seeker); ok {
+			if offset, err := s.Seek(0, io.SeekCurrent); err == nil {
+				return info.Size() - offset
+			}
+		}
+		return info.Size()
+	case *http.Response:
+		return v.ContentLength
+	case *http.Request:
+		return v.ContentLength
+	}
+	return -1
+}
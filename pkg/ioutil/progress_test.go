@@ -0,0 +1,56 @@
+package ioutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReader(t *testing.T) {
+	var updates [][2]int64
+	r := NewProgressReader(strings.NewReader("hello world"), -1, func(done, total int64) {
+		updates = append(updates, [2]int64{done, total})
+	})
+	assert.Equal(t, int64(11), r.Total())
+
+	buf := make([]byte, 6)
+	_, err := r.Read(buf)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int64{{6, 11}, {11, 11}}, updates)
+	assert.Equal(t, int64(11), r.Done())
+}
+
+func TestProgressWriter(t *testing.T) {
+	var last int64
+	buf := &bytes.Buffer{}
+	w := NewProgressWriter(buf, -5, func(done, total int64) {
+		last = done
+		assert.Equal(t, int64(-1), total)
+	})
+	_, err := io.Copy(w, strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), last)
+	assert.Equal(t, "hello", buf.String())
+}
+
+func TestDetectSize(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(name, []byte("0123456789"), 0o644))
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, int64(10), DetectSize(f))
+	_, err = f.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), DetectSize(f))
+	assert.Equal(t, int64(-1), DetectSize(io.MultiReader()))
+}
@@ -0,0 +1,99 @@
+package ioutil
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateEstimator estimates the transfer rate with an exponentially weighted
+// moving average, smoothing out bursts in throughput.
+//
+// Example:
+//
+//	rate := ioutil.NewRateEstimator(5 * time.Second)
+//	r := ioutil.NewProgressReader(resp.Body, resp.ContentLength, func(done, total int64) {
+//		rate.Update(done, time.Now())
+//		if eta, ok := rate.ETA(done, total); ok {
+//			fmt.Printf("\r%s %s ETA %s", ioutil.FormatBytes(done), ioutil.FormatRate(rate.Rate()), eta)
+//		}
+//	})
+type RateEstimator struct {
+	mu        sync.Mutex
+	halfLife  time.Duration
+	rate      float64
+	lastDone  int64
+	lastTime  time.Time
+	hasSample bool
+}
+
+// NewRateEstimator creates a RateEstimator. Samples older than halfLife
+// contribute half as much to the rate as new samples, so a longer half-life
+// gives a smoother but slower to react rate.
+func NewRateEstimator(halfLife time.Duration) *RateEstimator {
+	return &RateEstimator{halfLife: halfLife}
+}
+
+// Update adds a sample of the total number of units transferred at time now.
+func (e *RateEstimator) Update(done int64, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lastTime.IsZero() {
+		e.lastDone, e.lastTime = done, now
+		return
+	}
+	elapsed := now.Sub(e.lastTime)
+	if elapsed <= 0 {
+		return
+	}
+	instant := float64(done-e.lastDone) / elapsed.Seconds()
+	if !e.hasSample {
+		e.rate = instant
+		e.hasSample = true
+	} else {
+		// weight of the previous rate decays by half every halfLife
+		alpha := math.Exp2(-elapsed.Seconds() / e.halfLife.Seconds())
+		e.rate = alpha*e.rate + (1-alpha)*instant
+	}
+	e.lastDone, e.lastTime = done, now
+}
+
+// Rate returns the estimated rate in units per second.
+func (e *RateEstimator) Rate() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.rate
+}
+
+// ETA returns the estimated time remaining to transfer total units, given
+// done units transferred. It returns false if the total or rate is unknown.
+func (e *RateEstimator) ETA(done, total int64) (time.Duration, bool) {
+	rate := e.Rate()
+	if total < 0 || rate <= 0 {
+		return 0, false
+	}
+	remaining := max(total-done, 0)
+	return time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second), true
+}
+
+// FormatBytes formats n bytes with binary (IEC) units, e.g. "1.5 MiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	exp := 0
+	for math.Abs(value) >= unit && exp < 6 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTPE"[exp-1])
+}
+
+// FormatRate formats a rate in bytes per second, e.g. "1.5 MiB/s".
+func FormatRate(bytesPerSecond float64) string {
+	return FormatBytes(int64(bytesPerSecond)) + "/s"
+}
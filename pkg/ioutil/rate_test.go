@@ -0,0 +1,38 @@
+package ioutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateEstimator(t *testing.T) {
+	e := NewRateEstimator(time.Second)
+	start := time.Now()
+
+	_, ok := e.ETA(0, 1000)
+	assert.False(t, ok)
+
+	e.Update(0, start)
+	e.Update(100, start.Add(time.Second))
+	assert.InDelta(t, 100, e.Rate(), 0.001)
+
+	// a burst moves the rate halfway after one half-life
+	e.Update(400, start.Add(2*time.Second))
+	assert.InDelta(t, 200, e.Rate(), 0.001)
+
+	eta, ok := e.ETA(400, 1000)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, eta)
+
+	_, ok = e.ETA(400, -1)
+	assert.False(t, ok)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "2.0 GiB", FormatBytes(2<<30))
+	assert.Equal(t, "1.0 MiB/s", FormatRate(1<<20))
+}
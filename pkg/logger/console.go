@@ -0,0 +1,240 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+)
+
+// ConsoleHandlerOptions are options for a console handler.
+type ConsoleHandlerOptions struct {
+	// Level reports the minimum level to log, defaults to [slog.LevelInfo].
+	Level slog.Leveler
+
+	// AddSource adds the source file and line of the log call to the output.
+	AddSource bool
+
+	// NoColor disables colored output. Colors are already disabled when the
+	// writer is not a terminal or the NO_COLOR environment variable is set.
+	NoColor bool
+
+	// TimeFormat is the layout of the timestamp, defaults to "15:04:05.000".
+	// The timestamp is omitted if TimeFormat is "-".
+	TimeFormat string
+}
+
+// consoleHandler is a [slog.Handler] producing human-friendly output, e.g.:
+//
+//	12:03:44.120 INF loaded config file=config.yaml keys=12
+type consoleHandler struct {
+	opts   ConsoleHandlerOptions
+	out    *termenv.Output
+	mu     *sync.Mutex
+	w      io.Writer
+	attrs  []slog.Attr // attributes added with WithAttrs, keys already qualified by group
+	groups []string
+}
+
+// NewConsoleHandler creates a [slog.Handler] that writes compact, colored
+// records to w for reading in a terminal. Errors containing multiple lines,
+// such as those produced by [errors.Join], are rendered as an indented block
+// following the record.
+func NewConsoleHandler(w io.Writer, opts *ConsoleHandlerOptions) slog.Handler {
+	h := &consoleHandler{
+		mu: &sync.Mutex{},
+		w:  w,
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	if h.opts.TimeFormat == "" {
+		h.opts.TimeFormat = "15:04:05.000"
+	}
+	if h.opts.NoColor {
+		h.out = termenv.NewOutput(w, termenv.WithProfile(termenv.Ascii))
+	} else {
+		h.out = termenv.NewOutput(w)
+	}
+	return h
+}
+
+// NewAutoHandler creates a console handler if w is a terminal and a JSON
+// handler otherwise, so logs are readable interactively and machine-parsable
+// when piped.
+func NewAutoHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	if !IsTerminal(w) {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return NewConsoleHandler(w, &ConsoleHandlerOptions{
+		Level:     opts.Level,
+		AddSource: opts.AddSource,
+	})
+}
+
+// IsTerminal reports whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// LevelForVerbosity converts a verbosity to a [slog.Level]. A verbosity of 0
+// logs errors only, and each increment of 4 enables the next standard level
+// (4=warn, 8=info, 12=debug). This matches the levels of the --verbosity flag
+// and [V], where a positive bias requires a higher verbosity.
+func LevelForVerbosity(verbosity int) slog.Level {
+	return slog.LevelError - slog.Level(verbosity)
+}
+
+// Enabled implements [slog.Handler].
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// WithAttrs implements [slog.Handler].
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = appendFlattened(h2.attrs, h.groups, a)
+	}
+	return &h2
+}
+
+// WithGroup implements [slog.Handler].
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+	return &h2
+}
+
+// Handle implements [slog.Handler].
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	buf := &bytes.Buffer{}
+
+	if h.opts.TimeFormat != "-" && !r.Time.IsZero() {
+		buf.WriteString(h.out.String(r.Time.Format(h.opts.TimeFormat)).Faint().String())
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(h.level(r.Level))
+	buf.WriteByte(' ')
+	buf.WriteString(r.Message)
+
+	attrs := slices.Clone(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendFlattened(attrs, h.groups, a)
+		return true
+	})
+
+	var blocks []slog.Attr
+	for _, a := range attrs {
+		s := a.Value.String()
+		if err, ok := a.Value.Any().(error); ok && strings.Contains(err.Error(), "\n") {
+			blocks = append(blocks, a)
+			continue
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(h.out.String(a.Key + "=").Faint().String())
+		buf.WriteString(quote(s))
+	}
+
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		source := filepath.Join(filepath.Base(filepath.Dir(frame.File)), filepath.Base(frame.File))
+		buf.WriteByte(' ')
+		buf.WriteString(h.out.String(fmt.Sprintf("source=%s:%d", source, frame.Line)).Faint().String())
+	}
+	buf.WriteByte('\n')
+
+	for _, a := range blocks {
+		fmt.Fprintf(buf, "    %s:\n", h.out.String(a.Key).Faint())
+		for line := range strings.SplitSeq(a.Value.String(), "\n") {
+			fmt.Fprintf(buf, "      %s\n", line)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err //nolint:wrapcheck
+}
+
+// level produces the colored, abbreviated level. Levels between the standard
+// levels are shown relative to the standard level below them, e.g. "DBG-4".
+func (h *consoleHandler) level(level slog.Level) string {
+	var name string
+	var color termenv.Color
+	var delta slog.Level
+	switch {
+	case level >= slog.LevelError:
+		// the offset is dropped for errors, levelAlwaysLog is not useful to display
+		name, color = "ERR", termenv.ANSIRed
+	case level >= slog.LevelWarn:
+		name, color, delta = "WRN", termenv.ANSIYellow, level-slog.LevelWarn
+	case level >= slog.LevelInfo:
+		name, color, delta = "INF", termenv.ANSIGreen, level-slog.LevelInfo
+	default:
+		name, color, delta = "DBG", termenv.ANSIBlue, level-slog.LevelDebug
+	}
+	if delta != 0 {
+		name = fmt.Sprintf("%s%+d", name, delta)
+	}
+	return h.out.String(name).Foreground(color).Bold().String()
+}
+
+// appendFlattened appends the attribute, with its key qualified by groups.
+// Group values are flattened into their members.
+func appendFlattened(attrs []slog.Attr, groups []string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(slices.Clip(groups), a.Key)
+		}
+		for _, member := range a.Value.Group() {
+			attrs = appendFlattened(attrs, groups, member)
+		}
+		return attrs
+	}
+	if len(groups) > 0 {
+		a.Key = strings.Join(groups, ".") + "." + a.Key
+	}
+	if a.Value.Kind() == slog.KindTime {
+		a.Value = slog.StringValue(a.Value.Time().Format(time.RFC3339))
+	}
+	return append(attrs, a)
+}
+
+// quote quotes s if it is empty or would otherwise be ambiguous in key=value output.
+func quote(s string) string {
+	if s == "" || strings.ContainsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r)
+	}) {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleHandler(t *testing.T) {
+	newLogger := func(level slog.Level) (*slog.Logger, *bytes.Buffer) {
+		buf := &bytes.Buffer{}
+		return slog.New(NewConsoleHandler(buf, &ConsoleHandlerOptions{
+			Level:      level,
+			NoColor:    true,
+			TimeFormat: "-",
+		})), buf
+	}
+
+	t.Run("attrs", func(t *testing.T) {
+		log, buf := newLogger(slog.LevelInfo)
+		log.With("a", 1).WithGroup("g").Info("hello world", "b", "two words", "c", "", slog.Group("d", "e", true))
+		assert.Equal(t, "INF hello world a=1 g.b=\"two words\" g.c=\"\" g.d.e=true\n", buf.String())
+	})
+
+	t.Run("levels", func(t *testing.T) {
+		log, buf := newLogger(LevelForVerbosity(16))
+		log.Debug("a")
+		log.Log(t.Context(), slog.LevelDebug-4, "b")
+		log.Warn("c")
+		V(log, 2).Warn("d")
+		assert.Equal(t, "DBG a\nDBG-4 b\nWRN c\nINF+2 d\n", buf.String())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		log, buf := newLogger(LevelForVerbosity(4))
+		log.Info("hidden")
+		log.Warn("shown")
+		assert.Equal(t, "WRN shown\n", buf.String())
+	})
+
+	t.Run("multi-line error", func(t *testing.T) {
+		log, buf := newLogger(slog.LevelInfo)
+		log.Error("failed", "error", errors.Join(errors.New("first"), errors.New("second")), "n", 1)
+		assert.Equal(t, "ERR failed n=1\n    error:\n      first\n      second\n", buf.String())
+	})
+}
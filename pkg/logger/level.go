@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// verbosityAliases are the named verbosities accepted by [ParseVerbosity].
+var verbosityAliases = map[string]int{
+	"error": 0,
+	"warn":  4,
+	"info":  8,
+	"debug": 12,
+}
+
+// ParseVerbosity parses a verbosity, either an integer or one of the aliases
+// error=0, warn=4, info=8, debug=12. Use [LevelForVerbosity] to convert it to
+// a [slog.Level].
+func ParseVerbosity(val string) (int, error) {
+	if v, ok := verbosityAliases[strings.ToLower(val)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid verbosity %q: must be an integer or one of error, warn, info, debug", val)
+	}
+	return v, nil
+}
+
+// LevelVarFromEnv creates a [slog.LevelVar] set from the verbosity in the
+// environment variable name, see [ParseVerbosity]. The level is def if the
+// variable is unset or invalid.
+//
+// The returned LevelVar can be changed at runtime with [ServeLevelHandler]
+// and [HandleLevelSignals].
+func LevelVarFromEnv(name string, def slog.Level) *slog.LevelVar {
+	level := new(slog.LevelVar)
+	level.Set(def)
+	if val, ok := os.LookupEnv(name); ok {
+		if v, err := ParseVerbosity(val); err == nil {
+			level.Set(LevelForVerbosity(v))
+		}
+	}
+	return level
+}
+
+// levelBody is the response body of [ServeLevelHandler].
+type levelBody struct {
+	Level slog.Level `json:"level"`
+}
+
+// ServeLevelHandler creates a handler to get and set the level at runtime.
+//
+// GET responds with the current level, e.g. {"level":"INFO"}.
+// PUT sets the level from a body of the same form, which must include the level. Levels are parsed by
+// [slog.Level.UnmarshalText], e.g. "debug" or "INFO+2".
+//
+// Example:
+//
+//	mux.Handle("/debug/loglevel", logger.ServeLevelHandler(level))
+func ServeLevelHandler(level *slog.LevelVar) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var body struct {
+				Level *slog.Level `json:"level"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, fmt.Sprintf("invalid level: %v", err), http.StatusBadRequest)
+				return
+			}
+			if body.Level == nil {
+				http.Error(w, "invalid level: missing level field", http.StatusBadRequest)
+				return
+			}
+			old := level.Level()
+			level.Set(*body.Level)
+			FromContext(r.Context()).InfoContext(r.Context(), "Log level changed", "from", old, "to", *body.Level)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(levelBody{Level: level.Level()}); err != nil {
+			FromContext(r.Context()).ErrorContext(r.Context(), "Failed to write level", "error", err)
+		}
+	})
+}
+
+// stepLevel adjusts level by steps of 4 (one standard level), more verbose for
+// negative steps, and returns the new level. The level is not raised above
+// [slog.LevelError], so errors are always logged.
+func stepLevel(level *slog.LevelVar, steps int) slog.Level {
+	l := min(level.Level()+slog.Level(4*steps), slog.LevelError)
+	level.Set(l)
+	return l
+}
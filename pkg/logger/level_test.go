@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelVarFromEnv(t *testing.T) {
+	t.Setenv("TEST_VERBOSITY", "info")
+	assert.Equal(t, slog.LevelInfo, LevelVarFromEnv("TEST_VERBOSITY", slog.LevelWarn).Level())
+
+	t.Setenv("TEST_VERBOSITY", "14")
+	assert.Equal(t, slog.LevelDebug-2, LevelVarFromEnv("TEST_VERBOSITY", slog.LevelWarn).Level())
+
+	t.Setenv("TEST_VERBOSITY", "loud")
+	assert.Equal(t, slog.LevelWarn, LevelVarFromEnv("TEST_VERBOSITY", slog.LevelWarn).Level())
+}
+
+func TestServeLevelHandler(t *testing.T) {
+	level := new(slog.LevelVar)
+	handler := ServeLevelHandler(level)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"INFO"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"debug"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"DEBUG"}`, rec.Body.String())
+	assert.Equal(t, slog.LevelDebug, level.Level())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"loud"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, slog.LevelDebug, level.Level())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"lvl":"error"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, slog.LevelDebug, level.Level(), "the level is required")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestStepLevel(t *testing.T) {
+	level := new(slog.LevelVar)
+	assert.Equal(t, slog.LevelDebug, stepLevel(level, -1))
+	assert.Equal(t, slog.LevelInfo, stepLevel(level, 1))
+	assert.Equal(t, slog.LevelError, stepLevel(level, 5))
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/act3-ai/go-common/pkg/redact"
+)
+
+// DefaultRedactKeys are the key patterns redacted by [NewRedactHandler] when none are given.
+var DefaultRedactKeys = redact.DefaultKeys
+
+// RedactedValue replaces the value of redacted attributes.
+const RedactedValue = redact.Redacted
+
+// redactHandler masks sensitive attribute values.
+type redactHandler struct {
+	slog.Handler
+	redactor *redact.Redactor
+}
+
+// NewRedactHandler wraps handler to replace the values of attributes whose key
+// contains one of keys (case-insensitive) with [RedactedValue]. Attributes in
+// groups are redacted by their own key, and a group with a matching key is
+// redacted entirely. Credentials matching [redact.DefaultDetectors] are
+// replaced in messages and string values. If no keys are given,
+// [DefaultRedactKeys] are used.
+func NewRedactHandler(handler slog.Handler, keys ...string) slog.Handler {
+	if len(keys) == 0 {
+		keys = DefaultRedactKeys
+	}
+	return NewRedactorHandler(handler, &redact.Redactor{Keys: keys, Detectors: redact.DefaultDetectors})
+}
+
+// NewRedactorHandler wraps handler to redact messages and attributes with redactor.
+func NewRedactorHandler(handler slog.Handler, redactor *redact.Redactor) slog.Handler {
+	return &redactHandler{Handler: handler, redactor: redactor}
+}
+
+// Handle implements [slog.Handler].
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, h.redactor.String(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactor.Attr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted) //nolint:wrapcheck
+}
+
+// WithAttrs implements [slog.Handler].
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactor.Attr(a)
+	}
+	return &redactHandler{Handler: h.Handler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+// WithGroup implements [slog.Handler].
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{Handler: h.Handler.WithGroup(name), redactor: h.redactor}
+}
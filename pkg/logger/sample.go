@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// maxSampleKeys bounds the number of messages tracked by a sample handler
+// before expired windows are pruned.
+const maxSampleKeys = 1024
+
+// SampleOptions are options for a sample handler.
+type SampleOptions struct {
+	// Interval is the window in which identical messages are counted, defaults to 1s.
+	Interval time.Duration
+
+	// First is the number of identical messages logged per interval, defaults to 10.
+	First int
+}
+
+// sampleWindow counts the records with the same level and message in an interval.
+type sampleWindow struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// sampleKey identifies identical messages.
+type sampleKey struct {
+	level slog.Level
+	msg   string
+}
+
+// sampleState is shared by a sample handler and its derived handlers.
+type sampleState struct {
+	opts    SampleOptions
+	now     func() time.Time
+	mu      sync.Mutex
+	windows map[sampleKey]*sampleWindow
+}
+
+// sampleHandler rate-limits identical messages.
+type sampleHandler struct {
+	slog.Handler
+	state *sampleState
+}
+
+// NewSampleHandler wraps handler to log at most opts.First records with the
+// same level and message per opts.Interval, dropping the rest. The first
+// record logged after records were dropped has a "dropped" attribute with the
+// number of records dropped in the previous interval.
+func NewSampleHandler(handler slog.Handler, opts SampleOptions) slog.Handler {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.First <= 0 {
+		opts.First = 10
+	}
+	return &sampleHandler{
+		Handler: handler,
+		state: &sampleState{
+			opts:    opts,
+			now:     time.Now,
+			windows: map[sampleKey]*sampleWindow{},
+		},
+	}
+}
+
+// Handle implements [slog.Handler].
+func (h *sampleHandler) Handle(ctx context.Context, r slog.Record) error {
+	keep, dropped := h.state.sample(sampleKey{r.Level, r.Message})
+	if !keep {
+		return nil
+	}
+	if dropped > 0 {
+		r = r.Clone()
+		r.AddAttrs(slog.Int("dropped", dropped))
+	}
+	return h.Handler.Handle(ctx, r) //nolint:wrapcheck
+}
+
+// WithAttrs implements [slog.Handler].
+func (h *sampleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampleHandler{Handler: h.Handler.WithAttrs(attrs), state: h.state}
+}
+
+// WithGroup implements [slog.Handler].
+func (h *sampleHandler) WithGroup(name string) slog.Handler {
+	return &sampleHandler{Handler: h.Handler.WithGroup(name), state: h.state}
+}
+
+// sample reports whether a record should be logged, and the number of records
+// dropped in the previous window to report with it.
+func (s *sampleState) sample(key sampleKey) (bool, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	w, ok := s.windows[key]
+	if !ok || now.Sub(w.start) >= s.opts.Interval {
+		if len(s.windows) >= maxSampleKeys {
+			s.prune(now)
+		}
+		dropped := 0
+		if ok {
+			dropped = w.dropped
+		}
+		s.windows[key] = &sampleWindow{start: now, count: 1}
+		return true, dropped
+	}
+
+	if w.count < s.opts.First {
+		w.count++
+		return true, 0
+	}
+	w.dropped++
+	return false, 0
+}
+
+// prune removes expired windows. Drop counts of the expired windows are lost.
+func (s *sampleState) prune(now time.Time) {
+	maps.DeleteFunc(s.windows, func(_ sampleKey, w *sampleWindow) bool {
+		return now.Sub(w.start) >= s.opts.Interval
+	})
+}
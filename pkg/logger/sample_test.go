@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewSampleHandler(NewConsoleHandler(buf, &ConsoleHandlerOptions{NoColor: true, TimeFormat: "-"}),
+		SampleOptions{Interval: time.Minute, First: 2})
+	now := time.Now()
+	handler.(*sampleHandler).state.now = func() time.Time { return now }
+	log := slog.New(handler)
+
+	for range 5 {
+		log.Info("repeated")
+	}
+	log.With("a", 1).Warn("repeated")
+	log.Info("other")
+	assert.Equal(t, []string{"INF repeated", "INF repeated", "WRN repeated a=1", "INF other", ""},
+		strings.Split(buf.String(), "\n"))
+
+	buf.Reset()
+	now = now.Add(time.Minute)
+	log.Info("repeated")
+	assert.Equal(t, "INF repeated dropped=3\n", buf.String())
+}
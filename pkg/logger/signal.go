@@ -0,0 +1,35 @@
+//go:build unix
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleLevelSignals changes the level on SIGUSR1 (more verbose) and SIGUSR2
+// (less verbose), one standard level per signal, until ctx is done.
+func HandleLevelSignals(ctx context.Context, level *slog.LevelVar) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		defer signal.Stop(sigs)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigs:
+				old := level.Level()
+				steps := -1
+				if sig == syscall.SIGUSR2 {
+					steps = 1
+				}
+				l := stepLevel(level, steps)
+				FromContext(ctx).InfoContext(ctx, "Log level changed", "signal", sig.String(), "from", old, "to", l)
+			}
+		}
+	}()
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// HandleLevelSignals is a no-op on systems without SIGUSR1 and SIGUSR2, such as Windows.
+func HandleLevelSignals(ctx context.Context, level *slog.LevelVar) {}
@@ -39,6 +39,12 @@ func CodeBlock(lang, code string) string {
 	return "```" + lang + "\n" + code + "\n```"
 }
 
+// TableCell escapes text for use in a table cell, so pipes and line breaks do not end the cell.
+func TableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
 // UList renders the items as an unordered list.
 func UList(items ...string) string {
 	return "- " + strings.Join(items, "\n- ") + "\n"
@@ -0,0 +1,220 @@
+package md
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rule rewrites the lines of a markdown document.
+//
+// Rules are given the kind of each line so they can skip code blocks and front matter.
+type Rule func(lines []string, kinds []LineKind) []string
+
+// LineKind classifies a line of a markdown document.
+type LineKind int
+
+// Defined line kinds.
+const (
+	LineText        LineKind = iota // Markdown content
+	LineFence                       // Opening or closing code fence
+	LineCode                        // Content of a fenced code block
+	LineFrontMatter                 // YAML front matter, including its delimiters
+)
+
+// DefaultRules are the rules applied by [Normalize] when no rules are given.
+var DefaultRules = []Rule{
+	TrimTrailingWhitespace,
+	ConsistentBullets,
+	HeadingIncrements,
+	BlankLinesAroundHeadings,
+	BlankLinesAroundFences,
+	BlankLinesAroundLists,
+	CollapseBlankLines,
+}
+
+// Normalize fixes common markdownlint issues in the text by applying the rules in order.
+// If no rules are given, [DefaultRules] are applied.
+//
+// The result always ends with a single newline.
+func Normalize(text string, rules ...Rule) string {
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for _, rule := range rules {
+		lines = rule(lines, classifyLines(lines))
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+var (
+	fenceRe   = regexp.MustCompile("^\\s*(```+|~~~+)")
+	headingRe = regexp.MustCompile(`^(#{1,6})(\s+|$)`)
+	bulletRe  = regexp.MustCompile(`^(\s*)[*+](\s+)`)
+	listRe    = regexp.MustCompile(`^\s*([*+-]|\d+[.)])\s+`)
+	ruleRe    = regexp.MustCompile(`^\s*([*_-])(\s*([*_-])){2,}\s*$`)
+)
+
+// classifyLines determines the kind of each line.
+func classifyLines(lines []string) []LineKind {
+	kinds := make([]LineKind, len(lines))
+	fence := ""
+	for i, line := range lines {
+		switch {
+		case i == 0 && line == "---":
+			// Front matter continues to the closing delimiter
+			kinds[i] = LineFrontMatter
+			for j := 1; j < len(lines); j++ {
+				kinds[j] = LineFrontMatter
+				if lines[j] == "---" {
+					break
+				}
+			}
+		case kinds[i] == LineFrontMatter:
+		case fence != "":
+			kinds[i] = LineCode
+			if isClosingFence(line, fence) {
+				kinds[i] = LineFence
+				fence = ""
+			}
+		default:
+			if m := fenceRe.FindStringSubmatch(line); m != nil {
+				kinds[i] = LineFence
+				fence = m[1]
+			}
+		}
+	}
+	return kinds
+}
+
+// isClosingFence reports whether the line closes the code block opened by fence:
+// the same fence character at least as many times, with nothing after it but whitespace.
+func isClosingFence(line, fence string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) >= len(fence) && strings.TrimLeft(line, fence[:1]) == ""
+}
+
+// isBlank reports whether the line is empty or only whitespace.
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// isListItem reports whether the line begins a list item.
+func isListItem(line string) bool {
+	return listRe.MatchString(line) && !ruleRe.MatchString(line)
+}
+
+// TrimTrailingWhitespace removes whitespace from the end of lines (MD009).
+func TrimTrailingWhitespace(lines []string, kinds []LineKind) []string {
+	for i := range lines {
+		if kinds[i] != LineCode {
+			lines[i] = strings.TrimRight(lines[i], " \t")
+		}
+	}
+	return lines
+}
+
+// ConsistentBullets uses "-" as the marker of all unordered list items (MD004).
+func ConsistentBullets(lines []string, kinds []LineKind) []string {
+	for i, line := range lines {
+		if kinds[i] == LineText && !ruleRe.MatchString(line) {
+			lines[i] = bulletRe.ReplaceAllString(line, "$1-$2")
+		}
+	}
+	return lines
+}
+
+// HeadingIncrements lowers headings that skip levels, so each heading is
+// at most one level deeper than the one before it (MD001).
+func HeadingIncrements(lines []string, kinds []LineKind) []string {
+	prev := 0
+	for i, line := range lines {
+		if kinds[i] != LineText {
+			continue
+		}
+		m := headingRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		level := len(m[1])
+		if prev > 0 && level > prev+1 {
+			level = prev + 1
+			lines[i] = strings.Repeat("#", level) + strings.TrimPrefix(line, m[1])
+		}
+		prev = level
+	}
+	return lines
+}
+
+// BlankLinesAroundHeadings surrounds headings with blank lines (MD022).
+func BlankLinesAroundHeadings(lines []string, kinds []LineKind) []string {
+	return surroundWithBlankLines(lines, kinds, func(i int) (before, after bool) {
+		heading := kinds[i] == LineText && headingRe.MatchString(lines[i])
+		return heading, heading
+	})
+}
+
+// BlankLinesAroundFences surrounds fenced code blocks with blank lines (MD031).
+func BlankLinesAroundFences(lines []string, kinds []LineKind) []string {
+	open := false
+	return surroundWithBlankLines(lines, kinds, func(i int) (before, after bool) {
+		if kinds[i] != LineFence {
+			return false, false
+		}
+		open = !open
+		return open, !open
+	})
+}
+
+// BlankLinesAroundLists adds a blank line before lists that follow other content (MD032).
+func BlankLinesAroundLists(lines []string, kinds []LineKind) []string {
+	return surroundWithBlankLines(lines, kinds, func(i int) (before, after bool) {
+		if kinds[i] != LineText || !isListItem(lines[i]) || i == 0 {
+			return false, false
+		}
+		prev := lines[i-1]
+		// Items and continuation lines of the same list
+		if isListItem(prev) || strings.HasPrefix(prev, " ") || strings.HasPrefix(prev, "\t") {
+			return false, false
+		}
+		return kinds[i-1] == LineText, false
+	})
+}
+
+// CollapseBlankLines removes consecutive blank lines outside of code blocks (MD012).
+func CollapseBlankLines(lines []string, kinds []LineKind) []string {
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if i > 0 && kinds[i] == LineText && isBlank(line) && isBlank(lines[i-1]) && kinds[i-1] == LineText {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// isComment reports whether the line begins or ends an HTML comment.
+func isComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "<!--") || strings.HasSuffix(line, "-->")
+}
+
+// surroundWithBlankLines inserts blank lines before and after the lines selected by fn,
+// unless already present or at the start or end of the document.
+//
+// Blank lines are not inserted after HTML comments, which may be
+// directives for the following line such as "markdownlint-disable-next-line".
+func surroundWithBlankLines(lines []string, kinds []LineKind, fn func(i int) (before, after bool)) []string {
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		before, after := fn(i)
+		if before && len(out) > 0 && !isBlank(out[len(out)-1]) && !isComment(out[len(out)-1]) {
+			out = append(out, "")
+		}
+		out = append(out, line)
+		if after && i+1 < len(lines) && !isBlank(lines[i+1]) {
+			out = append(out, "")
+		}
+	}
+	return out
+}
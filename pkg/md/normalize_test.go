@@ -0,0 +1,193 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_classifyLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []LineKind
+	}{
+		{
+			name:  "text",
+			lines: []string{"# Title", "", "text"},
+			want:  []LineKind{LineText, LineText, LineText},
+		},
+		{
+			name:  "backtick fence",
+			lines: []string{"```go", "code", "```", "text"},
+			want:  []LineKind{LineFence, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "tilde fence",
+			lines: []string{"~~~", "code", "~~~", "text"},
+			want:  []LineKind{LineFence, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "fence with info string does not close",
+			lines: []string{"```", "```go", "code", "```", "text"},
+			want:  []LineKind{LineFence, LineCode, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "shorter fence does not close",
+			lines: []string{"````markdown", "```go", "code", "```", "````", "text"},
+			want:  []LineKind{LineFence, LineCode, LineCode, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "longer fence closes",
+			lines: []string{"```", "code", "`````", "text"},
+			want:  []LineKind{LineFence, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "other fence character does not close",
+			lines: []string{"~~~", "```", "code", "~~~", "text"},
+			want:  []LineKind{LineFence, LineCode, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "closing fence with trailing whitespace",
+			lines: []string{"```", "code", "```  ", "text"},
+			want:  []LineKind{LineFence, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "adjacent fences",
+			lines: []string{"```sh", "a", "```", "```yaml", "b", "```", "text"},
+			want:  []LineKind{LineFence, LineCode, LineFence, LineFence, LineCode, LineFence, LineText},
+		},
+		{
+			name:  "unclosed fence",
+			lines: []string{"text", "```", "code"},
+			want:  []LineKind{LineText, LineFence, LineCode},
+		},
+		{
+			name:  "front matter",
+			lines: []string{"---", "title: x", "---", "text"},
+			want:  []LineKind{LineFrontMatter, LineFrontMatter, LineFrontMatter, LineText},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyLines(tt.lines))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		text string
+		want string
+	}{
+		{
+			name: "trailing whitespace trimmed",
+			rule: TrimTrailingWhitespace,
+			text: "text  \n\t\n",
+			want: "text\n",
+		},
+		{
+			name: "trailing whitespace kept in code",
+			rule: TrimTrailingWhitespace,
+			text: "```\ncode  \n```\n",
+			want: "```\ncode  \n```\n",
+		},
+		{
+			name: "bullets",
+			rule: ConsistentBullets,
+			text: "* a\n+ b\n  * c\n",
+			want: "- a\n- b\n  - c\n",
+		},
+		{
+			name: "bullets kept for rules",
+			rule: ConsistentBullets,
+			text: "* * *\n",
+			want: "* * *\n",
+		},
+		{
+			name: "bullets kept in code",
+			rule: ConsistentBullets,
+			text: "```\n```go\n* not a bullet\n```\n* bullet\n",
+			want: "```\n```go\n* not a bullet\n```\n- bullet\n",
+		},
+		{
+			name: "bullets kept in tilde code",
+			rule: ConsistentBullets,
+			text: "~~~\n* not a bullet\n~~~\n",
+			want: "~~~\n* not a bullet\n~~~\n",
+		},
+		{
+			name: "heading increments",
+			rule: HeadingIncrements,
+			text: "# A\n### B\n#### C\n## D\n",
+			want: "# A\n## B\n### C\n## D\n",
+		},
+		{
+			name: "heading increments skip code",
+			rule: HeadingIncrements,
+			text: "# A\n```\n### comment\n```\n",
+			want: "# A\n```\n### comment\n```\n",
+		},
+		{
+			name: "blank lines around headings",
+			rule: BlankLinesAroundHeadings,
+			text: "text\n# A\ntext\n",
+			want: "text\n\n# A\n\ntext\n",
+		},
+		{
+			name: "blank lines around fences",
+			rule: BlankLinesAroundFences,
+			text: "text\n```\ncode\n```\ntext\n",
+			want: "text\n\n```\ncode\n```\n\ntext\n",
+		},
+		{
+			name: "blank lines around adjacent fences",
+			rule: BlankLinesAroundFences,
+			text: "```sh\na\n```\n```yaml\nb\n```\n",
+			want: "```sh\na\n```\n\n```yaml\nb\n```\n",
+		},
+		{
+			name: "blank lines around nested fences",
+			rule: BlankLinesAroundFences,
+			text: "text\n````md\n```go\ncode\n```\n````\ntext\n",
+			want: "text\n\n````md\n```go\ncode\n```\n````\n\ntext\n",
+		},
+		{
+			name: "blank lines not added after comments",
+			rule: BlankLinesAroundFences,
+			text: "<!-- markdownlint-disable-next-line -->\n```\ncode\n```\n",
+			want: "<!-- markdownlint-disable-next-line -->\n```\ncode\n```\n",
+		},
+		{
+			name: "blank lines around lists",
+			rule: BlankLinesAroundLists,
+			text: "text\n- a\n- b\n  more\n- c\n",
+			want: "text\n\n- a\n- b\n  more\n- c\n",
+		},
+		{
+			name: "blank lines collapsed",
+			rule: CollapseBlankLines,
+			text: "a\n\n\n\nb\n",
+			want: "a\n\nb\n",
+		},
+		{
+			name: "blank lines kept in code",
+			rule: CollapseBlankLines,
+			text: "```\na\n\n\nb\n```\n",
+			want: "```\na\n\n\nb\n```\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.text, tt.rule))
+		})
+	}
+}
+
+func TestNormalizeDefaultRules(t *testing.T) {
+	text := "---\ntitle: x\n---\n# Title\n### Usage  \n```go\n* code\n```\n* item\r\n\n\n\ntext"
+	want := "---\ntitle: x\n---\n\n# Title\n\n## Usage\n\n```go\n* code\n```\n\n- item\n\ntext\n"
+	assert.Equal(t, want, Normalize(text))
+}
@@ -13,6 +13,8 @@ func FromFlag(f *pflag.Flag) *Option {
 		ValueType:       Type(flagutil.GetFirstAnnotationOr(f, valueTypeAnno, "")),
 		TargetGroupName: flagutil.GetFirstAnnotationOr(f, targetGroupAnno, ""),
 		Default:         flagutil.GetFirstAnnotationOr(f, defaultAnno, ""),
+		ProfileDefaults: flagutil.GetProfileDefaults(f),
+		Choices:         flagutil.GetChoices(f),
 		Name:            flagutil.GetFirstAnnotationOr(f, nameAnno, ""),
 		JSON:            flagutil.GetFirstAnnotationOr(f, jsonAnno, ""),
 		Env:             flagutil.GetEnvName(f),
@@ -22,6 +24,9 @@ func FromFlag(f *pflag.Flag) *Option {
 		FlagType:        flagutil.GetFirstAnnotationOr(f, flagTypeAnno, f.Value.Type()),
 		Short:           flagutil.GetFirstAnnotationOr(f, shortAnno, ""),
 		Long:            flagutil.GetFirstAnnotationOr(f, longAnno, ""),
+		Deprecated:      f.Deprecated,
+		Sensitive:       flagutil.IsSensitive(f),
+		RenamedFrom:     renamesFromFlag(f),
 	}
 	return opt
 }
@@ -40,10 +45,16 @@ const (
 	longAnno        = "options_option_long"      // annotation for [Option.Long]
 	targetGroupAnno = "options_option_target"    // annotation for [Option.TargetGroupName]
 	groupAnno       = "options_option_group"     // used to group flags
+
+	renamedJSONAnno = "options_option_renamedFrom_json" // annotation for [Rename.JSON] values in [Option.RenamedFrom]
+	renamedEnvAnno  = "options_option_renamedFrom_env"  // annotation for [Rename.Env] values in [Option.RenamedFrom]
+	renamedFlagAnno = "options_option_renamedFrom_flag" // annotation for [Rename.Flag] values in [Option.RenamedFrom]
 )
 
 // withOptionConfig adds sets annotations on the flag from the option definition.
-func withOptionConfig(f *pflag.Flag, opt *Option) {
+//
+// Deprecations and renames are registered in the flag set.
+func withOptionConfig(flagSet *pflag.FlagSet, f *pflag.Flag, opt *Option) {
 	// Default some fields from the flag
 	if opt.FlagUsage == "" {
 		opt.FlagUsage = f.Usage
@@ -59,6 +70,7 @@ func withOptionConfig(f *pflag.Flag, opt *Option) {
 	setAnnoIfNotEmpty(f, valueTypeAnno, opt.ValueType)
 	setAnnoIfNotEmpty(f, targetGroupAnno, opt.TargetGroupName)
 	setAnnoIfNotEmpty(f, defaultAnno, opt.Default)
+	setProfileDefaults(f, opt)
 	setAnnoIfNotEmpty(f, nameAnno, opt.Name)
 	setAnnoIfNotEmpty(f, jsonAnno, opt.JSON)
 	if opt.Env != "" {
@@ -68,6 +80,13 @@ func withOptionConfig(f *pflag.Flag, opt *Option) {
 	setAnnoIfNotEmpty(f, flagTypeAnno, opt.FlagType)
 	setAnnoIfNotEmpty(f, shortAnno, opt.Short)
 	setAnnoIfNotEmpty(f, longAnno, opt.Long)
+	if opt.Sensitive {
+		flagutil.MarkSensitive(f)
+	}
+	if opt.Deprecated != "" {
+		_ = flagSet.MarkDeprecated(f.Name, opt.Deprecated)
+	}
+	registerRenames(flagSet, f, opt.RenamedFrom)
 }
 
 func setAnnoIfNotEmpty[T ~string](f *pflag.Flag, key string, value T) {
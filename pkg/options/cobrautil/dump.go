@@ -0,0 +1,135 @@
+package cobrautil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/redact"
+	"github.com/act3-ai/go-common/pkg/termdoc/mdfmt"
+)
+
+// Flag dump output formats.
+const (
+	dumpOutputTable = "table"
+	dumpOutputJSON  = "json"
+	dumpOutputYAML  = "yaml"
+)
+
+// FlagDump describes the resolved value of a flag, see [DumpFlags].
+type FlagDump struct {
+	Command    string               `json:"command"`
+	Flag       string               `json:"flag"`
+	Value      string               `json:"value"`
+	Default    string               `json:"default"`
+	Env        string               `json:"env,omitempty"`
+	Group      string               `json:"group,omitempty"`
+	Source     flagutil.ValueSource `json:"source"`
+	SourceName string               `json:"sourceName,omitempty"` // Name of the flag, environment variable, or config file field that set the value
+}
+
+// DumpFlags reports the resolved value of every flag in the command tree.
+//
+// Flags are reported once, on the command that defines them. Flags that were not parsed
+// are reported with the value of their environment variable, if set. The values of
+// sensitive flags are redacted.
+func DumpFlags(root *cobra.Command) []FlagDump {
+	var dump []FlagDump
+	WalkCommands(root, func(cmd *cobra.Command) {
+		cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Name == "help" {
+				return
+			}
+			dump = append(dump, dumpFlag(cmd, f))
+		})
+	})
+	return dump
+}
+
+// dumpFlag describes the resolved value of the flag.
+func dumpFlag(cmd *cobra.Command, f *pflag.Flag) FlagDump {
+	source, name := flagutil.GetValueSource(f)
+	d := FlagDump{
+		Command:    cmd.CommandPath(),
+		Flag:       f.Name,
+		Value:      f.Value.String(),
+		Default:    f.DefValue,
+		Env:        flagutil.GetEnvName(f),
+		Source:     source,
+		SourceName: name,
+	}
+	if source == flagutil.SourceDefault {
+		if envName, value, ok := flagutil.LookupEnvValue(f); ok {
+			d.Value, d.Source, d.SourceName = value, flagutil.SourceEnv, envName
+		}
+	}
+	if flagutil.IsSensitive(f) {
+		d.Value, d.Default = redact.Redacted, redact.Redacted
+	}
+	if g, ok := options.GroupOf(f); ok {
+		d.Group = g.Key
+	}
+	return d
+}
+
+// NewDumpFlagsCmd creates a hidden "debug-flags" command that prints the resolved value,
+// default, environment variable, group, and source of every flag of root's command tree,
+// see [DumpFlags]. Its output can be attached to bug reports.
+//
+//	root.AddCommand(cobrautil.NewDumpFlagsCmd(root))
+func NewDumpFlagsCmd(root *cobra.Command) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:    "debug-flags",
+		Short:  "Print the resolved value of every flag",
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dump := DumpFlags(root)
+			switch output {
+			case dumpOutputJSON:
+				data, err := json.MarshalIndent(dump, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding flags: %w", err)
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return err //nolint:wrapcheck
+			case dumpOutputYAML:
+				data, err := yaml.Marshal(dump)
+				if err != nil {
+					return fmt.Errorf("encoding flags: %w", err)
+				}
+				_, err = cmd.OutOrStdout().Write(data)
+				return err //nolint:wrapcheck
+			default:
+				rows := make([][]string, 0, len(dump))
+				for _, d := range dump {
+					source := string(d.Source)
+					if d.SourceName != "" {
+						source += " (" + d.SourceName + ")"
+					}
+					rows = append(rows, []string{d.Command, "--" + d.Flag, escapePipes(d.Value), escapePipes(d.Default), d.Env, d.Group, source})
+				}
+				// The table is not wrapped to the terminal, so it can be copied into a bug report
+				_, err := fmt.Fprint(cmd.OutOrStdout(),
+					mdfmt.WriteTable([]string{"Command", "Flag", "Value", "Default", "Env", "Group", "Source"}, rows))
+				return err //nolint:wrapcheck
+			}
+		},
+	}
+
+	flagutil.ChoiceVarP(cmd.Flags(), &output, "output", "o", dumpOutputTable,
+		[]string{dumpOutputTable, dumpOutputJSON, dumpOutputYAML}, "output format")
+
+	return cmd
+}
+
+// escapePipes escapes pipe characters, which would otherwise end a markdown table cell.
+var escapePipes = strings.NewReplacer("|", `\|`).Replace
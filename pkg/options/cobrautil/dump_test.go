@@ -0,0 +1,45 @@
+package cobrautil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/redact"
+)
+
+func TestNewDumpFlagsCmd(t *testing.T) {
+	t.Setenv("ACE_NAME", "env-name")
+
+	var config, name string
+	var token redact.Secret
+	root := &cobra.Command{Use: "root", CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true}}
+	root.PersistentFlags().StringVar(&config, "config", "config.yaml", "Config file")
+	flagutil.SecretVar(root.PersistentFlags(), &token, "token", "default-token", "API token")
+
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	nameFlag := options.StringVar(child.Flags(), &name, "world", &options.Option{Flag: "name", Env: "ACE_NAME"})
+	options.GroupFlags(&options.Group{Key: "greeting"}, nameFlag)
+	root.AddCommand(child, NewDumpFlagsCmd(root))
+
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetArgs([]string{"debug-flags", "--config", "other.yaml", "--token", "secret-token", "-o", "json"})
+	require.NoError(t, root.Execute())
+	assert.NotContains(t, out.String(), "secret-token")
+
+	var dump []FlagDump
+	require.NoError(t, json.Unmarshal(out.Bytes(), &dump))
+	assert.Equal(t, []FlagDump{
+		{Command: "root", Flag: "config", Value: "other.yaml", Default: "config.yaml", Source: flagutil.SourceFlag, SourceName: "config"},
+		{Command: "root", Flag: "token", Value: redact.Redacted, Default: redact.Redacted, Source: flagutil.SourceFlag, SourceName: "token"},
+		{Command: "root child", Flag: "name", Value: "env-name", Default: "world", Env: "ACE_NAME", Group: "greeting", Source: flagutil.SourceEnv, SourceName: "ACE_NAME"},
+		{Command: "root debug-flags", Flag: "output", Value: "json", Default: "table", Source: flagutil.SourceFlag, SourceName: "output"},
+	}, dump)
+}
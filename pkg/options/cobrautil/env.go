@@ -36,3 +36,29 @@ func ParseEnvOverrides(cmd *cobra.Command) error {
 
 	return flagErr
 }
+
+// FlagValueSource describes the effective value of a flag and where it came from.
+type FlagValueSource struct {
+	Flag   *pflag.Flag          // The flag
+	Value  string               // Effective value of the flag
+	Source flagutil.ValueSource // Source of the value
+	Name   string               // Name of the flag, environment variable, or config file field that set the value
+}
+
+// ValueSources reports the effective value and source of each flag of the command.
+//
+// Call ValueSources after [ParseEnvOverrides] (and [options.BindConfig], if used)
+// to produce an effective configuration report for debugging layered configuration.
+func ValueSources(cmd *cobra.Command) []FlagValueSource {
+	var sources []FlagValueSource
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		source, name := flagutil.GetValueSource(f)
+		sources = append(sources, FlagValueSource{
+			Flag:   f,
+			Value:  f.Value.String(),
+			Source: source,
+			Name:   name,
+		})
+	})
+	return sources
+}
@@ -0,0 +1,99 @@
+package cobrautil
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// CommandInfo is a machine-readable description of a command.
+type CommandInfo struct {
+	Name       string         `json:"name"`
+	Path       string         `json:"path"`
+	Use        string         `json:"use"`
+	Aliases    []string       `json:"aliases,omitempty"`
+	Short      string         `json:"short,omitempty"`
+	Long       string         `json:"long,omitempty"`
+	Example    string         `json:"example,omitempty"`
+	Deprecated string         `json:"deprecated,omitempty"`
+	Hidden     bool           `json:"hidden,omitempty"`
+	Runnable   bool           `json:"runnable"`
+	Flags      []*FlagInfo    `json:"flags,omitempty"`
+	Commands   []*CommandInfo `json:"commands,omitempty"`
+}
+
+// FlagInfo is a machine-readable description of a flag.
+type FlagInfo struct {
+	Name        string   `json:"name"`
+	Shorthand   string   `json:"shorthand,omitempty"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default,omitempty"`
+	Usage       string   `json:"usage,omitempty"`
+	Env         string   `json:"env,omitempty"`
+	JSON        string   `json:"json,omitempty"`
+	Group       string   `json:"group,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Deprecated  string   `json:"deprecated,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty"`
+	Persistent  bool     `json:"persistent,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+}
+
+// ExportCommandTree describes the command and all of its subcommands.
+//
+// Each command lists its local flags, including persistent flags defined by the command,
+// with metadata from the [options] package. Inherited flags are listed on the command that
+// defines them. The result can be encoded as JSON or YAML for external tooling.
+func ExportCommandTree(cmd *cobra.Command) *CommandInfo {
+	info := &CommandInfo{
+		Name:       cmd.Name(),
+		Path:       cmd.CommandPath(),
+		Use:        cmd.Use,
+		Aliases:    cmd.Aliases,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Example:    cmd.Example,
+		Deprecated: cmd.Deprecated,
+		Hidden:     cmd.Hidden,
+		Runnable:   cmd.Runnable(),
+	}
+
+	persistent := cmd.PersistentFlags()
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		info.Flags = append(info.Flags, exportFlag(f, persistent.Lookup(f.Name) == f))
+	})
+
+	for _, sub := range cmd.Commands() {
+		info.Commands = append(info.Commands, ExportCommandTree(sub))
+	}
+	return info
+}
+
+// exportFlag describes the flag.
+func exportFlag(f *pflag.Flag, persistent bool) *FlagInfo {
+	opt := options.FromFlag(f)
+	info := &FlagInfo{
+		Name:        f.Name,
+		Shorthand:   f.Shorthand,
+		Type:        f.Value.Type(),
+		Usage:       f.Usage,
+		Env:         opt.Env,
+		JSON:        opt.JSON,
+		Choices:     opt.Choices,
+		Description: opt.Short,
+		Deprecated:  f.Deprecated,
+		Hidden:      f.Hidden,
+		Persistent:  persistent,
+		Required:    flagutil.GetFirstAnnotationOr(f, cobra.BashCompOneRequiredFlag, "") == "true",
+	}
+	if !flagutil.DefaultIsZeroValue(f) {
+		info.Default = f.DefValue
+	}
+	if g, ok := options.GroupOf(f); ok {
+		info.Group = g.Key
+	}
+	return info
+}
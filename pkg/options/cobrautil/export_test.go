@@ -0,0 +1,49 @@
+package cobrautil
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+func TestExportCommandTree(t *testing.T) {
+	var name, config string
+	root := &cobra.Command{Use: "root", Short: "Root command"}
+	root.PersistentFlags().StringVar(&config, "config", "", "Config file")
+
+	child := &cobra.Command{Use: "child", Example: "root child --name foo", Run: func(*cobra.Command, []string) {}}
+	nameFlag := options.StringVar(child.Flags(), &name, "world", &options.Option{
+		Flag: "name", Env: "ACE_NAME", JSON: "name", Short: "Your name.",
+	})
+	options.GroupFlags(&options.Group{Key: "greeting"}, nameFlag)
+	MarkFlagRequired(nameFlag)
+	root.AddCommand(child)
+
+	assert.Equal(t, &CommandInfo{
+		Name:  "root",
+		Path:  "root",
+		Use:   "root",
+		Short: "Root command",
+		Flags: []*FlagInfo{
+			{Name: "config", Type: "string", Usage: "Config file", Persistent: true},
+		},
+		Commands: []*CommandInfo{
+			{
+				Name:     "child",
+				Path:     "root child",
+				Use:      "child",
+				Example:  "root child --name foo",
+				Runnable: true,
+				Flags: []*FlagInfo{
+					{
+						Name: "name", Type: "string", Default: "world", Usage: "Your name.",
+						Env: "ACE_NAME", JSON: "name", Group: "greeting", Description: "Your name.", Required: true,
+					},
+				},
+			},
+		},
+	}, ExportCommandTree(root))
+}
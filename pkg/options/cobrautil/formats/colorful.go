@@ -89,6 +89,8 @@ func Colorful() cobrautil.UsageFormatOptions {
 				}
 				return ansiCyan().Styled(typeName)
 			},
+			// Displays values set by environment variables in place of defaults
+			ShowEnvValues: true,
 			// Formats flag values bold
 			FormatValue: func(flag *pflag.Flag, value string) string {
 				return ansiBold().Styled(value)
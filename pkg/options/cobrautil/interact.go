@@ -0,0 +1,113 @@
+package cobrautil
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Confirm asks a yes or no question, returning def if the answer is empty.
+//
+// Returns def and [ErrNotInteractive] if input cannot be prompted.
+func (p *TerminalPrompter) Confirm(ctx context.Context, msg string, def bool) (bool, error) {
+	if err := p.check(ctx); err != nil {
+		return def, err
+	}
+	options := "[y/N]"
+	if def {
+		options = "[Y/n]"
+	}
+	for {
+		answer, err := p.readLine(fmt.Sprintf("%s %s: ", msg, options))
+		if err != nil {
+			return def, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		_, _ = fmt.Fprintln(p.out, "Please answer yes or no.")
+	}
+}
+
+// Select asks to choose one of choices, by number or value.
+//
+// Returns [ErrNotInteractive] if input cannot be prompted.
+func (p *TerminalPrompter) Select(ctx context.Context, msg string, choices []string) (string, error) {
+	if len(choices) == 0 {
+		return "", fmt.Errorf("no choices for %q", msg)
+	}
+	if err := p.check(ctx); err != nil {
+		return "", err
+	}
+	if _, err := fmt.Fprintln(p.out, msg); err != nil {
+		return "", fmt.Errorf("writing prompt: %w", err)
+	}
+	for i, choice := range choices {
+		_, _ = fmt.Fprintf(p.out, "  %d) %s\n", i+1, choice)
+	}
+	for {
+		answer, err := p.readLine(fmt.Sprintf("Choose 1-%d: ", len(choices)))
+		if err != nil {
+			return "", err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+			return choices[n-1], nil
+		}
+		if slices.Contains(choices, answer) {
+			return answer, nil
+		}
+		_, _ = fmt.Fprintf(p.out, "Invalid choice %q.\n", answer)
+	}
+}
+
+// Input asks for a line of input, repeating the prompt until validate
+// accepts it. A nil validate accepts any input.
+//
+// Returns [ErrNotInteractive] if input cannot be prompted.
+func (p *TerminalPrompter) Input(ctx context.Context, msg string, validate func(string) error) (string, error) {
+	if err := p.check(ctx); err != nil {
+		return "", err
+	}
+	for {
+		answer, err := p.readLine(msg + ": ")
+		if err != nil {
+			return "", err
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			_, _ = fmt.Fprintf(p.out, "Invalid input: %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// Secret asks for input without echoing it.
+//
+// Returns [ErrNotInteractive] if input cannot be prompted.
+func (p *TerminalPrompter) Secret(ctx context.Context, msg string) (string, error) {
+	if err := p.check(ctx); err != nil {
+		return "", err
+	}
+	return p.readSecret(msg + ": ")
+}
+
+// check returns an error if the context is done or input cannot be prompted.
+func (p *TerminalPrompter) check(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !p.Interactive() {
+		return ErrNotInteractive
+	}
+	return nil
+}
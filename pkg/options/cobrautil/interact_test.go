@@ -0,0 +1,74 @@
+package cobrautil
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPrompter creates a prompter reading the given input.
+func newTestPrompter(t *testing.T, input string) (*TerminalPrompter, *bytes.Buffer) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() { r.Close() })
+	_, err = w.WriteString(input)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	out := &bytes.Buffer{}
+	p := NewTerminalPrompter(r, out)
+	p.isTerminal = func() bool { return true }
+	return p, out
+}
+
+func TestTerminalPrompter(t *testing.T) {
+	t.Run("confirm", func(t *testing.T) {
+		p, out := newTestPrompter(t, "maybe\nyes\n\n")
+		got, err := p.Confirm(t.Context(), "Continue?", false)
+		require.NoError(t, err)
+		assert.True(t, got)
+		assert.Equal(t, "Continue? [y/N]: Please answer yes or no.\nContinue? [y/N]: ", out.String())
+
+		got, err = p.Confirm(t.Context(), "Continue?", true)
+		require.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("select", func(t *testing.T) {
+		p, _ := newTestPrompter(t, "4\n2\nblue\n")
+		got, err := p.Select(t.Context(), "Color?", []string{"red", "blue", "green"})
+		require.NoError(t, err)
+		assert.Equal(t, "blue", got)
+		got, err = p.Select(t.Context(), "Color?", []string{"red", "blue", "green"})
+		require.NoError(t, err)
+		assert.Equal(t, "blue", got)
+	})
+
+	t.Run("input", func(t *testing.T) {
+		p, out := newTestPrompter(t, "\nalice\n")
+		got, err := p.Input(t.Context(), "Name", func(s string) error {
+			if s == "" {
+				return errors.New("name is required")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", got)
+		assert.Contains(t, out.String(), "Invalid input: name is required\n")
+	})
+
+	t.Run("not interactive", func(t *testing.T) {
+		p, _ := newTestPrompter(t, "no\n")
+		t.Setenv(NonInteractiveEnv, "1")
+		got, err := p.Confirm(t.Context(), "Continue?", true)
+		assert.ErrorIs(t, err, ErrNotInteractive)
+		assert.True(t, got)
+		_, err = p.Secret(t.Context(), "Token")
+		assert.ErrorIs(t, err, ErrNotInteractive)
+	})
+}
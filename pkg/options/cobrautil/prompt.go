@@ -0,0 +1,156 @@
+package cobrautil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// ErrNotInteractive is returned by a [Prompter] that cannot prompt for input.
+var ErrNotInteractive = errors.New("input is not interactive")
+
+// Prompter prompts for the value of a flag.
+type Prompter interface {
+	// Prompt prompts for the flag's value. Input is not echoed if secret is true.
+	//
+	// Returns [ErrNotInteractive] if input cannot be prompted.
+	Prompt(f *pflag.Flag, secret bool) (string, error)
+}
+
+// NonInteractiveEnv is the environment variable that disables prompting when set to a non-empty value.
+var NonInteractiveEnv = "NONINTERACTIVE"
+
+// TerminalPrompter prompts for flag values on a terminal.
+type TerminalPrompter struct {
+	in     *os.File
+	out    io.Writer
+	reader *bufio.Reader
+
+	// isTerminal reports whether in is a terminal, overridden in tests
+	isTerminal func() bool
+}
+
+// NewTerminalPrompter creates a [TerminalPrompter] reading from in and writing prompts to out.
+func NewTerminalPrompter(in *os.File, out io.Writer) *TerminalPrompter {
+	return &TerminalPrompter{
+		in:     in,
+		out:    out,
+		reader: bufio.NewReader(in),
+		isTerminal: func() bool {
+			return term.IsTerminal(int(in.Fd()))
+		},
+	}
+}
+
+// Interactive reports whether the prompter can prompt for input. Input is not
+// interactive if it is not a terminal or the [NonInteractiveEnv] environment variable is set.
+func (p *TerminalPrompter) Interactive() bool {
+	return os.Getenv(NonInteractiveEnv) == "" && p.isTerminal()
+}
+
+// Prompt implements [Prompter].
+func (p *TerminalPrompter) Prompt(f *pflag.Flag, secret bool) (string, error) {
+	if !p.Interactive() {
+		return "", ErrNotInteractive
+	}
+	label := "--" + f.Name
+	if f.Usage != "" {
+		label = f.Usage + " (" + label + ")"
+	}
+	if secret {
+		return p.readSecret(label + ": ")
+	}
+	return p.readLine(label + ": ")
+}
+
+// readLine writes the prompt and reads a line of input.
+func (p *TerminalPrompter) readLine(prompt string) (string, error) {
+	if _, err := fmt.Fprint(p.out, prompt); err != nil {
+		return "", fmt.Errorf("writing prompt: %w", err)
+	}
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// readSecret writes the prompt and reads a line of input without echoing it.
+func (p *TerminalPrompter) readSecret(prompt string) (string, error) {
+	if _, err := fmt.Fprint(p.out, prompt); err != nil {
+		return "", fmt.Errorf("writing prompt: %w", err)
+	}
+	b, err := term.ReadPassword(int(p.in.Fd()))
+	_, _ = fmt.Fprintln(p.out)
+	if err != nil {
+		return "", fmt.Errorf("reading secret input: %w", err)
+	}
+	return string(b), nil
+}
+
+// WithRequiredFlagPrompts prompts for the values of required flags that were not set
+// for the root command and all of its subcommands, instead of failing.
+//
+// Required flags are marked with [MarkFlagRequired]. Flags marked with [flagutil.MarkSensitive]
+// are prompted as secret input. Prompting happens after the command's persistent pre-run
+// functions, so flags set by environment variables are not prompted.
+//
+// If the prompter returns [ErrNotInteractive] or an empty value, the flag is left unset
+// and the command fails with cobra's standard required flag error.
+//
+// WithRequiredFlagPrompts should be called after all subcommands have been added.
+func WithRequiredFlagPrompts(root *cobra.Command, prompter Prompter) {
+	WalkCommands(root, func(cmd *cobra.Command) {
+		if !cmd.Runnable() {
+			return
+		}
+		preRunE := cmd.PreRunE
+		preRun := cmd.PreRun
+		cmd.PreRun = nil
+		cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+			switch {
+			case preRunE != nil:
+				if err := preRunE(cmd, args); err != nil {
+					return err
+				}
+			case preRun != nil:
+				preRun(cmd, args)
+			}
+			return promptRequiredFlags(cmd, prompter)
+		}
+	})
+}
+
+// promptRequiredFlags prompts for the values of the command's unset required flags.
+func promptRequiredFlags(cmd *cobra.Command, prompter Prompter) error {
+	var missing []*pflag.Flag
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if required, ok := flagutil.GetFirstAnnotation(f, cobra.BashCompOneRequiredFlag); ok && required == "true" && !f.Changed {
+			missing = append(missing, f)
+		}
+	})
+	for _, f := range missing {
+		value, err := prompter.Prompt(f, flagutil.IsSensitive(f))
+		switch {
+		case errors.Is(err, ErrNotInteractive):
+			return nil
+		case err != nil:
+			return fmt.Errorf("prompting for flag %q: %w", f.Name, err)
+		case value == "":
+			continue
+		}
+		if err := cmd.Flags().Set(f.Name, value); err != nil {
+			return cmd.FlagErrorFunc()(cmd, fmt.Errorf("invalid argument %q for %q flag: %w", value, "--"+f.Name, err))
+		}
+	}
+	return nil
+}
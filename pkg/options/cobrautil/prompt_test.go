@@ -0,0 +1,55 @@
+package cobrautil
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// promptFunc implements Prompter with a function.
+type promptFunc func(f *pflag.Flag, secret bool) (string, error)
+
+func (fn promptFunc) Prompt(f *pflag.Flag, secret bool) (string, error) {
+	return fn(f, secret)
+}
+
+func TestWithRequiredFlagPrompts(t *testing.T) {
+	newRoot := func(prompter Prompter) (*cobra.Command, *string, *string) {
+		var user, token string
+		root := &cobra.Command{Use: "root", Run: func(*cobra.Command, []string) {}}
+		MarkFlagRequired(flagutil.StringVar(root.Flags(), &user, "user", "", "User name"))
+		tokenFlag := flagutil.StringVar(root.Flags(), &token, "token", "", "Access token")
+		MarkFlagRequired(tokenFlag)
+		flagutil.MarkSensitive(tokenFlag)
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		WithRequiredFlagPrompts(root, prompter)
+		return root, &user, &token
+	}
+
+	t.Run("prompts missing flags", func(t *testing.T) {
+		var secrets []bool
+		root, user, token := newRoot(promptFunc(func(f *pflag.Flag, secret bool) (string, error) {
+			secrets = append(secrets, secret)
+			return "prompted-" + f.Name, nil
+		}))
+		root.SetArgs([]string{"--user", "alice"})
+		assert.NoError(t, root.Execute())
+		assert.Equal(t, "alice", *user)
+		assert.Equal(t, "prompted-token", *token)
+		assert.Equal(t, []bool{true}, secrets)
+	})
+
+	t.Run("not interactive", func(t *testing.T) {
+		root, _, _ := newRoot(promptFunc(func(*pflag.Flag, bool) (string, error) {
+			return "", ErrNotInteractive
+		}))
+		root.SetArgs([]string{})
+		assert.EqualError(t, root.Execute(), `required flag(s) "token", "user" not set`)
+	})
+}
@@ -0,0 +1,157 @@
+package cobrautil
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// WithSuggestions adds "did you mean" suggestions to unknown command and unknown flag errors
+// for the root command and all of its subcommands.
+//
+// Suggestions are computed using the Levenshtein distance to command names, aliases,
+// flag names, and the environment variable names of flags. Hints are formatted with the
+// given Formatter.
+//
+// Commands with subcommands that are not runnable are made runnable so unknown
+// subcommands can be reported, displaying their help text when called without arguments.
+//
+// WithSuggestions should be called after all subcommands have been added.
+func WithSuggestions(root *cobra.Command, format Formatter) {
+	format.Default() // default formatter funcs
+
+	WalkCommands(root, func(cmd *cobra.Command) {
+		// Replace cobra's suggestions with formatted suggestions
+		cmd.DisableSuggestions = true
+		if !cmd.HasSubCommands() || cmd.Args != nil {
+			return
+		}
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return nil
+			}
+			return fmt.Errorf("unknown command %q for %q%s",
+				args[0], cmd.CommandPath(), suggestionHint(format, commandSuggestions(cmd, args[0])))
+		}
+		if !cmd.Runnable() {
+			cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+				return cmd.Help()
+			}
+		}
+	})
+
+	flagErrorFunc := root.FlagErrorFunc()
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		err = flagErrorFunc(cmd, err)
+		if err == nil {
+			return nil
+		}
+		name, ok := unknownFlagName(err)
+		if !ok {
+			return err
+		}
+		hint := suggestionHint(format, flagSuggestions(cmd.Flags(), name))
+		if hint == "" {
+			return err
+		}
+		return fmt.Errorf("%w%s", err, hint)
+	})
+}
+
+// suggestionMaxDistance is the maximum Levenshtein distance for a suggestion.
+const suggestionMaxDistance = 2
+
+// suggestionHint formats suggestions as a hint to append to an error message.
+func suggestionHint(format Formatter, suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	hint := "\n\n" + format.Header("Did you mean this?") + "\n"
+	for _, s := range suggestions {
+		hint += "  " + format.Command(s) + "\n"
+	}
+	return hint
+}
+
+// commandSuggestions suggests available subcommands of cmd for the typo.
+func commandSuggestions(cmd *cobra.Command, typo string) []string {
+	var suggestions []string
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() && sub.Name() != "help" {
+			continue
+		}
+		candidates := append([]string{sub.Name()}, sub.Aliases...)
+		candidates = append(candidates, sub.SuggestFor...)
+		if slices.ContainsFunc(candidates, func(c string) bool { return isSuggestion(typo, c) }) {
+			suggestions = append(suggestions, sub.Name())
+		}
+	}
+	return suggestions
+}
+
+// flagSuggestions suggests flags in the flag set for the typo.
+func flagSuggestions(f *pflag.FlagSet, typo string) []string {
+	var suggestions []string
+	f.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		candidates := []string{flag.Name}
+		if envName := flagutil.GetEnvName(flag); envName != "" {
+			// Match environment variable names given as flags, such as --ACE_SAMPLE_NAME or --ace-sample-name
+			candidates = append(candidates, envName, strings.ReplaceAll(strings.ToLower(envName), "_", "-"))
+		}
+		if slices.ContainsFunc(candidates, func(c string) bool { return isSuggestion(typo, c) }) {
+			suggestions = append(suggestions, "--"+flag.Name)
+		}
+	})
+	return suggestions
+}
+
+// isSuggestion reports whether candidate should be suggested for the typo.
+func isSuggestion(typo, candidate string) bool {
+	typo, candidate = strings.ToLower(typo), strings.ToLower(candidate)
+	return levenshtein(typo, candidate) <= suggestionMaxDistance ||
+		(len(typo) > 1 && strings.HasPrefix(candidate, typo))
+}
+
+// unknownFlagName extracts the flag name from a pflag unknown flag error.
+func unknownFlagName(err error) (string, bool) {
+	var notExist *pflag.NotExistError
+	if errors.As(err, &notExist) {
+		if name := notExist.GetSpecifiedName(); len(name) > 1 {
+			return name, true
+		}
+		return "", false
+	}
+	name, ok := strings.CutPrefix(err.Error(), "unknown flag: --")
+	return name, ok
+}
+
+// levenshtein computes the Levenshtein distance between two strings.
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
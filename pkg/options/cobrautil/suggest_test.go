@@ -0,0 +1,51 @@
+package cobrautil
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+func TestWithSuggestions(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		var name string
+		root := &cobra.Command{Use: "root", Run: func(*cobra.Command, []string) {}}
+		options.StringVar(root.Flags(), &name, "", &options.Option{Flag: "name", Env: "ACE_ROOT_NAME"})
+		group := &cobra.Command{Use: "config"}
+		group.AddCommand(&cobra.Command{Use: "show", Aliases: []string{"view"}, Run: func(*cobra.Command, []string) {}})
+		root.AddCommand(group)
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		WithSuggestions(root, Formatter{})
+		return root
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"command", []string{"confg"}, "unknown command \"confg\" for \"root\"\n\nDid you mean this?\n  config\n"},
+		{"subcommand alias", []string{"config", "veiw"}, "unknown command \"veiw\" for \"root config\"\n\nDid you mean this?\n  show\n"},
+		{"flag", []string{"--nmae", "x"}, "unknown flag: --nmae\n\nDid you mean this?\n  --name\n"},
+		{"env name as flag", []string{"--ACE_ROOT_NAME", "x"}, "unknown flag: --ACE_ROOT_NAME\n\nDid you mean this?\n  --name\n"},
+		{"no suggestion", []string{"--zzzzzz"}, "unknown flag: --zzzzzz"},
+		{"group help", []string{"config"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot()
+			root.SetArgs(tt.args)
+			err := root.Execute()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
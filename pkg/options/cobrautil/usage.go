@@ -30,19 +30,23 @@ func CommandFlagUsages(cmd *cobra.Command, opts UsageFormatOptions) string {
 
 	buf := new(bytes.Buffer)
 
-	if cmd.HasAvailableLocalFlags() {
-		usage := LocalFlagUsages(cmd, opts)
+	localFlags, inheritedFlags := commandFlagSets(cmd, opts)
+	hasLocal := localFlags.HasAvailableFlags()
+	hasInherited := inheritedFlags.HasAvailableFlags()
+
+	if hasLocal {
+		usage := GroupedFlagUsages(localFlags, opts.LocalFlags, opts.Format, opts.FlagOptions)
 		usage = strings.TrimRightFunc(usage, unicode.IsSpace) // trimTrailingWhitespaces
 		buf.WriteString(usage + "\n")
 	}
 
 	// Additional separator if needed
-	if cmd.HasAvailableLocalFlags() && cmd.HasAvailableInheritedFlags() {
+	if hasLocal && hasInherited {
 		buf.WriteString("\n")
 	}
 
-	if cmd.HasAvailableInheritedFlags() {
-		usage := InheritedFlagUsages(cmd, opts)
+	if hasInherited {
+		usage := GroupedFlagUsages(inheritedFlags, opts.InheritedFlags, opts.Format, opts.FlagOptions)
 		usage = strings.TrimRightFunc(usage, unicode.IsSpace) // trimTrailingWhitespaces
 		buf.WriteString(usage + "\n")
 	}
@@ -50,6 +54,43 @@ func CommandFlagUsages(cmd *cobra.Command, opts UsageFormatOptions) string {
 	return buf.String()
 }
 
+// commandFlagSets splits the command's flags into local and inherited flag sets.
+//
+// When both local and inherited flags are grouped, inherited flags that share a group
+// with local flags are moved to the local flag set, so each group is rendered once.
+func commandFlagSets(cmd *cobra.Command, opts UsageFormatOptions) (local, inherited *pflag.FlagSet) {
+	local, inherited = cmd.LocalFlags(), cmd.InheritedFlags()
+	if !opts.LocalFlags.GroupFlags || !opts.InheritedFlags.GroupFlags {
+		return local, inherited
+	}
+
+	localGroups, _ := options.ToGroupFlagSets(local)
+	if len(localGroups) == 0 {
+		return local, inherited
+	}
+	shared := make(map[string]bool, len(localGroups))
+	for _, g := range localGroups {
+		shared[g.Key] = true
+	}
+
+	merged := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	merged.SortFlags = local.SortFlags
+	merged.AddFlagSet(local)
+	remainder := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	remainder.SortFlags = inherited.SortFlags
+
+	inheritedGroups, ungrouped := options.ToGroupFlagSets(inherited)
+	remainder.AddFlagSet(ungrouped.FlagSet)
+	for _, g := range inheritedGroups {
+		if shared[g.Key] {
+			merged.AddFlagSet(g.FlagSet)
+		} else {
+			remainder.AddFlagSet(g.FlagSet)
+		}
+	}
+	return merged, remainder
+}
+
 // LocalFlagUsages returns flag usage for a command's local flags.
 func LocalFlagUsages(cmd *cobra.Command, opts UsageFormatOptions) string {
 	if opts.LocalFlags.UngroupedHeader == "" {
@@ -117,6 +158,9 @@ func GroupedFlagUsages(f *pflag.FlagSet, gopts FlagGroupingOptions, format Forma
 			continue
 		}
 		header := strings.TrimRight(group.Title, ".:") + ":"
+		if gopts.GroupHeader != nil {
+			header = gopts.GroupHeader(group.Group)
+		}
 		header = format.Header(header)
 		if header != "" {
 			if i != 0 {
@@ -1,10 +1,14 @@
 package cobrautil
 
 import (
+	"io"
+	"os"
 	"strings"
 	"text/template"
 
+	"github.com/act3-ai/go-common/pkg/options"
 	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/termdoc"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +19,13 @@ const (
 	DefaultGlobalFlagHeader = "Global options:"
 )
 
+// Default bounds for the terminal width used to wrap help output.
+const (
+	DefaultMinColumns      = 40  // Narrowest width help output is wrapped to
+	DefaultMaxColumns      = 120 // Widest width help output is wrapped to
+	DefaultFallbackColumns = 80  // Width used when output is not a terminal
+)
+
 // Formatter defines general formatting functions.
 type Formatter struct {
 	Header         func(s string) string // Formats all header lines
@@ -57,40 +68,110 @@ type UsageFormatOptions struct {
 	FlagOptions    flagutil.UsageFormatOptions // Flag formatting options
 	LocalFlags     FlagGroupingOptions         // Flag grouping options (for local flags)
 	InheritedFlags FlagGroupingOptions         // Flag grouping options (for inherited flags)
+	MinColumns     int                         // Lower bound for the terminal width, defaults to DefaultMinColumns
+	MaxColumns     int                         // Upper bound for the terminal width, defaults to DefaultMaxColumns
+
+	// NonTerminal is the format used in place of this one when help output
+	// is not a terminal or color is disabled, such as formats.Markdown().
+	NonTerminal *UsageFormatOptions
+
+	// snippets is set if Format.CommandAndArgs was set before defaults were applied,
+	// in which case it supersedes Format.Command and Format.Args in command snippets
+	snippets bool
 }
 
 // FlagGroupingOptions is used to group flags.
 type FlagGroupingOptions struct {
-	GroupFlags      bool   // Set true to organize flags by group.
-	UngroupedHeader string // Header for section of flags without group.
+	GroupFlags      bool                          // Set true to organize flags by group.
+	UngroupedHeader string                        // Header for section of flags without group.
+	GroupHeader     func(g *options.Group) string // Produces the header for each group, defaults to the group's title.
 }
 
 func noopFormat(s string) string { return s }
 
+// TerminalColumns produces a Columns setting that queries the terminal width
+// each time it is evaluated, clamped to the range [minCols, maxCols].
+// A bound less than or equal to zero is ignored.
+func TerminalColumns(minCols, maxCols int) flagutil.DynamicColumns {
+	return func() int {
+		cols := termdoc.TerminalWidth(DefaultFallbackColumns)
+		if maxCols > 0 {
+			cols = min(cols, maxCols)
+		}
+		if minCols > 0 {
+			cols = max(cols, minCols)
+		}
+		return cols
+	}
+}
+
 // WithCustomUsage modifies a command's usage function according to the UsageFormatOptions.
 func WithCustomUsage(cmd *cobra.Command, opts UsageFormatOptions) {
-	opts.Format.Default() // default formatter funcs
-	if opts.LocalFlags.UngroupedHeader == "" {
-		opts.LocalFlags.UngroupedHeader = DefaultLocalFlagHeader
+	opts.setDefaults()
+	var nonTerminal *UsageFormatOptions
+	if opts.NonTerminal != nil {
+		nt := *opts.NonTerminal
+		nt.setDefaults()
+		nonTerminal = &nt
 	}
-	if opts.InheritedFlags.UngroupedHeader == "" {
-		opts.InheritedFlags.UngroupedHeader = DefaultGlobalFlagHeader
+
+	// Choose the format each time help or usage is rendered, from the output
+	// it is displayed on, the same way termdoc decides to render markdown.
+	// Help renders usage to a buffer, so the outermost output decides.
+	active := &opts
+	rendering := false
+	render := func(c *cobra.Command, w io.Writer) (done func()) {
+		if rendering {
+			return func() {}
+		}
+		rendering = true
+		if nonTerminal != nil && termdoc.NoColorWriter(displayOutput(c, w)) {
+			active = nonTerminal
+		}
+		return func() {
+			rendering = false
+			active = &opts
+		}
+	}
+	format := func() *UsageFormatOptions {
+		return active
 	}
 
+	helpFunc := cmd.HelpFunc()
+	cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+		defer render(c, c.OutOrStdout())()
+		helpFunc(c, args)
+	})
+	usageFunc := cmd.UsageFunc()
+	cmd.SetUsageFunc(func(c *cobra.Command) error {
+		defer render(c, c.OutOrStderr())()
+		return usageFunc(c)
+	})
+
 	cobra.AddTemplateFuncs(template.FuncMap{
 		"flagUsages": func(cmd *cobra.Command) string {
-			return CommandFlagUsages(cmd, opts)
+			return CommandFlagUsages(cmd, *format())
 		},
 		"formatHeader": func(s string) string {
-			return opts.Format.Header(s)
+			return format().Format.Header(s)
 		},
 		"formatCommand": func(commandPath string, args ...string) string {
-			return formatCommand(opts, commandPath, args...)
+			return formatCommand(*format(), commandPath, args...)
 		},
 		"formatExample": func(s string) string {
-			return opts.Format.Example(s)
+			return format().Format.Example(s)
 		},
 		"rpadANSI": rpadANSI,
+		// Wrap s to the help width, leaving room for indent spaces
+		"wrap": func(indent int, s string) string {
+			return wrapText(s, format().FlagOptions.Columns.Value()-indent, 0)
+		},
+		// Wrap a command description following a name column of the given padding
+		"wrapDescription": func(padding int, s string) string {
+			// 2 spaces of indentation + padding + 1 space of separation
+			offset := padding + 3
+			return wrapText(s, format().FlagOptions.Columns.Value()-offset, offset)
+		},
 		"formattedUseLine": func(cmd *cobra.Command) string {
 			useline := cmd.UseLine()
 			commandPath := cmd.CommandPath()
@@ -100,12 +181,12 @@ func WithCustomUsage(cmd *cobra.Command, opts UsageFormatOptions) {
 				remainder := strings.TrimPrefix(useline, commandPath+" ")
 				// Split on spaces
 				commandArgs := strings.Split(remainder, " ")
-				return formatCommand(opts, commandPath, commandArgs...)
+				return formatCommand(*format(), commandPath, commandArgs...)
 			}
 
 			// Preserve use line otherwise.
 			// commandPath = useline
-			return formatCommand(opts, useline)
+			return formatCommand(*format(), useline)
 		},
 		// Indent s by indent spaces (including the first line)
 		"indent": func(indent int, s string) string {
@@ -122,8 +203,62 @@ func WithCustomUsage(cmd *cobra.Command, opts UsageFormatOptions) {
 		},
 	})
 	cmd.SetUsageTemplate(groupedFlagsUsageTemplate)
+	cmd.SetHelpTemplate(wrappedHelpTemplate)
+}
+
+// displayOutput returns the output that help or usage rendered to w is displayed on.
+// Output rendered to memory, such as by [cobra.Command.UsageString] when cobra
+// prints usage for an error, is assumed to be displayed on the error output.
+func displayOutput(c *cobra.Command, w io.Writer) io.Writer {
+	for _, out := range []io.Writer{w, c.Root().ErrOrStderr()} {
+		if _, ok := out.(interface{ Fd() uintptr }); ok {
+			return out
+		}
+	}
+	return os.Stderr
+}
+
+// setDefaults fills in unset formatting options.
+func (opts *UsageFormatOptions) setDefaults() {
+	opts.snippets = opts.Format.CommandAndArgs != nil
+	opts.Format.Default() // default formatter funcs
+	if opts.LocalFlags.UngroupedHeader == "" {
+		opts.LocalFlags.UngroupedHeader = DefaultLocalFlagHeader
+	}
+	if opts.InheritedFlags.UngroupedHeader == "" {
+		opts.InheritedFlags.UngroupedHeader = DefaultGlobalFlagHeader
+	}
+	if opts.MinColumns == 0 {
+		opts.MinColumns = DefaultMinColumns
+	}
+	if opts.MaxColumns == 0 {
+		opts.MaxColumns = DefaultMaxColumns
+	}
+	// Wrap to the live terminal width unless the caller chose a width.
+	// The width is evaluated each time help is rendered.
+	if opts.FlagOptions.Columns == nil {
+		opts.FlagOptions.Columns = TerminalColumns(opts.MinColumns, opts.MaxColumns)
+	}
+}
+
+// wrapText wraps each line of s to width, indenting continuation lines by hang spaces.
+// Text is left unwrapped if width is not positive.
+func wrapText(s string, width, hang int) string {
+	if width <= 0 {
+		return s
+	}
+	wrapped := ansi.Wrap(s, width, "")
+	if hang == 0 {
+		return wrapped
+	}
+	return strings.ReplaceAll(wrapped, "\n", "\n"+strings.Repeat(" ", hang))
 }
 
+// This is a modified version of cobra's help template.
+var wrappedHelpTemplate = `{{with (or .Long .Short)}}{{wrap 0 . | trimTrailingWhitespaces}}
+
+{{end}}{{if or .Runnable .HasSubCommands}}{{.UsageString}}{{end}}`
+
 // This is a modified version of cobra's usage template.
 var groupedFlagsUsageTemplate = `{{formatHeader "Usage:"}}{{if .Runnable}}
   {{formattedUseLine .}}{{end}}{{if .HasAvailableSubCommands}}
@@ -133,21 +268,21 @@ var groupedFlagsUsageTemplate = `{{formatHeader "Usage:"}}{{if .Runnable}}
   {{.NameAndAliases}}{{end}}{{if .HasExample}}
 
 {{formatHeader "Examples:"}}
-{{formatExample .Example | indent 2}}{{end}}{{if .HasAvailableSubCommands}}{{$cmds := .Commands}}{{if eq (len .Groups) 0}}
+{{formatExample .Example | wrap 2 | indent 2}}{{end}}{{if .HasAvailableSubCommands}}{{$cmds := .Commands}}{{if eq (len .Groups) 0}}
 
 {{formatHeader "Available Commands:"}}{{range $cmds}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
-  {{rpadANSI (formatCommand .Name) .NamePadding}} {{.Short}}{{end}}{{end}}{{else}}{{range $group := .Groups}}
+  {{rpadANSI (formatCommand .Name) .NamePadding}} {{wrapDescription .NamePadding .Short}}{{end}}{{end}}{{else}}{{range $group := .Groups}}
 
 {{formatHeader .Title}}{{range $cmds}}{{if (and (eq .GroupID $group.ID) (or .IsAvailableCommand (eq .Name "help")))}}
-  {{rpadANSI (formatCommand .Name) .NamePadding}} {{.Short}}{{end}}{{end}}{{end}}{{if not .AllChildCommandsHaveGroup}}
+  {{rpadANSI (formatCommand .Name) .NamePadding}} {{wrapDescription .NamePadding .Short}}{{end}}{{end}}{{end}}{{if not .AllChildCommandsHaveGroup}}
 
 {{formatHeader "Additional Commands:"}}{{range $cmds}}{{if (and (eq .GroupID "") (or .IsAvailableCommand (eq .Name "help")))}}
-  {{rpadANSI (formatCommand .Name) .NamePadding}} {{.Short}}{{end}}{{end}}{{end}}{{end}}{{end}}{{with flagUsages .}}
+  {{rpadANSI (formatCommand .Name) .NamePadding}} {{wrapDescription .NamePadding .Short}}{{end}}{{end}}{{end}}{{end}}{{end}}{{with flagUsages .}}
 
 {{ . | trimTrailingWhitespaces }}{{end}}{{if .HasHelpSubCommands}}
 
 {{formatHeader "Additional help topics:"}}{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
-  {{rpadANSI (formatCommand .CommandPath) .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+  {{rpadANSI (formatCommand .CommandPath) .CommandPathPadding}} {{wrapDescription .CommandPathPadding .Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
 
 Use "{{formatCommand .CommandPath "[command]" "--help"}}" for more information about a command.{{end}}
 `
@@ -187,6 +322,10 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 `
 
 func formatCommand(opts UsageFormatOptions, commandPath string, args ...string) string {
+	if opts.snippets {
+		// CommandAndArgs supersedes Command and Args
+		return opts.Format.CommandAndArgs(strings.Join(append([]string{commandPath}, args...), " "))
+	}
 	commandPath = opts.Format.Command(commandPath)
 	for i, arg := range args {
 		switch {
@@ -0,0 +1,162 @@
+package cobrautil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+func TestCommandFlagUsages_inheritedGroups(t *testing.T) {
+	var registry, token, output, verbose string
+
+	registryGroup := &options.Group{Key: "registry", Title: "Registry options"}
+	outputGroup := &options.Group{Key: "output", Title: "Output options"}
+
+	root := &cobra.Command{Use: "root"}
+	options.GroupFlags(registryGroup,
+		options.StringVar(root.PersistentFlags(), &registry, "", &options.Option{Flag: "registry", FlagUsage: "Registry host"}))
+	options.GroupFlags(outputGroup,
+		options.StringVar(root.PersistentFlags(), &output, "", &options.Option{Flag: "output", FlagUsage: "Output format"}))
+	root.PersistentFlags().StringVar(&verbose, "verbose", "", "Verbosity")
+
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	options.GroupFlags(registryGroup,
+		options.StringVar(child.Flags(), &token, "", &options.Option{Flag: "token", FlagUsage: "Registry token"}))
+	root.AddCommand(child)
+
+	opts := UsageFormatOptions{
+		LocalFlags:     FlagGroupingOptions{GroupFlags: true},
+		InheritedFlags: FlagGroupingOptions{GroupFlags: true},
+	}
+	assert.Equal(t, heredoc.Doc(`
+		Registry options:
+		      --registry string   Registry host
+		      --token string      Registry token
+
+		Global options:
+		      --verbose string   Verbosity
+
+		Output options:
+		      --output string   Output format
+	`), CommandFlagUsages(child, opts))
+
+	opts.InheritedFlags.GroupHeader = func(g *options.Group) string {
+		return "Global " + g.Title + ":"
+	}
+	opts.LocalFlags.GroupFlags = false
+	assert.Equal(t, heredoc.Doc(`
+		Options:
+		      --token string   Registry token
+
+		Global options:
+		      --verbose string   Verbosity
+
+		Global Output options:
+		      --output string   Output format
+
+		Global Registry options:
+		      --registry string   Registry host
+	`), CommandFlagUsages(child, opts))
+}
+
+func TestTerminalColumns(t *testing.T) {
+	t.Setenv("COLUMNS", "200")
+	assert.Equal(t, 120, TerminalColumns(40, 120).Value())
+	assert.Equal(t, 200, TerminalColumns(40, 0).Value())
+
+	t.Setenv("COLUMNS", "20")
+	assert.Equal(t, 40, TerminalColumns(40, 120).Value())
+	assert.Equal(t, 20, TerminalColumns(0, 120).Value())
+}
+
+func TestWithCustomUsage_wrapsToTerminalWidth(t *testing.T) {
+	root := &cobra.Command{
+		Use:  "root",
+		Long: "This long description is wordy enough that it must be wrapped.",
+		Run:  func(*cobra.Command, []string) {},
+	}
+	root.Flags().String("name", "", "Name of the thing that is wordy enough to wrap")
+	WithCustomUsage(root, UsageFormatOptions{MinColumns: 1})
+
+	render := func() string {
+		out := &strings.Builder{}
+		root.SetOut(out)
+		assert.NoError(t, root.Help())
+		return out.String()
+	}
+
+	// Width is evaluated each time help is rendered
+	t.Setenv("COLUMNS", "200")
+	assert.Contains(t, render(), "This long description is wordy enough that it must be wrapped.\n")
+
+	t.Setenv("COLUMNS", "40")
+	for line := range strings.SplitSeq(render(), "\n") {
+		assert.LessOrEqual(t, len(line), 40, line)
+	}
+}
+
+func TestWithCustomUsage_nonTerminal(t *testing.T) {
+	root := &cobra.Command{
+		Use:     "root",
+		Example: "root --name foo",
+		Run:     func(*cobra.Command, []string) {},
+	}
+	root.Flags().String("name", "", "Name of the thing")
+	WithCustomUsage(root, UsageFormatOptions{
+		Format: Formatter{Header: strings.ToUpper},
+		NonTerminal: &UsageFormatOptions{
+			Format: Formatter{
+				Header: func(s string) string { return "## " + strings.TrimSuffix(s, ":") + "\n" },
+			},
+		},
+	})
+
+	// Output of tests is not a terminal
+	out := &strings.Builder{}
+	root.SetOut(out)
+	assert.NoError(t, root.Help())
+	assert.Contains(t, out.String(), "## Usage\n")
+	assert.Contains(t, out.String(), "## Examples\n")
+	assert.NotContains(t, out.String(), "USAGE:")
+}
+
+func TestWithCustomUsage_nonTerminalSnippets(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{
+		Use:  "sub [flags]",
+		RunE: func(*cobra.Command, []string) error { return errors.New("failed") },
+	}
+	root.AddCommand(sub)
+	WithCustomUsage(root, UsageFormatOptions{
+		NonTerminal: &UsageFormatOptions{
+			Format: Formatter{
+				Command:        func(s string) string { return "__" + s + "__" },
+				Args:           func(s string) string { return "__" + s + "__" },
+				CommandAndArgs: func(s string) string { return "`" + s + "`" },
+			},
+		},
+	})
+
+	// Help output is not a terminal
+	out := &strings.Builder{}
+	root.SetOut(out)
+	require.NoError(t, root.Help())
+	assert.Contains(t, out.String(), "\n  `root [command]`\n")
+	assert.Contains(t, out.String(), "Use \"`root [command] --help`\" for more information about a command.")
+	assert.NotContains(t, out.String(), "__")
+
+	// Usage printed for errors is rendered for the error output
+	out.Reset()
+	root.SetErr(out)
+	root.SetArgs([]string{"sub"})
+	require.Error(t, root.Execute())
+	assert.Contains(t, out.String(), "Error: failed\n")
+	assert.Contains(t, out.String(), "\n  `root sub [flags]`\n")
+}
@@ -0,0 +1,139 @@
+package options
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// BindConfigFile reads a YAML or JSON config file and applies its values to the flags in the flag set.
+//
+// See [BindConfig] for details.
+func BindConfigFile(f *pflag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	cfg, err := DecodeConfig(data)
+	if err != nil {
+		return fmt.Errorf("decoding config file %q: %w", path, err)
+	}
+	return BindConfig(f, cfg)
+}
+
+// BindConfig applies values from a decoded config file to the flags in the flag set.
+//
+// Each flag with an [Option.JSON] path (or a previous path in [Option.RenamedFrom]) is set
+// from the value at that path in cfg, writing to the same target as the flag. Flags that were
+// already set, either on the command line or from an environment variable, are not modified. This gives the
+// precedence config < env < flag, regardless of whether BindConfig is called before
+// or after [flagutil.ParseEnvOverrides].
+//
+// Config values do not mark the flag as changed.
+func BindConfig(f *pflag.FlagSet, cfg map[string]any) error {
+	var errs []error
+	f.VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed {
+			return
+		}
+		path, value, ok := lookupFlagConfig(flag, cfg)
+		if !ok {
+			return
+		}
+		if err := setFromConfig(flag, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for %q in config file: %w", path, err))
+			return
+		}
+		flagutil.SetConfigOverride(flag, path)
+	})
+	return errors.Join(errs...)
+}
+
+// DecodeConfig decodes YAML or JSON config file contents, for use with [BindConfig].
+// Numbers are decoded as [json.Number] to preserve their formatting.
+func DecodeConfig(data []byte) (map[string]any, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("converting YAML to JSON: %w", err)
+	}
+	cfg := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.UseNumber() // preserve integer formatting
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("decoding JSON: %w", err)
+	}
+	return cfg, nil
+}
+
+// lookupFlagConfig finds the flag's value in cfg, falling back to previous JSON paths of renamed options.
+func lookupFlagConfig(flag *pflag.Flag, cfg map[string]any) (path string, value any, ok bool) {
+	path, hasPath := flagutil.GetFirstAnnotation(flag, jsonAnno)
+	if hasPath {
+		if value, ok = lookupConfigPath(cfg, path); ok && value != nil {
+			return path, value, true
+		}
+	}
+	for _, oldPath := range flag.Annotations[renamedJSONAnno] {
+		if oldPath == "" {
+			continue
+		}
+		if value, ok = lookupConfigPath(cfg, oldPath); ok && value != nil {
+			slog.Warn("config file field is deprecated",
+				slog.String("field", oldPath),
+				slog.String("replacement", path))
+			return oldPath, value, true
+		}
+	}
+	return "", nil, false
+}
+
+// lookupConfigPath finds the value at the dot-separated path in cfg.
+func lookupConfigPath(cfg map[string]any, path string) (any, bool) {
+	var current any = cfg
+	for key := range strings.SplitSeq(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setFromConfig sets the flag's value from a config value.
+func setFromConfig(flag *pflag.Flag, value any) error {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		// Replace the value so a later env override is not appended
+		if sv, ok := flag.Value.(pflag.SliceValue); ok {
+			return sv.Replace(items) //nolint:wrapcheck
+		}
+		return flag.Value.Set(strings.Join(items, ",")) //nolint:wrapcheck
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, key+"="+fmt.Sprint(item))
+		}
+		slices.Sort(pairs)
+		return flag.Value.Set(strings.Join(pairs, ",")) //nolint:wrapcheck
+	default:
+		return flag.Value.Set(fmt.Sprint(v)) //nolint:wrapcheck
+	}
+}
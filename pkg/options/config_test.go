@@ -0,0 +1,62 @@
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+func TestBindConfigFile(t *testing.T) {
+	var (
+		host string
+		port int
+		tags []string
+		name string
+	)
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	StringVar(f, &host, "localhost", &Option{JSON: "server.host", Flag: "host"})
+	IntVar(f, &port, 80, &Option{JSON: "server.port", Flag: "port"})
+	StringSliceVar(f, &tags, nil, &Option{JSON: "tags", Flag: "tag"})
+	StringVar(f, &name, "", &Option{JSON: "name", Flag: "name", Env: "TEST_BIND_CONFIG_NAME"})
+
+	t.Setenv("TEST_BIND_CONFIG_NAME", "from-env")
+	require.NoError(t, f.Parse([]string{"--port", "9090"}))
+	f.VisitAll(func(flag *pflag.Flag) {
+		require.NoError(t, flagutil.ParseEnvOverrides(flag))
+	})
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+server:
+  host: example.com
+  port: 8080
+tags: [a, b]
+name: from-config
+`), 0o600))
+
+	require.NoError(t, BindConfigFile(f, path))
+	assert.Equal(t, "example.com", host, "config overrides default")
+	assert.Equal(t, 9090, port, "flag overrides config")
+	assert.Equal(t, []string{"a", "b"}, tags)
+	assert.Equal(t, "from-env", name, "env overrides config")
+
+	for flag, want := range map[string]flagutil.ValueSource{
+		"host": flagutil.SourceConfig,
+		"port": flagutil.SourceFlag,
+		"name": flagutil.SourceEnv,
+	} {
+		got, _ := flagutil.GetValueSource(f.Lookup(flag))
+		assert.Equal(t, want, got, flag)
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(`server: {port: "not a number"}`), 0o600))
+	f = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	IntVar(f, &port, 80, &Option{JSON: "server.port", Flag: "port"})
+	assert.Error(t, BindConfigFile(f, path))
+}
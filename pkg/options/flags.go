@@ -1,9 +1,15 @@
 package options
 
 import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/redact"
 )
 
 /*
@@ -22,7 +28,7 @@ func OptionFlag[T any](f *pflag.FlagSet, p *T, value T, opts *Option,
 	createVarP FlagFuncP[T],
 ) *pflag.Flag {
 	flag := createVarP(f, p, opts.Flag, opts.FlagShorthand, value, opts.formattedFlagUsage())
-	withOptionConfig(flag, opts)
+	withOptionConfig(f, flag, opts)
 	return flag
 }
 
@@ -43,7 +49,7 @@ func StringToOptStringVar(f *pflag.FlagSet, p *map[string]*string, value map[str
 // Var creates a flag for the option.
 func Var(f *pflag.FlagSet, value pflag.Value, opts *Option) *pflag.Flag {
 	flag := f.VarPF(value, opts.Flag, opts.FlagShorthand, opts.formattedFlagUsage())
-	withOptionConfig(flag, opts)
+	withOptionConfig(f, flag, opts)
 	return flag
 }
 
@@ -57,23 +63,72 @@ func BoolVar(f *pflag.FlagSet, p *bool, value bool, opts *Option) *pflag.Flag {
 // BoolFunc creates a flag for the option.
 func BoolFunc(f *pflag.FlagSet, opts *Option, fn func(string) error) *pflag.Flag {
 	flag := flagutil.BoolFuncP(f, opts.Flag, opts.FlagShorthand, opts.formattedFlagUsage(), fn)
-	withOptionConfig(flag, opts)
+	withOptionConfig(f, flag, opts)
 	return flag
 }
 
 /* Bytes flag types */
 
+// BytesBase64Var creates a flag for the option.
+func BytesBase64Var(f *pflag.FlagSet, p *[]byte, value []byte, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.BytesBase64VarP)
+}
+
+// BytesHexVar creates a flag for the option.
+func BytesHexVar(f *pflag.FlagSet, p *[]byte, value []byte, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.BytesHexVarP)
+}
+
+/* Choice flag types */
+
+// ChoiceVar creates a flag for the option, accepting only the values in [Option.Choices].
+// Shell completion for the allowed values is registered for the flag.
+//
+// The option's Type defaults to [Choice].
+func ChoiceVar(f *pflag.FlagSet, p *string, value string, opts *Option) *pflag.Flag {
+	if opts.Type == "" {
+		opts.Type = Choice
+	}
+	if opts.FlagType == "" {
+		opts.FlagType = strings.Join(opts.Choices, "|")
+	}
+	flag := flagutil.ChoiceVarP(f, p, opts.Flag, opts.FlagShorthand, value, opts.Choices, opts.formattedFlagUsage())
+	withOptionConfig(f, flag, opts)
+	registerChoiceCompletion(flag, opts.Choices)
+	return flag
+}
+
+// registerChoiceCompletion registers shell completion for the allowed values of the flag.
+//
+// Cobra stores completion functions by flag, so any command with the flag can register
+// it for every command the flag set is added to.
+func registerChoiceCompletion(flag *pflag.Flag, choices []string) {
+	cmd := &cobra.Command{}
+	cmd.Flags().AddFlag(flag)
+	_ = cmd.RegisterFlagCompletionFunc(flag.Name, cobra.FixedCompletions(choices, cobra.ShellCompDirectiveNoFileComp))
+}
+
 /* Count flag types */
 
 // CountVar creates a flag for the option.
 func CountVar(f *pflag.FlagSet, p *int, opts *Option) *pflag.Flag {
 	flag := flagutil.CountVarP(f, p, opts.Flag, opts.FlagShorthand, opts.formattedFlagUsage())
-	withOptionConfig(flag, opts)
+	withOptionConfig(f, flag, opts)
 	return flag
 }
 
 /* Duration flag types */
 
+// DurationVar creates a flag for the option.
+func DurationVar(f *pflag.FlagSet, p *time.Duration, value time.Duration, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.DurationVarP)
+}
+
+// DurationSliceVar creates a flag for the option.
+func DurationSliceVar(f *pflag.FlagSet, p *[]time.Duration, value []time.Duration, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.DurationSliceVarP)
+}
+
 /* Float flag types */
 
 /* Func flag types */
@@ -81,12 +136,32 @@ func CountVar(f *pflag.FlagSet, p *int, opts *Option) *pflag.Flag {
 // Func creates a flag for the option.
 func Func(f *pflag.FlagSet, opts *Option, fn func(string) error) *pflag.Flag {
 	flag := flagutil.FuncP(f, opts.Flag, opts.FlagShorthand, opts.formattedFlagUsage(), fn)
-	withOptionConfig(flag, opts)
+	withOptionConfig(f, flag, opts)
 	return flag
 }
 
 /* IP flag types */
 
+// IPVar creates a flag for the option.
+func IPVar(f *pflag.FlagSet, p *net.IP, value net.IP, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.IPVarP)
+}
+
+// IPMaskVar creates a flag for the option.
+func IPMaskVar(f *pflag.FlagSet, p *net.IPMask, value net.IPMask, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.IPMaskVarP)
+}
+
+// IPNetVar creates a flag for the option.
+func IPNetVar(f *pflag.FlagSet, p *net.IPNet, value net.IPNet, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.IPNetVarP)
+}
+
+// IPSliceVar creates a flag for the option.
+func IPSliceVar(f *pflag.FlagSet, p *[]net.IP, value []net.IP, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.IPSliceVarP)
+}
+
 /* Int flag types */
 
 // IntVar creates a flag for the option.
@@ -99,6 +174,22 @@ func Int64Var(f *pflag.FlagSet, p *int64, value int64, opts *Option) *pflag.Flag
 	return OptionFlag(f, p, value, opts, flagutil.Int64VarP)
 }
 
+/* Secret flag types */
+
+// SecretVar creates a flag for the option, masking its value when displayed.
+//
+// The value may reference a file or environment variable, see [flagutil.SecretVarP].
+// The option's Type defaults to [Secret], it is always marked Sensitive, and
+// its Default is cleared so the value is never documented.
+func SecretVar(f *pflag.FlagSet, p *redact.Secret, value redact.Secret, opts *Option) *pflag.Flag {
+	if opts.Type == "" {
+		opts.Type = Secret
+	}
+	opts.Sensitive = true
+	opts.Default = ""
+	return OptionFlag(f, p, value, opts, flagutil.SecretVarP)
+}
+
 /* String flag types */
 
 // StringVar creates a flag for the option.
@@ -111,6 +202,11 @@ func StringSliceVar(f *pflag.FlagSet, p *[]string, value []string, opts *Option)
 	return OptionFlag(f, p, value, opts, flagutil.StringSliceVarP)
 }
 
+// StringArrayVar creates a flag for the option.
+func StringArrayVar(f *pflag.FlagSet, p *[]string, value []string, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.StringArrayVarP)
+}
+
 /* Map flag types */
 
 // StringToIntVar creates a flag for the option.
@@ -129,3 +225,33 @@ func StringToStringVar(f *pflag.FlagSet, p *map[string]string, value map[string]
 }
 
 /* Uint flag types */
+
+// UintVar creates a flag for the option.
+func UintVar(f *pflag.FlagSet, p *uint, value uint, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.UintVarP)
+}
+
+// UintSliceVar creates a flag for the option.
+func UintSliceVar(f *pflag.FlagSet, p *[]uint, value []uint, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.UintSliceVarP)
+}
+
+// Uint8Var creates a flag for the option.
+func Uint8Var(f *pflag.FlagSet, p *uint8, value uint8, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.Uint8VarP)
+}
+
+// Uint16Var creates a flag for the option.
+func Uint16Var(f *pflag.FlagSet, p *uint16, value uint16, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.Uint16VarP)
+}
+
+// Uint32Var creates a flag for the option.
+func Uint32Var(f *pflag.FlagSet, p *uint32, value uint32, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.Uint32VarP)
+}
+
+// Uint64Var creates a flag for the option.
+func Uint64Var(f *pflag.FlagSet, p *uint64, value uint64, opts *Option) *pflag.Flag {
+	return OptionFlag(f, p, value, opts, flagutil.Uint64VarP)
+}
@@ -0,0 +1,36 @@
+package options
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChoiceVar(t *testing.T) {
+	var format string
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{
+		Use: "get",
+		Run: func(*cobra.Command, []string) {},
+	}
+	root.AddCommand(sub)
+	ChoiceVar(sub.Flags(), &format, "json", &Option{
+		Flag:    "format",
+		Choices: []string{"json", "yaml", "table"},
+	})
+
+	// Completion is registered for the allowed values
+	out := &strings.Builder{}
+	root.SetOut(out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{cobra.ShellCompNoDescRequestCmd, "get", "--format", ""})
+	require.NoError(t, root.Execute())
+	assert.True(t, strings.HasPrefix(out.String(), "json\nyaml\ntable\n:4\n"), out.String())
+
+	root.SetArgs([]string{"get", "--format", "xml"})
+	require.Error(t, root.Execute())
+}
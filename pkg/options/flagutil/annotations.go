@@ -4,6 +4,25 @@ import (
 	"github.com/spf13/pflag"
 )
 
+// choicesAnno is the key for the allowed values of a choice flag.
+const choicesAnno = "flagutil_choices"
+
+// sensitiveAnno marks a flag whose value is sensitive.
+const sensitiveAnno = "flagutil_sensitive"
+
+// MarkSensitive marks the flag's value as sensitive, such as a password or token.
+//
+// Sensitive values are read without echoing input when prompted.
+func MarkSensitive(f *pflag.Flag) {
+	SetAnnotation(f, sensitiveAnno, "true")
+}
+
+// IsSensitive reports whether the flag was marked with [MarkSensitive].
+func IsSensitive(f *pflag.Flag) bool {
+	v, _ := GetFirstAnnotation(f, sensitiveAnno)
+	return v == "true"
+}
+
 // SetAnnotation sets the flag's annotations for the given key.
 func SetAnnotation(f *pflag.Flag, key string, values ...string) {
 	if f.Annotations == nil {
@@ -2,6 +2,7 @@ package flagutil
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/spf13/pflag"
@@ -13,6 +14,9 @@ const (
 
 	// envOverrideAnno signals that the flag's value came from an environment variable.
 	envOverrideAnno = "flagutil_value_from_env"
+
+	// deprecatedEnvAnno is the key for the deprecated environment variable names annotation.
+	deprecatedEnvAnno = "flagutil_env_deprecated_names"
 )
 
 // SetEnvName sets the name of an environment variable used to override the flag's value
@@ -32,6 +36,21 @@ func GetEnvName(f *pflag.Flag) string {
 	return GetFirstAnnotationOr(f, envAnno, "")
 }
 
+// AddDeprecatedEnvNames adds previous names of the environment variable used to override the flag's value.
+//
+// ParseEnvOverrides uses the deprecated names, with a warning, if the current environment variable is not set.
+func AddDeprecatedEnvNames(f *pflag.Flag, envNames ...string) {
+	SetAnnotation(f, deprecatedEnvAnno, append(GetDeprecatedEnvNames(f), envNames...)...)
+}
+
+// GetDeprecatedEnvNames gets the previous names of the environment variable used to override the flag's value.
+func GetDeprecatedEnvNames(f *pflag.Flag) []string {
+	if f.Annotations == nil {
+		return nil
+	}
+	return f.Annotations[deprecatedEnvAnno]
+}
+
 // ParseEnvOverrides overrides the flag from an environment variable,
 // if it has a defined environment variable and the flag was not already set.
 //
@@ -51,15 +70,14 @@ func ParseEnvOverrides(f *pflag.Flag) error {
 	if f.Changed {
 		return nil
 	}
-	// Skip flags without an "env" annotation
-	envName, ok := GetFirstAnnotation(f, envAnno)
+	envName, envString, ok := lookupFlagEnv(f)
 	if !ok {
 		return nil
 	}
-	// Lookup environment variable, skip if unset.
-	envString, ok := os.LookupEnv(envName)
-	if !ok {
-		return nil
+	if f.Deprecated != "" {
+		slog.Warn("environment variable is deprecated",
+			slog.String("env", envName),
+			slog.String("reason", f.Deprecated))
 	}
 	err := f.Value.Set(envString)
 	if err != nil {
@@ -71,6 +89,48 @@ func ParseEnvOverrides(f *pflag.Flag) error {
 	return nil
 }
 
+// lookupFlagEnv looks up the flag's environment variable, falling back to its deprecated names.
+func lookupFlagEnv(f *pflag.Flag) (envName, envString string, ok bool) {
+	envName, hasEnv := GetFirstAnnotation(f, envAnno)
+	if hasEnv {
+		if envString, ok = os.LookupEnv(envName); ok {
+			return envName, envString, true
+		}
+	}
+	for _, oldName := range GetDeprecatedEnvNames(f) {
+		if envString, ok = os.LookupEnv(oldName); ok {
+			slog.Warn("environment variable is deprecated",
+				slog.String("env", oldName),
+				slog.String("replacement", envName))
+			return oldName, envString, true
+		}
+	}
+	return "", "", false
+}
+
+// LookupEnvValue returns the value of the environment variable overriding the flag, if set.
+//
+// If the flag's value was already set from the environment by [ParseEnvOverrides],
+// the flag's current value is returned. Otherwise, the flag's environment variable
+// and its deprecated names are looked up, without logging deprecation warnings.
+func LookupEnvValue(f *pflag.Flag) (envName, value string, ok bool) {
+	switch source, name := GetValueSource(f); source {
+	case SourceEnv:
+		return name, f.Value.String(), true
+	case SourceFlag:
+		return "", "", false
+	}
+	for _, name := range append([]string{GetEnvName(f)}, GetDeprecatedEnvNames(f)...) {
+		if name == "" {
+			continue
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			return name, value, true
+		}
+	}
+	return "", "", false
+}
+
 // EnvParseError represents an environment variable parsing error.
 type EnvParseError interface {
 	error
@@ -2,10 +2,15 @@ package flagutil
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/act3-ai/go-common/pkg/config/resolve"
+	"github.com/act3-ai/go-common/pkg/redact"
 )
 
 /* Additional flag implementations similar to the StringToString/StringToInt flag implementation in the pflag project. */
@@ -134,3 +139,64 @@ func (s *stringToOptStringValue) String() string {
 	w.Flush()
 	return "[" + strings.TrimSpace(buf.String()) + "]"
 }
+
+// -- choice Value
+type choiceValue struct {
+	value   *string
+	choices []string
+}
+
+func newChoiceValue(val string, p *string, choices []string) *choiceValue {
+	cv := &choiceValue{value: p, choices: choices}
+	*cv.value = val
+	return cv
+}
+
+func (c *choiceValue) Set(val string) error {
+	if !slices.Contains(c.choices, val) {
+		return fmt.Errorf("must be one of: %s", strings.Join(c.choices, ", "))
+	}
+	*c.value = val
+	return nil
+}
+
+func (c *choiceValue) Type() string {
+	return "string"
+}
+
+func (c *choiceValue) String() string {
+	return *c.value
+}
+
+// -- secret Value
+type secretValue struct {
+	value *redact.Secret
+}
+
+func newSecretValue(val redact.Secret, p *redact.Secret) *secretValue {
+	sv := &secretValue{value: p}
+	*sv.value = val
+	return sv
+}
+
+// Set resolves file and env references, such as "file:///run/secrets/password", to the secret.
+func (s *secretValue) Set(val string) error {
+	resolved, err := resolve.New().Resolve(context.Background(), val)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	*s.value = redact.Secret(resolved)
+	return nil
+}
+
+func (s *secretValue) Type() string {
+	return "secret"
+}
+
+// String masks the value so it is not displayed in help, reports, or telemetry.
+func (s *secretValue) String() string {
+	if *s.value == "" {
+		return ""
+	}
+	return redact.Redacted
+}
@@ -0,0 +1,91 @@
+package flagutil
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/redact"
+)
+
+func TestChoiceVarP(t *testing.T) {
+	var format string
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flag := ChoiceVarP(f, &format, "format", "f", "yaml", []string{"yaml", "json"}, "Output format")
+
+	assert.Equal(t, "yaml", format)
+	assert.Equal(t, []string{"yaml", "json"}, GetChoices(flag))
+	assert.Contains(t, FlagUsages(f, UsageFormatOptions{}), "--format yaml|json")
+
+	require.NoError(t, f.Parse([]string{"--format", "json"}))
+	assert.Equal(t, "json", format)
+
+	assert.Error(t, f.Parse([]string{"--format", "toml"}))
+	assert.Equal(t, "json", format, "invalid value is not set")
+}
+
+func TestSecretVarP(t *testing.T) {
+	var token redact.Secret
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flag := SecretVarP(f, &token, "token", "", "default-token", "API token")
+
+	assert.True(t, IsSensitive(flag))
+	assert.Equal(t, redact.Redacted, flag.Value.String())
+	assert.NotContains(t, FlagUsages(f, UsageFormatOptions{}), "default")
+
+	require.NoError(t, f.Parse([]string{"--token", "literal"}))
+	assert.Equal(t, redact.Secret("literal"), token)
+	assert.Equal(t, redact.Redacted, flag.Value.String())
+
+	t.Setenv("TEST_TOKEN", "from-env")
+	require.NoError(t, f.Parse([]string{"--token", "env://TEST_TOKEN"}))
+	assert.Equal(t, redact.Secret("from-env"), token)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+	require.NoError(t, f.Parse([]string{"--token", "file://" + path}))
+	assert.Equal(t, redact.Secret("from-file"), token)
+
+	assert.Error(t, f.Parse([]string{"--token", "env://TEST_TOKEN_UNSET"}))
+	assert.Error(t, f.Parse([]string{"--token", "file://" + filepath.Join(t.TempDir(), "missing")}))
+}
+
+func TestTypedVar(t *testing.T) {
+	var u url.URL
+	parseURL := func(s string) (url.URL, error) {
+		parsed, err := url.Parse(s)
+		if err != nil {
+			return url.URL{}, err //nolint:wrapcheck
+		}
+		return *parsed, nil
+	}
+	def, _ := parseURL("https://example.com")
+
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flag := TypedVar(f, &u, "endpoint", def, parseURL, "Server endpoint")
+	assert.Equal(t, "url", flag.Value.Type())
+	assert.Equal(t, "https://example.com", flag.DefValue)
+
+	require.NoError(t, f.Parse([]string{"--endpoint", "http://localhost:8080/api"}))
+	assert.Equal(t, "localhost:8080", u.Host)
+	assert.Equal(t, "http://localhost:8080/api", flag.Value.String())
+
+	assert.Error(t, f.Parse([]string{"--endpoint", "://bad"}))
+}
+
+func TestTypedVarNil(t *testing.T) {
+	var u *url.URL
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flag := TypedVar(f, &u, "proxy", nil, url.Parse, "Proxy URL")
+	assert.Equal(t, "url", flag.Value.Type())
+	assert.Empty(t, flag.DefValue)
+	assert.Empty(t, flag.Value.String())
+
+	require.NoError(t, f.Parse([]string{"--proxy", "http://proxy:3128"}))
+	assert.Equal(t, "http://proxy:3128", flag.Value.String())
+}
@@ -2,9 +2,12 @@
 package flagutil
 
 import (
+	"net"
 	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/redact"
 )
 
 /*
@@ -52,6 +55,48 @@ func StringToOptStringVarP(f *pflag.FlagSet, p *map[string]*string, name, shorth
 	return VarP(f, newStringToOptStringValue(value, p), name, shorthand, usage)
 }
 
+// ChoiceVar creates a [pflag.Flag] that only accepts one of the given choices.
+func ChoiceVar(f *pflag.FlagSet, p *string, name string, value string, choices []string, usage string) *pflag.Flag {
+	return ChoiceVarP(f, p, name, "", value, choices, usage)
+}
+
+// ChoiceVarP creates a [pflag.Flag] that only accepts one of the given choices.
+//
+// The choices are stored in the flag's annotations, see [GetChoices].
+func ChoiceVarP(f *pflag.FlagSet, p *string, name, shorthand string, value string, choices []string, usage string) *pflag.Flag {
+	flag := VarP(f, newChoiceValue(value, p, choices), name, shorthand, usage)
+	SetAnnotation(flag, choicesAnno, choices...)
+	return flag
+}
+
+// GetChoices returns the allowed values of a flag created with [ChoiceVar] or [ChoiceVarP].
+func GetChoices(f *pflag.Flag) []string {
+	if f == nil || f.Annotations == nil {
+		return nil
+	}
+	return f.Annotations[choicesAnno]
+}
+
+/* Secret flag types */
+
+// SecretVar creates a [pflag.Flag] for a sensitive value.
+//
+// See [SecretVarP].
+func SecretVar(f *pflag.FlagSet, p *redact.Secret, name string, value redact.Secret, usage string) *pflag.Flag {
+	return SecretVarP(f, p, name, "", value, usage)
+}
+
+// SecretVarP creates a [pflag.Flag] for a sensitive value.
+//
+// The value may be given directly or as a reference of the form "file://<path>"
+// or "env://<name>", which reads the value from a file (without a trailing newline) or environment variable.
+// The flag's value is masked when displayed and the flag is marked with [MarkSensitive].
+func SecretVarP(f *pflag.FlagSet, p *redact.Secret, name, shorthand string, value redact.Secret, usage string) *pflag.Flag {
+	flag := VarP(f, newSecretValue(value, p), name, shorthand, usage)
+	MarkSensitive(flag)
+	return flag
+}
+
 /* Generic value flag types */
 
 // Var creates a [pflag.Flag].
@@ -105,8 +150,30 @@ func BoolFuncP(f *pflag.FlagSet, name, shorthand string, usage string, fn func(s
 }
 
 /* Bytes flag types */
-// BytesBase64
-// BytesHex
+
+// BytesBase64Var creates a [pflag.Flag].
+func BytesBase64Var(f *pflag.FlagSet, p *[]byte, name string, value []byte, usage string) *pflag.Flag {
+	f.BytesBase64Var(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// BytesBase64VarP creates a [pflag.Flag].
+func BytesBase64VarP(f *pflag.FlagSet, p *[]byte, name, shorthand string, value []byte, usage string) *pflag.Flag {
+	f.BytesBase64VarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
+
+// BytesHexVar creates a [pflag.Flag].
+func BytesHexVar(f *pflag.FlagSet, p *[]byte, name string, value []byte, usage string) *pflag.Flag {
+	f.BytesHexVar(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// BytesHexVarP creates a [pflag.Flag].
+func BytesHexVarP(f *pflag.FlagSet, p *[]byte, name, shorthand string, value []byte, usage string) *pflag.Flag {
+	f.BytesHexVarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
 
 /* Count flag types */
 
@@ -213,10 +280,54 @@ func FuncP(f *pflag.FlagSet, name, shorthand string, usage string, fn func(strin
 }
 
 /* IP flag types */
-// IP
-// IPMask
-// IPNet
-// IPSlice
+
+// IPVar creates a [pflag.Flag].
+func IPVar(f *pflag.FlagSet, p *net.IP, name string, value net.IP, usage string) *pflag.Flag {
+	f.IPVar(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// IPVarP creates a [pflag.Flag].
+func IPVarP(f *pflag.FlagSet, p *net.IP, name, shorthand string, value net.IP, usage string) *pflag.Flag {
+	f.IPVarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
+
+// IPMaskVar creates a [pflag.Flag].
+func IPMaskVar(f *pflag.FlagSet, p *net.IPMask, name string, value net.IPMask, usage string) *pflag.Flag {
+	f.IPMaskVar(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// IPMaskVarP creates a [pflag.Flag].
+func IPMaskVarP(f *pflag.FlagSet, p *net.IPMask, name, shorthand string, value net.IPMask, usage string) *pflag.Flag {
+	f.IPMaskVarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
+
+// IPNetVar creates a [pflag.Flag].
+func IPNetVar(f *pflag.FlagSet, p *net.IPNet, name string, value net.IPNet, usage string) *pflag.Flag {
+	f.IPNetVar(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// IPNetVarP creates a [pflag.Flag].
+func IPNetVarP(f *pflag.FlagSet, p *net.IPNet, name, shorthand string, value net.IPNet, usage string) *pflag.Flag {
+	f.IPNetVarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
+
+// IPSliceVar creates a [pflag.Flag].
+func IPSliceVar(f *pflag.FlagSet, p *[]net.IP, name string, value []net.IP, usage string) *pflag.Flag {
+	f.IPSliceVar(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// IPSliceVarP creates a [pflag.Flag].
+func IPSliceVarP(f *pflag.FlagSet, p *[]net.IP, name, shorthand string, value []net.IP, usage string) *pflag.Flag {
+	f.IPSliceVarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
 
 /* Int flag types */
 
@@ -317,7 +428,6 @@ func Int64SliceVarP(f *pflag.FlagSet, p *[]int64, name, shorthand string, value
 }
 
 /* String flag types */
-// StringArray
 
 // StringVar creates a [pflag.Flag].
 func StringVar(f *pflag.FlagSet, p *string, name string, value string, usage string) *pflag.Flag {
@@ -343,6 +453,18 @@ func StringSliceVarP(f *pflag.FlagSet, p *[]string, name, shorthand string, valu
 	return f.Lookup(name)
 }
 
+// StringArrayVar creates a [pflag.Flag].
+func StringArrayVar(f *pflag.FlagSet, p *[]string, name string, value []string, usage string) *pflag.Flag {
+	f.StringArrayVar(p, name, value, usage)
+	return f.Lookup(name)
+}
+
+// StringArrayVarP creates a [pflag.Flag].
+func StringArrayVarP(f *pflag.FlagSet, p *[]string, name, shorthand string, value []string, usage string) *pflag.Flag {
+	f.StringArrayVarP(p, name, shorthand, value, usage)
+	return f.Lookup(name)
+}
+
 /* Map flag types */
 
 // StringToIntVar creates a [pflag.Flag].
@@ -0,0 +1,97 @@
+package flagutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// profileDefaultsAnno is the key for the default values of each profile, as "profile=value" entries.
+	profileDefaultsAnno = "flagutil_profile_defaults"
+
+	// profileOverrideAnno signals that the flag's value came from the defaults of a profile.
+	profileOverrideAnno = "flagutil_value_from_profile"
+
+	// profileFlagAnno marks the flag selecting the active profile.
+	profileFlagAnno = "flagutil_profile_flag"
+)
+
+// SetProfileDefault sets the default value of the flag when the profile is active,
+// applied by [ApplyProfile].
+func SetProfileDefault(f *pflag.Flag, profile, value string) {
+	entries := slices.DeleteFunc(f.Annotations[profileDefaultsAnno], func(entry string) bool {
+		name, _, _ := strings.Cut(entry, "=")
+		return name == profile
+	})
+	SetAnnotation(f, profileDefaultsAnno, append(entries, profile+"="+value)...)
+}
+
+// GetProfileDefaults gets the default values of the flag for each profile, set with [SetProfileDefault].
+func GetProfileDefaults(f *pflag.Flag) map[string]string {
+	if f.Annotations == nil || len(f.Annotations[profileDefaultsAnno]) == 0 {
+		return nil
+	}
+	defaults := make(map[string]string, len(f.Annotations[profileDefaultsAnno]))
+	for _, entry := range f.Annotations[profileDefaultsAnno] {
+		name, value, _ := strings.Cut(entry, "=")
+		defaults[name] = value
+	}
+	return defaults
+}
+
+// MarkProfileFlag marks the flag as selecting the active profile, see [ProfileFlag].
+func MarkProfileFlag(f *pflag.Flag) {
+	SetAnnotation(f, profileFlagAnno, "true")
+}
+
+// ProfileFlag returns the flag marked with [MarkProfileFlag] in the flag set, or nil.
+func ProfileFlag(f *pflag.FlagSet) *pflag.Flag {
+	var profileFlag *pflag.Flag
+	f.VisitAll(func(flag *pflag.Flag) {
+		if v, _ := GetFirstAnnotation(flag, profileFlagAnno); v == "true" && profileFlag == nil {
+			profileFlag = flag
+		}
+	})
+	return profileFlag
+}
+
+// ApplyProfile sets the flag to its default value for the profile, if it has one.
+//
+// Flags set on the command line, from an environment variable, or from a config file are
+// not modified, giving the precedence default < profile < config < env < flag. This holds
+// regardless of whether ApplyProfile is called before or after [ParseEnvOverrides] and
+// options.BindConfig, as long as the profile is selected before the other values are applied.
+//
+// Profile values do not mark the flag as changed.
+func ApplyProfile(f *pflag.Flag, profile string) error {
+	if f.Changed || profile == "" {
+		return nil
+	}
+	if _, ok := GetFirstAnnotation(f, configOverrideAnno); ok {
+		return nil
+	}
+	value, ok := GetProfileDefaults(f)[profile]
+	if !ok {
+		return nil
+	}
+
+	var err error
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		// Replace the value so a later override is not appended
+		var items []string
+		if value != "" {
+			items = strings.Split(value, ",")
+		}
+		err = sv.Replace(items)
+	} else {
+		err = f.Value.Set(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %q in profile %q: %w", value, f.Name, profile, err)
+	}
+	SetAnnotation(f, profileOverrideAnno, profile)
+	return nil
+}
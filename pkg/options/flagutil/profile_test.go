@@ -0,0 +1,46 @@
+package flagutil
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProfile(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("level", "info", "log level")
+	SetProfileDefault(fs.Lookup("level"), "dev", "debug")
+	SetProfileDefault(fs.Lookup("level"), "prod", "warn")
+	fs.StringSlice("hosts", []string{"localhost"}, "server hosts")
+	SetProfileDefault(fs.Lookup("hosts"), "prod", "a.example.com,b.example.com")
+	fs.Int("port", 80, "server port")
+	SetProfileDefault(fs.Lookup("port"), "prod", "443")
+	require.NoError(t, fs.Parse([]string{"--port", "8443"}))
+
+	assert.Equal(t, map[string]string{"dev": "debug", "prod": "warn"}, GetProfileDefaults(fs.Lookup("level")))
+
+	fs.VisitAll(func(f *pflag.Flag) {
+		require.NoError(t, ApplyProfile(f, "prod"))
+	})
+	hosts, err := fs.GetStringSlice("hosts")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, hosts)
+	assert.Equal(t, "warn", fs.Lookup("level").Value.String())
+	assert.Equal(t, "8443", fs.Lookup("port").Value.String(), "flag overrides profile")
+	assert.False(t, fs.Lookup("level").Changed)
+
+	source, name := GetValueSource(fs.Lookup("level"))
+	assert.Equal(t, SourceProfile, source)
+	assert.Equal(t, "prod", name)
+
+	usage := FlagUsages(fs, UsageFormatOptions{})
+	assert.Contains(t, usage, `log level (value "warn" from profile prod) (profile defaults dev: "debug", prod: "warn")`)
+	assert.Contains(t, usage, `server port (default 80) (profile defaults prod: 443)`)
+
+	fs.Int("count", 1, "number of items")
+	SetProfileDefault(fs.Lookup("count"), "prod", "many")
+	require.EqualError(t, ApplyProfile(fs.Lookup("count"), "prod"),
+		`invalid value "many" for "count" in profile "prod": strconv.ParseInt: parsing "many": invalid syntax`)
+}
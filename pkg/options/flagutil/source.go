@@ -0,0 +1,43 @@
+package flagutil
+
+import "github.com/spf13/pflag"
+
+// configOverrideAnno signals that the flag's value came from a config file.
+const configOverrideAnno = "flagutil_value_from_config"
+
+// ValueSource identifies where a flag's value came from.
+type ValueSource string
+
+// Defined value sources, from lowest to highest precedence.
+const (
+	SourceDefault ValueSource = "default" // Default value of the flag.
+	SourceProfile ValueSource = "profile" // Default value of the active profile.
+	SourceConfig  ValueSource = "config"  // Config file field.
+	SourceEnv     ValueSource = "env"     // Environment variable.
+	SourceFlag    ValueSource = "flag"    // Command line flag.
+)
+
+// SetConfigOverride records that the flag's value came from the config file field at path.
+func SetConfigOverride(f *pflag.Flag, path string) {
+	SetAnnotation(f, configOverrideAnno, path)
+}
+
+// GetValueSource returns the source of the flag's value, and the name of the
+// flag, environment variable, config file field, or profile that set it.
+//
+// The name is empty for default values.
+func GetValueSource(f *pflag.Flag) (ValueSource, string) {
+	if f.Changed {
+		if envName, ok := GetFirstAnnotation(f, envOverrideAnno); ok {
+			return SourceEnv, envName
+		}
+		return SourceFlag, f.Name
+	}
+	if path, ok := GetFirstAnnotation(f, configOverrideAnno); ok {
+		return SourceConfig, path
+	}
+	if profile, ok := GetFirstAnnotation(f, profileOverrideAnno); ok {
+		return SourceProfile, profile
+	}
+	return SourceDefault, ""
+}
@@ -0,0 +1,80 @@
+package flagutil
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/pflag"
+)
+
+// ValueOf adapts a pointer and a parse function to a [pflag.Value].
+//
+// The pointer is set to value. Values are formatted with their String method,
+// if implemented, or with [fmt.Sprint]. The type name of the value is derived
+// from the name of T, for example "url" for [url.URL].
+func ValueOf[T any](p *T, value T, parse func(string) (T, error)) pflag.Value {
+	*p = value
+	return &typedValue[T]{value: p, parse: parse}
+}
+
+// TypedVar creates a [pflag.Flag] for an arbitrary type using a parse function.
+func TypedVar[T any](f *pflag.FlagSet, p *T, name string, value T, parse func(string) (T, error), usage string) *pflag.Flag {
+	return Var(f, ValueOf(p, value, parse), name, usage)
+}
+
+// TypedVarP creates a [pflag.Flag] for an arbitrary type using a parse function.
+func TypedVarP[T any](f *pflag.FlagSet, p *T, name, shorthand string, value T, parse func(string) (T, error), usage string) *pflag.Flag {
+	return VarP(f, ValueOf(p, value, parse), name, shorthand, usage)
+}
+
+// -- typed Value
+type typedValue[T any] struct {
+	value *T
+	parse func(string) (T, error)
+}
+
+func (v *typedValue[T]) Set(val string) error {
+	parsed, err := v.parse(val)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	*v.value = parsed
+	return nil
+}
+
+func (v *typedValue[T]) Type() string {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return t.String()
+	}
+	return strcase.ToLowerCamel(t.Name())
+}
+
+func (v *typedValue[T]) String() string {
+	if v.value == nil || isNil(any(*v.value)) {
+		return ""
+	}
+	switch val := any(*v.value).(type) {
+	case fmt.Stringer:
+		return val.String()
+	default:
+		if p, ok := any(v.value).(fmt.Stringer); ok {
+			// String method with pointer receiver, such as url.URL
+			return p.String()
+		}
+		return fmt.Sprint(val)
+	}
+}
+
+// isNil reports whether v is nil or a nil pointer, whose String method may not handle a nil receiver.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -2,10 +2,15 @@ package flagutil
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
 	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/md"
+	"github.com/act3-ai/go-common/pkg/termdoc/mdfmt"
 )
 
 // UsageFormatOptions is used to format flag usage output.
@@ -22,6 +27,10 @@ type UsageFormatOptions struct {
 	FormatValue func(flag *pflag.Flag, value string) string
 	// FormatUsage is called to format the flag usage string.
 	FormatUsage func(flag *pflag.Flag, usage string) string
+	// ShowEnvValues displays the effective value of flags whose environment variable
+	// is set in place of their default, noting the variable, e.g. (value "x" from ACE_NAME).
+	// Values of sensitive flags are not displayed.
+	ShowEnvValues bool
 	// LineFunc overrides all other functions.
 	LineFunc func(flag *pflag.Flag) (line string, skip bool)
 }
@@ -81,6 +90,10 @@ func FlagUsages(f *pflag.FlagSet, opts UsageFormatOptions) string {
 		line += fmtName(flag, opts)
 
 		varname, usage := pflag.UnquoteUsage(flag)
+		// Display the allowed values of choice flags
+		if choices := GetChoices(flag); len(choices) > 0 && varname == flag.Value.Type() {
+			varname = strings.Join(choices, "|")
+		}
 		if varname != "" {
 			if opts.FormatType != nil {
 				varname = opts.FormatType(flag, varname)
@@ -107,11 +120,20 @@ func FlagUsages(f *pflag.FlagSet, opts UsageFormatOptions) string {
 		}
 		line += usage
 
-		// Add default value
-		if def := fmtDefault(flag, DefaultIsZeroValue(flag), opts); def != "" {
+		// Add value from the environment or default value
+		if envValue := fmtEnvValue(flag, opts); envValue != "" {
+			line += " " + envValue
+		} else if profileValue := fmtProfileValue(flag, opts); profileValue != "" {
+			line += " " + profileValue
+		} else if def := fmtDefault(flag, DefaultIsZeroValue(flag), opts); def != "" {
 			line += " " + def
 		}
 
+		// Add defaults of profiles
+		if profileDefaults := fmtProfileDefaults(flag, opts); profileDefaults != "" {
+			line += " " + profileDefaults
+		}
+
 		// Add deprecated notice
 		if len(flag.Deprecated) != 0 {
 			line += fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
@@ -178,7 +200,7 @@ func fmtNoOptDefVal(flag *pflag.Flag, opts UsageFormatOptions) string {
 			if flag.NoOptDefVal != "true" {
 				return fmt.Sprintf("[=%s]", noOptDefVal)
 			}
-		case "count":
+		case "count", "level":
 			if flag.NoOptDefVal != "+1" {
 				return fmt.Sprintf("[=%s]", noOptDefVal)
 			}
@@ -203,6 +225,57 @@ func fmtDefault(flag *pflag.Flag, defaultIsZeroValue bool, opts UsageFormatOptio
 	return fmt.Sprintf("(default %s)", defValue)
 }
 
+func fmtEnvValue(flag *pflag.Flag, opts UsageFormatOptions) string {
+	if !opts.ShowEnvValues {
+		return ""
+	}
+	envName, value, ok := LookupEnvValue(flag)
+	if !ok {
+		return ""
+	}
+	if IsSensitive(flag) || flag.Value.Type() == "secret" {
+		return fmt.Sprintf("(set from %s)", envName)
+	}
+	return fmt.Sprintf("(value %s from %s)", fmtValue(flag, value, opts), envName)
+}
+
+// fmtProfileValue formats the value of a flag set from the defaults of the active profile.
+func fmtProfileValue(flag *pflag.Flag, opts UsageFormatOptions) string {
+	source, profile := GetValueSource(flag)
+	if source != SourceProfile {
+		return ""
+	}
+	if IsSensitive(flag) || flag.Value.Type() == "secret" {
+		return fmt.Sprintf("(set from profile %s)", profile)
+	}
+	return fmt.Sprintf("(value %s from profile %s)", fmtValue(flag, flag.Value.String(), opts), profile)
+}
+
+// fmtProfileDefaults formats the defaults of each profile of a flag, in order of profile name.
+func fmtProfileDefaults(flag *pflag.Flag, opts UsageFormatOptions) string {
+	defaults := GetProfileDefaults(flag)
+	if len(defaults) == 0 || IsSensitive(flag) || flag.Value.Type() == "secret" {
+		return ""
+	}
+	profiles := slices.Sorted(maps.Keys(defaults))
+	entries := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		entries = append(entries, profile+": "+fmtValue(flag, defaults[profile], opts))
+	}
+	return "(profile defaults " + strings.Join(entries, ", ") + ")"
+}
+
+// fmtValue formats a value of the flag, quoting strings.
+func fmtValue(flag *pflag.Flag, value string, opts UsageFormatOptions) string {
+	if flag.Value.Type() == "string" {
+		value = fmt.Sprintf("%q", value)
+	}
+	if opts.FormatValue != nil {
+		value = opts.FormatValue(flag, value)
+	}
+	return value
+}
+
 // DefaultIsZeroValue returns true if the default value for this flag represents
 // a zero value.
 //
@@ -220,6 +293,9 @@ func DefaultIsZeroValue(f *pflag.Flag) bool {
 		return f.DefValue == "0"
 	case "string":
 		return f.DefValue == ""
+	case "secret":
+		// Secret defaults are never displayed
+		return true
 	case "ip", "ipMask", "ipNet":
 		return f.DefValue == "<nil>"
 	case "intSlice", "stringSlice", "stringArray":
@@ -240,3 +316,74 @@ func DefaultIsZeroValue(f *pflag.Flag) bool {
 		return false
 	}
 }
+
+// FlagUsagesMarkdown returns a markdown table containing the usage information
+// for all flags in the FlagSet.
+//
+// The table has columns for the flag, type, environment variable, default value,
+// and description. The FormatFlagName, FormatType, FormatValue, and FormatUsage
+// options are applied, other options are ignored.
+func FlagUsagesMarkdown(f *pflag.FlagSet, opts UsageFormatOptions) string {
+	if f == nil {
+		return ""
+	}
+
+	rows := [][]string{}
+	f.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		name := strings.TrimSpace(fmtName(flag, opts))
+		name = "`" + strings.ReplaceAll(name, ", ", "`, `") + "`"
+
+		varname, usage := pflag.UnquoteUsage(flag)
+		if choices := GetChoices(flag); len(choices) > 0 && varname == flag.Value.Type() {
+			varname = strings.Join(choices, "|")
+		}
+		if opts.FormatType != nil {
+			varname = opts.FormatType(flag, varname)
+		}
+
+		env := ""
+		if envName := GetEnvName(flag); envName != "" {
+			env = "`" + envName + "`"
+		}
+
+		def := ""
+		if !DefaultIsZeroValue(flag) {
+			def = flag.DefValue
+			if opts.FormatValue != nil {
+				def = opts.FormatValue(flag, def)
+			}
+			def = "`" + def + "`"
+		}
+		if profileDefaults := GetProfileDefaults(flag); len(profileDefaults) > 0 {
+			entries := make([]string, 0, len(profileDefaults))
+			for _, profile := range slices.Sorted(maps.Keys(profileDefaults)) {
+				entries = append(entries, profile+": `"+profileDefaults[profile]+"`")
+			}
+			def = strings.TrimSpace(def + " (" + strings.Join(entries, ", ") + ")")
+		}
+
+		if opts.FormatUsage != nil {
+			usage = opts.FormatUsage(flag, usage)
+		}
+		if len(flag.Deprecated) != 0 {
+			usage += fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
+		}
+
+		rows = append(rows, []string{
+			name,
+			md.TableCell(varname),
+			env,
+			md.TableCell(def),
+			md.TableCell(usage),
+		})
+	})
+
+	if len(rows) == 0 {
+		return ""
+	}
+	return mdfmt.WriteTable([]string{"Flag", "Type", "Env", "Default", "Description"}, rows)
+}
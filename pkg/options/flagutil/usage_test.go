@@ -0,0 +1,56 @@
+package flagutil
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagUsages_showEnvValues(t *testing.T) {
+	t.Setenv("TEST_NAME", "bob")
+	t.Setenv("TEST_OLD_TOKEN", "hunter2")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("name", "alice", "your name")
+	SetEnvName(fs.Lookup("name"), "TEST_NAME")
+	fs.String("token", "", "access token")
+	SetEnvName(fs.Lookup("token"), "TEST_TOKEN")
+	AddDeprecatedEnvNames(fs.Lookup("token"), "TEST_OLD_TOKEN")
+	MarkSensitive(fs.Lookup("token"))
+	fs.Int("count", 1, "number of greetings")
+	SetEnvName(fs.Lookup("count"), "TEST_COUNT")
+
+	usage := FlagUsages(fs, UsageFormatOptions{ShowEnvValues: true})
+	assert.Contains(t, usage, `your name (value "bob" from TEST_NAME)`)
+	assert.Contains(t, usage, "access token (set from TEST_OLD_TOKEN)")
+	assert.NotContains(t, usage, "hunter2")
+	assert.Contains(t, usage, "number of greetings (default 1)")
+
+	// Flags set on the command line show their default
+	require.NoError(t, fs.Parse([]string{"--name", "carol"}))
+	usage = FlagUsages(fs, UsageFormatOptions{ShowEnvValues: true})
+	assert.Contains(t, usage, `your name (default "alice")`)
+
+	// Disabled by default
+	usage = FlagUsages(fs, UsageFormatOptions{})
+	assert.NotContains(t, usage, "TEST_OLD_TOKEN")
+}
+
+func TestFlagUsagesMarkdown(t *testing.T) {
+	var (
+		name  string
+		count int
+	)
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	StringVarP(f, &name, "name", "n", "world", "Your name | nickname")
+	SetEnvName(IntVar(f, &count, "count", 0, "Number of greetings"), "TEST_COUNT")
+
+	assert.Equal(t,
+		"| Flag           | Type   | Env          | Default | Description           |\n"+
+			"| -------------- | ------ | ------------ | ------- | --------------------- |\n"+
+			"| `--count`      | int    | `TEST_COUNT` |         | Number of greetings   |\n"+
+			"| `-n`, `--name` | string |              | `world` | Your name \\| nickname |\n",
+		FlagUsagesMarkdown(f, UsageFormatOptions{}))
+}
@@ -0,0 +1,165 @@
+package flagutil
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/logger"
+)
+
+// verbosityStep is the verbosity added by each -v and removed by each -q,
+// one standard [slog.Level].
+const verbosityStep = 4
+
+// Verbosity is a logging verbosity set by the flags of [VerbosityFlags].
+//
+// Verbosities use the scale of [logger.ParseVerbosity]: 0 logs errors only,
+// and each increment of 4 enables the next standard level (4=warn, 8=info, 12=debug).
+type Verbosity struct {
+	base    int  // verbosity given as a value, from the default, environment, or flag
+	verbose int  // count of -v
+	quiet   int  // count of -q
+	lowered int  // sum of --quiet values
+	set     bool // base was set by a value, later values are summed
+}
+
+// Value returns the resolved verbosity.
+func (v *Verbosity) Value() int {
+	return v.base - v.lowered + (v.verbose-v.quiet)*verbosityStep
+}
+
+// Level returns the [slog.Level] for the resolved verbosity, see [logger.LevelForVerbosity].
+func (v *Verbosity) Level() slog.Level {
+	return logger.LevelForVerbosity(v.Value())
+}
+
+// V returns the logr V-level for the resolved verbosity, for libraries logging with logr.
+// The V-level is the negated [slog.Level], so 0 enables info messages and 4 enables debug
+// messages. It is negative when only warnings and errors are logged.
+func (v *Verbosity) V() int {
+	return -int(v.Level())
+}
+
+// VerbosityFlags creates the counted logging verbosity flags "-v, --verbosity" and
+// "-q, --quiet" storing the resolved verbosity in p.
+//
+// Each -v enables the next level, so -v logs info messages and -vv logs debug messages
+// with the default of warn (4). Each -q disables the lowest enabled level. Values use the
+// scale of [logger.ParseVerbosity]: --verbosity=debug sets the verbosity, and --quiet=4
+// lowers it by one level like -q. Repeated values of either flag are summed.
+//
+// The default verbosity is value, or the verbosity in the environment variable envName if
+// it is set and valid, following the ACE_<NAME>_VERBOSITY convention of runner.Run and otel.Run.
+//
+// The resolved verbosity of a command can be retrieved with [VerbosityOf], such as to
+// select an interactive or plain UI.
+func VerbosityFlags(f *pflag.FlagSet, p *Verbosity, value int, envName string) (verbosity, quiet *pflag.Flag) {
+	*p = Verbosity{base: value}
+	if val, ok := os.LookupEnv(envName); ok && envName != "" {
+		if v, err := logger.ParseVerbosity(val); err == nil {
+			p.base = v
+		}
+	}
+
+	verbosity = VarP(f, &verbosityValue{v: p}, "verbosity", "v", `Logging verbosity level, repeat to log more
+Aliases: error=0, warn=4, info=8, debug=12`)
+	verbosity.NoOptDefVal = "+1"
+	if envName != "" {
+		SetEnvName(verbosity, envName)
+	}
+
+	quiet = VarP(f, &quietValue{v: p}, "quiet", "q", "Decrease the logging verbosity level, repeat to log less")
+	quiet.NoOptDefVal = "+1"
+	return verbosity, quiet
+}
+
+// VerbosityOf returns the verbosity set by a flag created with [VerbosityFlags],
+// or nil if the flag is not a verbosity flag.
+//
+//	if v := flagutil.VerbosityOf(cmd.Flags().Lookup("verbosity")); v != nil && v.Level() <= slog.LevelDebug {
+//		// Use plain output so debug logs are not overwritten
+//	}
+func VerbosityOf(f *pflag.Flag) *Verbosity {
+	if f == nil {
+		return nil
+	}
+	if value, ok := f.Value.(*verbosityValue); ok {
+		return value.v
+	}
+	return nil
+}
+
+// -- verbosity Value
+type verbosityValue struct {
+	v *Verbosity
+}
+
+func (s *verbosityValue) Set(val string) error {
+	if val == "+1" {
+		s.v.verbose++
+		return nil
+	}
+	parsed, err := logger.ParseVerbosity(val)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if s.v.set {
+		s.v.base += parsed
+	} else {
+		// The first value replaces the default
+		s.v.base = parsed
+		s.v.set = true
+	}
+	return nil
+}
+
+func (s *verbosityValue) Type() string {
+	return "level"
+}
+
+func (s *verbosityValue) String() string {
+	value := s.v.Value()
+	for name, v := range verbosityNames {
+		if v == value {
+			return name
+		}
+	}
+	return strconv.Itoa(value)
+}
+
+// verbosityNames are the aliases of [logger.ParseVerbosity], used to format verbosities.
+var verbosityNames = map[string]int{
+	"error": 0,
+	"warn":  4,
+	"info":  8,
+	"debug": 12,
+}
+
+// -- quiet Value
+type quietValue struct {
+	v *Verbosity
+}
+
+func (s *quietValue) Set(val string) error {
+	if val == "+1" {
+		s.v.quiet++
+		return nil
+	}
+	parsed, err := logger.ParseVerbosity(val)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	s.v.lowered += parsed
+	return nil
+}
+
+func (s *quietValue) Type() string {
+	return "level"
+}
+
+func (s *quietValue) String() string {
+	return strconv.Itoa(s.v.lowered + s.v.quiet*verbosityStep)
+}
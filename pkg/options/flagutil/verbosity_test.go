@@ -0,0 +1,60 @@
+package flagutil
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbosityFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		level slog.Level
+		v     int
+	}{
+		{"default", nil, slog.LevelWarn, -4},
+		{"verbose", []string{"-v"}, slog.LevelInfo, 0},
+		{"very verbose", []string{"-vv"}, slog.LevelDebug, 4},
+		{"quiet", []string{"-q"}, slog.LevelError, -8},
+		{"verbose and quiet", []string{"-vvq"}, slog.LevelInfo, 0},
+		{"alias", []string{"--verbosity=debug"}, slog.LevelDebug, 4},
+		{"summed values", []string{"-v=4", "-v=info"}, slog.LevelDebug, 4},
+		{"quiet value", []string{"--quiet=4"}, slog.LevelError, -8},
+		{"quiet values and counts", []string{"-vv", "-q=4", "-q"}, slog.LevelWarn, -4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verbosity Verbosity
+			f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			VerbosityFlags(f, &verbosity, 4, "")
+
+			require.NoError(t, f.Parse(tt.args))
+			assert.Equal(t, tt.level, verbosity.Level())
+			assert.Equal(t, tt.v, verbosity.V())
+			assert.Same(t, &verbosity, VerbosityOf(f.Lookup("verbosity")))
+			assert.Equal(t, "level", f.Lookup("verbosity").Value.Type())
+			assert.Equal(t, "level", f.Lookup("quiet").Value.Type())
+		})
+	}
+}
+
+func TestVerbosityFlagsEnv(t *testing.T) {
+	t.Setenv("ACE_TEST_VERBOSITY", "debug")
+
+	var verbosity Verbosity
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flag, _ := VerbosityFlags(f, &verbosity, 4, "ACE_TEST_VERBOSITY")
+
+	assert.Equal(t, "ACE_TEST_VERBOSITY", GetEnvName(flag))
+	assert.Equal(t, slog.LevelDebug, verbosity.Level())
+
+	require.NoError(t, f.Parse([]string{"-q"}))
+	assert.Equal(t, slog.LevelInfo, verbosity.Level(), "flags adjust the environment verbosity")
+
+	assert.Error(t, f.Parse([]string{"--verbosity=loud"}))
+	assert.Nil(t, VerbosityOf(f.Lookup("quiet")))
+}
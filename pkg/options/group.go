@@ -28,6 +28,18 @@ func parseGroupDataFromFlag(f *pflag.Flag, g *Group) {
 	}
 }
 
+// GroupOf returns the [Group] the flag is part of, if any.
+//
+// The returned group only contains the group's metadata, its Options are not set.
+func GroupOf(f *pflag.Flag) (*Group, bool) {
+	if _, ok := flagutil.GetFirstAnnotation(f, groupAnno); !ok {
+		return nil, false
+	}
+	g := &Group{}
+	parseGroupDataFromFlag(f, g)
+	return g, true
+}
+
 // GroupFlags marks flags as part of a [Group].
 func GroupFlags(g *Group, flags ...*pflag.Flag) {
 	groupInfo := groupInfoAnnotation(g)
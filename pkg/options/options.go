@@ -70,26 +70,33 @@ const (
 	Integer   Type = "integer"           // Integer type.
 	Float     Type = "float"             // Float type.
 	Duration  Type = "duration (string)" // Duration string type.
+	Choice    Type = "choice (string)"   // String type restricted to a list of choices.
 	Object    Type = "object"            // Object type.
 	List      Type = "list"              // List type.
 	StringMap Type = "map"               // String map type.
+	Secret    Type = "secret (string)"   // Sensitive string type, masked when displayed.
 )
 
 // Option represents an option.
 type Option struct {
-	Type            Type   // Type of the field
-	ValueType       Type   // Type of the values in a composite option (List/StringMap)
-	TargetGroupName string // Target group ID (Object/List/StringMap)
-	Default         string // Default value (as a string)
-	Name            string // Name to use for the field in documentation
-	JSON            string // Path to field in JSON config file
-	Env             string // Environment variable name
-	Flag            string // Flag name
-	FlagShorthand   string // Flag shorthand
-	FlagUsage       string // Flag usage (if different than the short description)
-	FlagType        string // Flag type description
-	Short           string // Short description
-	Long            string // Long description
+	Type            Type              // Type of the field
+	ValueType       Type              // Type of the values in a composite option (List/StringMap)
+	TargetGroupName string            // Target group ID (Object/List/StringMap)
+	Default         string            // Default value (as a string)
+	ProfileDefaults map[string]string // Default values (as strings) for each profile, see [ProfileVar]
+	Choices         []string          // Allowed values (Choice)
+	Name            string            // Name to use for the field in documentation
+	JSON            string            // Path to field in JSON config file
+	Env             string            // Environment variable name
+	Flag            string            // Flag name
+	FlagShorthand   string            // Flag shorthand
+	FlagUsage       string            // Flag usage (if different than the short description)
+	FlagType        string            // Flag type description
+	Short           string            // Short description
+	Long            string            // Long description
+	Deprecated      string            // Deprecation message, set if the option is deprecated
+	Sensitive       bool              // Value is sensitive and should be redacted from logs and telemetry
+	RenamedFrom     []Rename          // Previous names of the option
 	// Examples    []*Example // Usage examples for this option
 }
 
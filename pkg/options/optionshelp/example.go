@@ -0,0 +1,186 @@
+package optionshelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+// Supported formats for example configuration files.
+const (
+	ExampleFormatYAML = "yaml" // Commented YAML skeleton.
+	ExampleFormatJSON = "json" // JSON skeleton, without comments.
+)
+
+// GenerateExampleConfig produces an example configuration file for the given options.
+//
+// The file is built from each option's JSON path, default value, and short description.
+// Options without a JSON path and object options (which are documented by their target group) are skipped.
+// Supported formats are "yaml" and "json". YAML output includes each option's short description as a comment.
+func GenerateExampleConfig(groups []*options.Group, format string) (string, error) {
+	root := &exampleNode{}
+	for _, g := range groups {
+		for _, o := range g.Options {
+			if o.JSON == "" || o.Type == options.Object {
+				continue
+			}
+			root.insert(strings.Split(o.JSON, "."), o)
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case ExampleFormatYAML, "yml":
+		w := &strings.Builder{}
+		root.writeYAML(w, 0)
+		return w.String(), nil
+	case ExampleFormatJSON:
+		out, err := json.MarshalIndent(root.jsonValue(), "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encoding example config: %w", err)
+		}
+		return string(out) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported example config format %q (expected %q or %q)",
+			format, ExampleFormatYAML, ExampleFormatJSON)
+	}
+}
+
+// withExampleFlag adds the --example flag to a help topic command, producing an example configuration file when set.
+func withExampleFlag(cmd *cobra.Command, groupFunc func() []*options.Group) *cobra.Command {
+	var format string
+	cmd.Flags().StringVar(&format, "example", "",
+		fmt.Sprintf("Output an example configuration file (%s, %s)", ExampleFormatYAML, ExampleFormatJSON))
+	_ = cmd.RegisterFlagCompletionFunc("example",
+		cobra.FixedCompletions([]string{ExampleFormatYAML, ExampleFormatJSON}, cobra.ShellCompDirectiveNoFileComp))
+
+	helpFunc := cmd.HelpFunc()
+	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if format == "" {
+			helpFunc(cmd, args)
+			return
+		}
+		example, err := GenerateExampleConfig(groupFunc(), format)
+		if err != nil {
+			cmd.PrintErrln(cmd.ErrPrefix() + err.Error())
+			return
+		}
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), example); err != nil {
+			cmd.PrintErrln(cmd.ErrPrefix() + err.Error())
+		}
+	})
+	return cmd
+}
+
+// exampleNode is a node in the tree of configuration fields.
+type exampleNode struct {
+	key      string
+	option   *options.Option // set for leaf nodes
+	children []*exampleNode  // set for intermediate nodes, in insertion order
+}
+
+// insert adds the option at the given path, creating intermediate nodes as needed.
+func (n *exampleNode) insert(path []string, o *options.Option) {
+	child := n.child(path[0])
+	if len(path) == 1 {
+		child.option = o
+		return
+	}
+	child.insert(path[1:], o)
+}
+
+// child returns the child node with the given key, creating it if needed.
+func (n *exampleNode) child(key string) *exampleNode {
+	for _, c := range n.children {
+		if c.key == key {
+			return c
+		}
+	}
+	c := &exampleNode{key: key}
+	n.children = append(n.children, c)
+	return c
+}
+
+// writeYAML writes the children of the node as YAML with comments.
+func (n *exampleNode) writeYAML(w *strings.Builder, depth int) {
+	pad := strings.Repeat("  ", depth)
+	for i, c := range n.children {
+		if i > 0 && depth == 0 {
+			w.WriteString("\n")
+		}
+		if len(c.children) > 0 {
+			w.WriteString(pad + c.key + ":\n")
+			c.writeYAML(w, depth+1)
+			continue
+		}
+		if desc := c.option.ShortDescription(); desc != "" {
+			for line := range strings.SplitSeq(desc, "\n") {
+				w.WriteString(strings.TrimRight(pad+"# "+line, " ") + "\n")
+			}
+		}
+		// JSON encoding of the value is valid YAML flow syntax
+		value, _ := json.Marshal(exampleValue(c.option))
+		w.WriteString(pad + c.key + ": " + string(value) + "\n")
+	}
+}
+
+// jsonValue returns the node as a value for JSON encoding.
+func (n *exampleNode) jsonValue() any {
+	if len(n.children) == 0 {
+		return exampleValue(n.option)
+	}
+	obj := orderedmap.New()
+	for _, c := range n.children {
+		obj.Set(c.key, c.jsonValue())
+	}
+	return obj
+}
+
+// exampleValue converts the option's default value to a typed value.
+func exampleValue(o *options.Option) any {
+	if o == nil {
+		return nil
+	}
+	def := o.Default
+	switch o.Type {
+	case options.Boolean:
+		if b, err := strconv.ParseBool(def); err == nil {
+			return b
+		}
+		return false
+	case options.Integer:
+		if i, err := strconv.ParseInt(def, 10, 64); err == nil {
+			return i
+		}
+		return 0
+	case options.Float:
+		if f, err := strconv.ParseFloat(def, 64); err == nil {
+			return f
+		}
+		return 0.0
+	case options.List:
+		list := []string{}
+		for item := range strings.SplitSeq(strings.Trim(def, "[]"), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	case options.StringMap:
+		m := orderedmap.New()
+		for item := range strings.SplitSeq(strings.Trim(def, "[]{}"), ",") {
+			k, v, ok := strings.Cut(strings.TrimSpace(item), "=")
+			if ok {
+				m.Set(k, v)
+			}
+		}
+		return m
+	default:
+		return def
+	}
+}
@@ -0,0 +1,83 @@
+package optionshelp
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/act3-ai/go-common/pkg/options"
+	"github.com/act3-ai/go-common/pkg/testutil"
+)
+
+func TestGenerateExampleConfig(t *testing.T) {
+	groups := []*options.Group{
+		{
+			Key: "server",
+			Options: []*options.Option{
+				{Type: options.String, JSON: "server.host", Default: "localhost", Short: "Host to listen on."},
+				{Type: options.Integer, JSON: "server.port", Default: "8080", Short: "Port to listen on."},
+				{Type: options.Boolean, Flag: "debug", Short: "Not in config file."},
+			},
+		},
+		{
+			Key: "general",
+			Options: []*options.Option{
+				{Type: options.List, JSON: "tags", Default: "[a,b]"},
+				{Type: options.StringMap, JSON: "labels"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "yaml",
+			format: "yaml",
+			want: heredoc.Doc(`
+				server:
+				  # Host to listen on.
+				  host: "localhost"
+				  # Port to listen on.
+				  port: 8080
+
+				tags: ["a","b"]
+
+				labels: {}
+			`),
+		},
+		{
+			name:   "json",
+			format: "json",
+			want: heredoc.Doc(`
+				{
+				  "server": {
+				    "host": "localhost",
+				    "port": 8080
+				  },
+				  "tags": [
+				    "a",
+				    "b"
+				  ],
+				  "labels": {}
+				}
+			`),
+		},
+		{
+			name:    "unsupported",
+			format:  "toml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateExampleConfig(groups, tt.format)
+			testutil.AssertErrorIf(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -16,20 +16,28 @@ import (
 )
 
 // Command creates a command to display help for the given options.
+//
+// The --example flag outputs an example configuration file instead, see [GenerateExampleConfig].
 func Command(name, short string, groups []*options.Group, format *mdfmt.Formatter) *cobra.Command {
 	optionsDoc, err := MarkdownDoc(groups)
 	if err != nil {
 		panic(err)
 	}
-	return termdoc.AdditionalHelpTopic(name, short, optionsDoc, format)
+	return withExampleFlag(
+		termdoc.AdditionalHelpTopic(name, short, optionsDoc, format),
+		func() []*options.Group { return groups })
 }
 
 // LazyCommand creates a command to display help for the given options.
+//
+// The --example flag outputs an example configuration file instead, see [GenerateExampleConfig].
 func LazyCommand(name, short string, groupFunc func() []*options.Group, format *mdfmt.Formatter) *cobra.Command {
 	contentFunc := func(cmd *cobra.Command, args []string) (string, error) {
 		return MarkdownDoc(groupFunc())
 	}
-	return termdoc.LazyAdditionalHelpTopic(name, short, contentFunc, format)
+	return withExampleFlag(
+		termdoc.LazyAdditionalHelpTopic(name, short, contentFunc, format),
+		groupFunc)
 }
 
 // MarkdownDoc produces markdown documentation for the given options.
@@ -0,0 +1,214 @@
+package optionshelp
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/act3-ai/go-common/pkg/md"
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+// ReferenceDoc produces an API reference for the given options, in the style of
+// Kubernetes CustomResourceDefinition references.
+//
+// Each group is a section with a table of its fields. Groups targeted by an option,
+// such as the group documenting an object option, are nested in the section of the
+// first group referencing them, with links to the groups they appear in. Long
+// descriptions follow the table.
+//
+// Groups are typically produced by [options.FlagGroups.Groups], so the reference
+// matches the registered flags.
+func ReferenceDoc(title string, groups []*options.Group) (string, error) {
+	descErr := options.ResolveDescriptions(groups...)
+
+	ref := newReference(groups)
+	b := &strings.Builder{}
+	b.WriteString(md.Header(1, title) + "\n")
+	for _, g := range ref.roots {
+		ref.writeGroup(b, g, 2)
+	}
+	return strings.TrimSpace(b.String()), descErr
+}
+
+// reference is the nesting of groups in a reference document.
+type reference struct {
+	scope     *templateScope
+	roots     []*options.Group            // Groups not targeted by other groups' options
+	children  map[string][]*options.Group // Groups nested in each group
+	appearsIn map[string][]*options.Group // Groups with options targeting each group
+}
+
+// newReference nests each group in the first group targeting it.
+func newReference(groups []*options.Group) *reference {
+	ref := &reference{
+		scope:     newTemplateScope(groups...),
+		children:  map[string][]*options.Group{},
+		appearsIn: map[string][]*options.Group{},
+	}
+
+	for _, g := range groups {
+		for _, o := range g.Options {
+			target, ok := ref.scope.groupsByKey[o.TargetGroupName]
+			if !ok || target == g || slices.Contains(ref.appearsIn[target.Key], g) {
+				continue
+			}
+			ref.appearsIn[target.Key] = append(ref.appearsIn[target.Key], g)
+		}
+	}
+
+	nested := map[string]bool{}
+	var nest func(g *options.Group)
+	nest = func(g *options.Group) {
+		nested[g.Key] = true
+		for _, o := range g.Options {
+			target, ok := ref.scope.groupsByKey[o.TargetGroupName]
+			if !ok || nested[target.Key] {
+				continue
+			}
+			ref.children[g.Key] = append(ref.children[g.Key], target)
+			nest(target)
+		}
+	}
+	for _, g := range groups {
+		if len(ref.appearsIn[g.Key]) == 0 && !nested[g.Key] {
+			ref.roots = append(ref.roots, g)
+			nest(g)
+		}
+	}
+	// Groups only targeted in a cycle are documented at the top level
+	for _, g := range groups {
+		if !nested[g.Key] {
+			ref.roots = append(ref.roots, g)
+			nest(g)
+		}
+	}
+
+	return ref
+}
+
+// writeGroup writes the section of a group and its nested groups.
+func (ref *reference) writeGroup(b *strings.Builder, g *options.Group, level int) {
+	b.WriteString("\n" + md.Header(min(level, 6), groupTitle(g)) + "\n")
+
+	if parents := ref.appearsIn[g.Key]; len(parents) > 0 {
+		links := make([]string, 0, len(parents))
+		for _, p := range parents {
+			links = append(links, md.Link(groupTitle(p), md.HeaderLinkTarget(groupTitle(p))))
+		}
+		b.WriteString("\n" + md.Italics("Appears in:") + " " + strings.Join(links, ", ") + "\n")
+	}
+	if g.Description != "" {
+		b.WriteString("\n" + g.Description + "\n")
+	}
+
+	if len(g.Options) > 0 {
+		b.WriteString("\n" + ref.fieldTable(g))
+	}
+
+	for _, o := range g.Options {
+		if o.Long == "" {
+			continue
+		}
+		b.WriteString("\n" + md.Header(min(level+1, 6), fieldName(o)) + "\n\n")
+		b.WriteString(strings.TrimSpace(o.Long) + "\n")
+	}
+
+	for _, child := range ref.children[g.Key] {
+		ref.writeGroup(b, child, level+1)
+	}
+}
+
+// fieldTable produces the table of the group's fields. The flag and environment
+// variable columns are only included if a field has one.
+func (ref *reference) fieldTable(g *options.Group) string {
+	var hasFlag, hasEnv bool
+	for _, o := range g.Options {
+		hasFlag = hasFlag || o.Flag != ""
+		hasEnv = hasEnv || o.Env != ""
+	}
+
+	header := []string{"Field", "Type", "Default"}
+	if hasFlag {
+		header = append(header, "Flag")
+	}
+	if hasEnv {
+		header = append(header, "Env")
+	}
+	header = append(header, "Description")
+
+	rows := make([][]string, 0, len(g.Options))
+	for _, o := range g.Options {
+		row := []string{fieldName(o), ref.fieldType(o), ""}
+		if o.Default != "" {
+			row[2] = md.Code(o.Default)
+		}
+		if len(o.ProfileDefaults) > 0 {
+			row[2] = strings.TrimSpace(row[2] + " (" + formatProfileDefaults(o) + ")")
+		}
+		if hasFlag {
+			flag := ""
+			if o.Flag != "" {
+				flag = md.Code("--" + o.Flag)
+			}
+			row = append(row, flag)
+		}
+		if hasEnv {
+			env := ""
+			if o.Env != "" {
+				env = md.Code(o.Env)
+			}
+			row = append(row, env)
+		}
+		row = append(row, fieldDescription(o))
+		rows = append(rows, row)
+	}
+	return writeTable(header, rows)
+}
+
+// fieldType formats the type of a field, linking to the sections of targeted groups.
+func (ref *reference) fieldType(o *options.Option) string {
+	switch o.Type {
+	case options.Object:
+		return ref.scope.formattedValueType(o)
+	case options.List:
+		return "list of " + ref.scope.formattedValueType(o)
+	case options.StringMap:
+		return "map of string to " + ref.scope.formattedValueType(o)
+	default:
+		return string(o.Type)
+	}
+}
+
+// fieldName formats the name of a field, its path in the configuration file if it has one.
+func fieldName(o *options.Option) string {
+	if o.JSON != "" {
+		return md.Code(o.JSON)
+	}
+	return o.Header()
+}
+
+// fieldDescription formats the description of a field for a table cell.
+func fieldDescription(o *options.Option) string {
+	desc := o.ShortDescription()
+	if len(o.Choices) > 0 {
+		choices := make([]string, 0, len(o.Choices))
+		for _, c := range o.Choices {
+			choices = append(choices, md.Code(c))
+		}
+		desc = strings.TrimSpace(desc + " One of " + strings.Join(choices, ", ") + ".")
+	}
+	if o.Deprecated != "" {
+		desc = strings.TrimSpace(md.Bold("Deprecated:") + " " + o.Deprecated + " " + desc)
+	}
+	// Keep the description in its cell
+	desc = strings.Join(strings.Fields(desc), " ")
+	return strings.ReplaceAll(desc, "|", `\|`)
+}
+
+// groupTitle produces the section title of a group.
+func groupTitle(g *options.Group) string {
+	if g.Title != "" {
+		return g.Title
+	}
+	return g.Key
+}
@@ -0,0 +1,30 @@
+package optionshelp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+func TestReferenceDoc(t *testing.T) {
+	groups := renderTestGroups()
+	groups[0].Options = append(groups[0].Options, &options.Option{
+		Type: options.Choice, Name: "mode", JSON: "server.mode", Flag: "mode", Env: "SERVER_MODE",
+		Choices: []string{"fast", "safe"}, Short: "Serving mode.", Long: "The serving mode.\n\nSafe mode | checks more.",
+	})
+
+	doc, err := ReferenceDoc("Sample Configuration Reference", groups)
+	require.NoError(t, err)
+	assert.Contains(t, doc, "# Sample Configuration Reference\n\n## Server Options\n\nOptions for the server.\n")
+	// Object fields link to the nested group
+	assert.Contains(t, doc, "| `server.tls`  | [TLS Options](#tls-options) |")
+	assert.Contains(t, doc, "| `server.mode` | choice (string)             |             | `--mode` | `SERVER_MODE` | Serving mode. One of `fast`, `safe`. |")
+	// Long descriptions follow the table
+	assert.Contains(t, doc, "### `server.mode`\n\nThe serving mode.\n\nSafe mode | checks more.\n")
+	// Targeted groups are nested, linking to their parents
+	assert.Contains(t, doc, "### TLS Options\n\n_Appears in:_ [Server Options](#server-options)\n")
+	assert.Contains(t, doc, "| `server.tls.cert` | string |         | Certificate file. |")
+}
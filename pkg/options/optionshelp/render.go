@@ -0,0 +1,79 @@
+package optionshelp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cpuguy83/go-md2man/v2/md2man"
+	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/html"
+	"github.com/gomarkdown/markdown/parser"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+// docTitle is the title of the options reference produced by [MarkdownDoc].
+const docTitle = "Configuration Options"
+
+// HTMLDoc produces a standalone HTML page documenting the given options.
+func HTMLDoc(groups []*options.Group) (string, error) {
+	doc, err := MarkdownDoc(groups)
+	if err != nil {
+		return "", err
+	}
+
+	p := parser.NewWithExtensions(parser.CommonExtensions | parser.AutoHeadingIDs | parser.NoEmptyLineBeforeBlock)
+	renderer := html.NewRenderer(html.RendererOptions{
+		Title: docTitle,
+		Flags: html.CommonFlags | html.CompletePage,
+	})
+
+	return string(markdown.Render(p.Parse([]byte(doc)), renderer)), nil
+}
+
+// ManDoc produces a man(5) page documenting the given options.
+//
+// The name is used as the title of the page, such as "ace-dt-config".
+func ManDoc(name string, groups []*options.Group) (string, error) {
+	doc, err := MarkdownDoc(groups)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%% %s 5\n\n", strings.ToUpper(name))
+	fmt.Fprintf(buf, "# NAME\n\n%s \\- %s\n\n", name, strings.ToLower(docTitle))
+	buf.WriteString(manSections(doc))
+
+	return string(md2man.Render([]byte(buf.String()))), nil
+}
+
+// anchorLink matches markdown links to a location in the same document.
+var anchorLink = regexp.MustCompile(`\[([^\]]*)\]\(#[^)]*\)`)
+
+// manSections converts the markdown options reference into manpage sections.
+//
+// The title and table of contents are dropped, each group becomes a section
+// and each option a subsection. Links within the document are replaced by
+// their text, since they cannot be followed in a manpage.
+func manSections(doc string) string {
+	lines := strings.Split(doc, "\n")
+	out := make([]string, 0, len(lines))
+	started, fenced := false, false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			fenced = !fenced
+		}
+		switch {
+		case fenced:
+		case strings.HasPrefix(line, "## "):
+			started = true
+			line = strings.ToUpper(line)
+		}
+		if started {
+			out = append(out, anchorLink.ReplaceAllString(line, "$1"))
+		}
+	}
+	return strings.Join(out, "\n") + "\n"
+}
@@ -0,0 +1,53 @@
+package optionshelp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/options"
+)
+
+func renderTestGroups() []*options.Group {
+	return []*options.Group{
+		{
+			Key:         "server",
+			Title:       "Server Options",
+			Description: "Options for the server.",
+			Options: []*options.Option{
+				{Type: options.Object, Name: "tls", JSON: "server.tls", TargetGroupName: "tls", Short: "TLS settings."},
+				{Type: options.String, Name: "host", JSON: "server.host", Default: "localhost", Short: "Host to listen on."},
+			},
+		},
+		{
+			Key:   "tls",
+			Title: "TLS Options",
+			Options: []*options.Option{
+				{Type: options.String, Name: "cert", JSON: "server.tls.cert", Short: "Certificate file."},
+			},
+		},
+	}
+}
+
+func TestHTMLDoc(t *testing.T) {
+	doc, err := HTMLDoc(renderTestGroups())
+	require.NoError(t, err)
+	assert.Contains(t, doc, "<!DOCTYPE html>")
+	assert.Contains(t, doc, "<title>Configuration Options</title>")
+	assert.Contains(t, doc, `<h2 id="server-options">Server Options</h2>`)
+	// Links to groups resolve to heading IDs
+	assert.Contains(t, doc, `<a href="#tls-options">tls</a>`)
+}
+
+func TestManDoc(t *testing.T) {
+	doc, err := ManDoc("sample-config", renderTestGroups())
+	require.NoError(t, err)
+	assert.Contains(t, doc, ".TH SAMPLE-CONFIG 5")
+	assert.Contains(t, doc, ".SH NAME\nsample-config - configuration options")
+	assert.Contains(t, doc, ".SH SERVER OPTIONS")
+	assert.Contains(t, doc, ".SS host")
+	// Table of contents and links are dropped
+	assert.NotContains(t, doc, "Table of contents")
+	assert.NotContains(t, doc, "#tls-options")
+}
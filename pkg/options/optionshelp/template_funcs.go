@@ -2,7 +2,9 @@ package optionshelp
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -55,15 +57,19 @@ func (scope *templateScope) GroupTable(g *options.Group) string {
 
 	for _, o := range g.Options {
 		header := o.Header()
+		desc := o.ShortDescription()
+		if o.Deprecated != "" {
+			desc = strings.TrimSpace(md.Bold("Deprecated.") + " " + desc)
+		}
 		switch {
 		case o.TargetGroupName != "":
 			group := scope.mustGetGroup(o.TargetGroupName)
 			rows = append(rows, []string{
-				md.Link(header, md.HeaderLinkTarget(group.Title)), o.ShortDescription(),
+				md.Link(header, md.HeaderLinkTarget(group.Title)), desc,
 			})
 		default:
 			rows = append(rows, []string{
-				md.Link(header, md.HeaderLinkTarget(header)), o.ShortDescription(),
+				md.Link(header, md.HeaderLinkTarget(header)), desc,
 			})
 		}
 	}
@@ -119,11 +125,25 @@ func (scope *templateScope) OptionTable(o *options.Option) string {
 			"type", string(o.Type),
 		})
 	}
+	if len(o.Choices) > 0 {
+		choices := make([]string, 0, len(o.Choices))
+		for _, c := range o.Choices {
+			choices = append(choices, md.Code(c))
+		}
+		rows = append(rows, []string{
+			"choices", strings.Join(choices, ", "),
+		})
+	}
 	if o.Default != "" {
 		rows = append(rows, []string{
 			"default", md.Code(o.Default),
 		})
 	}
+	if len(o.ProfileDefaults) > 0 {
+		rows = append(rows, []string{
+			"profile defaults", formatProfileDefaults(o),
+		})
+	}
 	if o.JSON != "" {
 		rows = append(rows, []string{
 			"json/yaml", md.Code(o.JSON),
@@ -148,6 +168,28 @@ func (scope *templateScope) OptionTable(o *options.Option) string {
 			"env", md.Code(o.Env),
 		})
 	}
+	if len(o.RenamedFrom) > 0 {
+		var previous []string
+		for _, r := range o.RenamedFrom {
+			if r.JSON != "" {
+				previous = append(previous, md.Code(r.JSON))
+			}
+			if r.Flag != "" {
+				previous = append(previous, md.Code("--"+r.Flag))
+			}
+			if r.Env != "" {
+				previous = append(previous, md.Code(r.Env))
+			}
+		}
+		rows = append(rows, []string{
+			"renamed from", strings.Join(previous, ", "),
+		})
+	}
+	if o.Deprecated != "" {
+		rows = append(rows, []string{
+			"deprecated", o.Deprecated,
+		})
+	}
 
 	return writeTable(header, rows)
 }
@@ -188,7 +230,7 @@ func (scope *templateScope) targetLink(groupKey string) string {
 func writeTable(header []string, rows [][]string) string {
 	// Get maximum width of each column
 	colMaxLens := make([]int, len(header))
-	for _, row := range rows {
+	for _, row := range append([][]string{header}, rows...) {
 		for col, cell := range row {
 			cellLen := ansi.StringWidth(cell) // ansi-aware string width
 			if cellLen > colMaxLens[col] {
@@ -274,3 +316,13 @@ func empty(given any) bool {
 		return false
 	}
 }
+
+// formatProfileDefaults formats the option's default values for each profile, in sorted order.
+func formatProfileDefaults(o *options.Option) string {
+	profiles := slices.Sorted(maps.Keys(o.ProfileDefaults))
+	defaults := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		defaults = append(defaults, profile+": "+md.Code(o.ProfileDefaults[profile]))
+	}
+	return strings.Join(defaults, ", ")
+}
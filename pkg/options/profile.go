@@ -0,0 +1,83 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// ProfileVar creates the flag selecting the active profile, such as "dev" or "prod".
+// The defaults of options with [Option.ProfileDefaults] for the active profile are
+// applied by [ApplyProfiles].
+//
+// If [Option.Choices] is set, only the listed profiles are accepted.
+//
+// The option's description defaults to a summary of the precedence of profile defaults.
+func ProfileVar(f *pflag.FlagSet, p *string, value string, opts *Option) *pflag.Flag {
+	if opts.Short == "" && opts.FlagUsage == "" {
+		opts.Short = "Profile selecting option defaults, overridden by config files, environment variables, and flags"
+	}
+	var flag *pflag.Flag
+	if len(opts.Choices) > 0 {
+		flag = ChoiceVar(f, p, value, opts)
+	} else {
+		flag = StringVar(f, p, value, opts)
+	}
+	flagutil.MarkProfileFlag(flag)
+	return flag
+}
+
+// ApplyProfiles applies the defaults of the profile selected by the flag created with
+// [ProfileVar], see [ApplyProfile]. Nothing is applied if the flag set has no profile
+// flag or no profile is selected.
+//
+// Call ApplyProfiles after the profile flag's environment variable is parsed, so the
+// profile can be selected by flag or environment variable.
+func ApplyProfiles(f *pflag.FlagSet) error {
+	profileFlag := flagutil.ProfileFlag(f)
+	if profileFlag == nil {
+		return nil
+	}
+	return ApplyProfile(f, profileFlag.Value.String())
+}
+
+// ApplyProfile sets each flag in the flag set to its default value for the profile,
+// if it has one, giving the precedence default < profile < config < env < flag.
+// Flags set by the other sources are not modified.
+//
+// The value source of flags set from the profile is [flagutil.SourceProfile].
+func ApplyProfile(f *pflag.FlagSet, profile string) error {
+	var errs []error
+	f.VisitAll(func(flag *pflag.Flag) {
+		if err := flagutil.ApplyProfile(flag, profile); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// Profiles returns the names of the profiles with defaults for the flags in the flag set, in sorted order.
+func Profiles(f *pflag.FlagSet) []string {
+	profiles := map[string]bool{}
+	f.VisitAll(func(flag *pflag.Flag) {
+		for profile := range flagutil.GetProfileDefaults(flag) {
+			profiles[profile] = true
+		}
+	})
+	return slices.Sorted(maps.Keys(profiles))
+}
+
+// setProfileDefaults records the option's profile defaults on the flag.
+func setProfileDefaults(f *pflag.Flag, opt *Option) {
+	for _, profile := range slices.Sorted(maps.Keys(opt.ProfileDefaults)) {
+		if profile == "" {
+			panic(fmt.Sprintf("option %q: empty profile name", opt.Header()))
+		}
+		flagutil.SetProfileDefault(f, profile, opt.ProfileDefaults[profile])
+	}
+}
@@ -0,0 +1,83 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// Rename records the previous names of a renamed option.
+//
+// Previous names continue to be accepted, with a deprecation warning.
+type Rename struct {
+	JSON string // Previous path to field in JSON config file
+	Env  string // Previous environment variable name
+	Flag string // Previous flag name
+}
+
+// registerRenames registers the previous names of the flag.
+//
+// Previous flag names are registered as deprecated aliases of the flag,
+// previous environment variable names are used by [flagutil.ParseEnvOverrides],
+// and previous JSON paths are used by [BindConfig].
+func registerRenames(flagSet *pflag.FlagSet, f *pflag.Flag, renames []Rename) {
+	if len(renames) == 0 {
+		return
+	}
+	var jsonPaths, envNames, flagNames []string
+	for _, r := range renames {
+		jsonPaths = append(jsonPaths, r.JSON)
+		envNames = append(envNames, r.Env)
+		flagNames = append(flagNames, r.Flag)
+
+		if r.Env != "" {
+			flagutil.AddDeprecatedEnvNames(f, r.Env)
+		}
+		if r.Flag != "" && flagSet.Lookup(r.Flag) == nil {
+			alias := flagSet.VarPF(aliasValue{Value: f.Value, target: f}, r.Flag, "", f.Usage)
+			alias.NoOptDefVal = f.NoOptDefVal
+			_ = flagSet.MarkDeprecated(r.Flag, fmt.Sprintf("use --%s instead", f.Name))
+		}
+	}
+	flagutil.SetAnnotation(f, renamedJSONAnno, jsonPaths...)
+	flagutil.SetAnnotation(f, renamedEnvAnno, envNames...)
+	flagutil.SetAnnotation(f, renamedFlagAnno, flagNames...)
+}
+
+// renamesFromFlag produces the previous names of the flag from its annotations.
+func renamesFromFlag(f *pflag.Flag) []Rename {
+	jsonPaths := f.Annotations[renamedJSONAnno]
+	envNames := f.Annotations[renamedEnvAnno]
+	flagNames := f.Annotations[renamedFlagAnno]
+	if len(jsonPaths) == 0 {
+		return nil
+	}
+	renames := make([]Rename, len(jsonPaths))
+	for i := range renames {
+		renames[i].JSON = jsonPaths[i]
+		if i < len(envNames) {
+			renames[i].Env = envNames[i]
+		}
+		if i < len(flagNames) {
+			renames[i].Flag = flagNames[i]
+		}
+	}
+	return renames
+}
+
+// aliasValue sets the value of the target flag, marking it as changed.
+type aliasValue struct {
+	pflag.Value
+	target *pflag.Flag
+}
+
+// Set implements [pflag.Value].
+func (v aliasValue) Set(s string) error {
+	if err := v.Value.Set(s); err != nil {
+		return err //nolint:wrapcheck
+	}
+	v.target.Changed = true
+	return nil
+}
@@ -0,0 +1,61 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+func TestRenamedFrom(t *testing.T) {
+	newFlagSet := func(p *string) (*pflag.FlagSet, *pflag.Flag) {
+		f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flag := StringVar(f, p, "", &Option{
+			JSON: "registry.host",
+			Env:  "TEST_REGISTRY_HOST",
+			Flag: "registry-host",
+			RenamedFrom: []Rename{
+				{JSON: "host", Env: "TEST_HOST", Flag: "host"},
+			},
+		})
+		return f, flag
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		var host string
+		f, flag := newFlagSet(&host)
+		require.NoError(t, f.Parse([]string{"--host", "example.com"}))
+		assert.Equal(t, "example.com", host)
+		assert.True(t, flag.Changed, "alias marks the renamed flag as changed")
+		assert.True(t, f.Lookup("host").Hidden, "alias is hidden")
+	})
+
+	t.Run("env", func(t *testing.T) {
+		var host string
+		t.Setenv("TEST_HOST", "old.example.com")
+		_, flag := newFlagSet(&host)
+		require.NoError(t, flagutil.ParseEnvOverrides(flag))
+		assert.Equal(t, "old.example.com", host)
+
+		t.Setenv("TEST_REGISTRY_HOST", "new.example.com")
+		_, flag = newFlagSet(&host)
+		require.NoError(t, flagutil.ParseEnvOverrides(flag))
+		assert.Equal(t, "new.example.com", host, "current name takes precedence")
+	})
+
+	t.Run("config", func(t *testing.T) {
+		var host string
+		f, _ := newFlagSet(&host)
+		require.NoError(t, BindConfig(f, map[string]any{"host": "old.example.com"}))
+		assert.Equal(t, "old.example.com", host)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var host string
+		_, flag := newFlagSet(&host)
+		assert.Equal(t, []Rename{{JSON: "host", Env: "TEST_HOST", Flag: "host"}}, FromFlag(flag).RenamedFrom)
+	})
+}
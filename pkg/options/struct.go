@@ -0,0 +1,273 @@
+package options
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrNotStruct is returned by [FromStruct] when given a value that is not a struct.
+var ErrNotStruct = errors.New("value is not a struct")
+
+// Struct tags read by [FromStruct].
+const (
+	tagJSON      = "json"      // JSON field name, "-" to skip the field
+	tagEnv       = "env"       // Environment variable name, "-" for none
+	tagFlag      = "flag"      // Flag name, "-" for none
+	tagShorthand = "shorthand" // Flag shorthand
+	tagDesc      = "desc"      // Short description
+	tagDefault   = "default"   // Default value, overrides the field's current value
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	metav1DurationType  = reflect.TypeFor[metav1.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// FromStruct builds option groups by reflecting over a configuration struct.
+//
+// The first group documents the fields of v. Each nested struct field is documented
+// as an [Object] option targeting an additional group for the nested struct's fields.
+//
+// Option fields are derived from each exported field:
+//   - JSON: the "json" tag name (or the lowerCamel field name), joined to parent paths and prefix.JSON with "."
+//   - Env: the SCREAMING_SNAKE JSON path, joined to prefix.Env with "_", or the "env" tag
+//   - Flag: the kebab-case JSON path, joined to prefix.Flag with "-", or the "flag" tag
+//   - FlagShorthand: the "shorthand" tag
+//   - Short: the "desc" tag
+//   - Default: the "default" tag, or the field's value in v if it is not the zero value
+//
+// A tag value of "-" disables the corresponding JSON path, environment variable, or flag.
+//
+// Types implementing [encoding.TextUnmarshaler], such as [time.Time], are documented
+// as strings. Fields of a recursive type target the group of the enclosing struct of
+// that type instead of nesting another group.
+func FromStruct(v any, prefix Prefix) ([]*Group, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv = reflect.Zero(rv.Type().Elem())
+			continue
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T: %w", v, ErrNotStruct)
+	}
+
+	b := &structBuilder{visiting: map[reflect.Type]*Group{}}
+	root := &Group{
+		Key:   strcase.ToKebab(rv.Type().Name()),
+		Title: rv.Type().Name(),
+		JSON:  prefix.JSON,
+	}
+	b.groups = append(b.groups, root)
+	if err := b.addFields(root, rv, prefix); err != nil {
+		return nil, err
+	}
+	return b.groups, nil
+}
+
+// structBuilder collects groups while walking a struct.
+type structBuilder struct {
+	groups   []*Group
+	visiting map[reflect.Type]*Group // Groups of the struct types being walked, to stop at recursive types
+}
+
+// addFields adds the options for the fields of the struct value to the group.
+func (b *structBuilder) addFields(g *Group, rv reflect.Value, prefix Prefix) error {
+	rt := rv.Type()
+	b.visiting[rt] = g
+	defer delete(b.visiting, rt)
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		value := rv.Field(i)
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get(tagJSON), ",")
+		if jsonName == "-" {
+			continue
+		}
+		// Inline embedded structs without a JSON name, matching encoding/json
+		if field.Anonymous && jsonName == "" && derefType(field.Type).Kind() == reflect.Struct && !isTextType(derefType(field.Type)) {
+			if _, ok := b.visiting[derefType(field.Type)]; ok {
+				continue
+			}
+			if err := b.addFields(g, derefValue(value), prefix); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = strcase.ToLowerCamel(field.Name)
+		}
+
+		opt := &Option{
+			JSON:          joinNonEmpty(".", prefix.JSON, jsonName),
+			Short:         field.Tag.Get(tagDesc),
+			FlagShorthand: field.Tag.Get(tagShorthand),
+		}
+		fieldPrefix := Prefix{
+			JSON: opt.JSON,
+			Env:  joinNonEmpty("_", prefix.Env, strcase.ToScreamingSnake(jsonName)),
+			Flag: joinNonEmpty("-", prefix.Flag, strcase.ToKebab(jsonName)),
+		}
+
+		ft := derefType(field.Type)
+		switch {
+		case ft == metav1DurationType || ft == durationType:
+			opt.Type = Duration
+		case isTextType(ft):
+			opt.Type = String
+		case ft.Kind() == reflect.Struct && b.visiting[ft] != nil:
+			// Target the group of the recursive type instead of nesting it again
+			opt.Type = Object
+			opt.TargetGroupName = b.visiting[ft].Key
+			g.Options = append(g.Options, opt)
+			continue
+		case ft.Kind() == reflect.Struct:
+			// Document nested structs in their own group
+			child := &Group{
+				Key:         opt.JSON,
+				Title:       field.Name,
+				Description: opt.Short,
+				JSON:        opt.JSON,
+			}
+			b.groups = append(b.groups, child)
+			if err := b.addFields(child, derefValue(value), fieldPrefix); err != nil {
+				return err
+			}
+			opt.Type = Object
+			opt.TargetGroupName = child.Key
+			g.Options = append(g.Options, opt)
+			continue
+		case ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array:
+			opt.Type = List
+			opt.ValueType = kindType(derefType(ft.Elem()))
+		case ft.Kind() == reflect.Map:
+			if ft.Key().Kind() != reflect.String {
+				return fmt.Errorf("field %s.%s: map keys must be strings", rt.Name(), field.Name)
+			}
+			opt.Type = StringMap
+			opt.ValueType = kindType(derefType(ft.Elem()))
+		default:
+			opt.Type = kindType(ft)
+			if opt.Type == "" {
+				return fmt.Errorf("field %s.%s: unsupported type %s", rt.Name(), field.Name, field.Type)
+			}
+		}
+
+		opt.Env = tagOr(field, tagEnv, fieldPrefix.Env)
+		opt.Flag = tagOr(field, tagFlag, fieldPrefix.Flag)
+		if def, ok := field.Tag.Lookup(tagDefault); ok {
+			opt.Default = def
+		} else {
+			opt.Default = formatDefault(value)
+		}
+		g.Options = append(g.Options, opt)
+	}
+	return nil
+}
+
+// kindType returns the option type for a scalar type.
+func kindType(t reflect.Type) Type {
+	if t == durationType || t == metav1DurationType {
+		return Duration
+	}
+	if isTextType(t) {
+		return String
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return String
+	case reflect.Bool:
+		return Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Integer
+	case reflect.Float32, reflect.Float64:
+		return Float
+	case reflect.Struct:
+		return Object
+	default:
+		return ""
+	}
+}
+
+// formatDefault formats the field value as a default, returning an empty string for zero values.
+func formatDefault(v reflect.Value) string {
+	v = derefValue(v)
+	if !v.IsValid() || v.IsZero() || !v.CanInterface() {
+		return ""
+	}
+	switch val := v.Interface().(type) {
+	case metav1.Duration:
+		return val.Duration.String()
+	case encoding.TextMarshaler:
+		text, err := val.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// tagOr returns the value of the struct tag, or def if unset.
+// Returns an empty string if the tag is "-".
+func tagOr(field reflect.StructField, key, def string) string {
+	v, ok := field.Tag.Lookup(key)
+	switch {
+	case !ok || v == "":
+		return def
+	case v == "-":
+		return ""
+	default:
+		return v
+	}
+}
+
+// joinNonEmpty joins the non-empty elements with sep.
+func joinNonEmpty(sep string, elems ...string) string {
+	nonEmpty := make([]string, 0, len(elems))
+	for _, e := range elems {
+		if e != "" {
+			nonEmpty = append(nonEmpty, strings.TrimSuffix(e, sep))
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
+
+// isTextType reports whether values of the type are decoded from text, such as [time.Time].
+func isTextType(t reflect.Type) bool {
+	return t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// derefType dereferences pointer types.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// derefValue dereferences pointers, producing a zero value for nil pointers.
+func derefValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,90 @@
+package options
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServerConfig struct {
+	Host    string        `json:"host" desc:"Host to listen on."`
+	Port    int           `json:"port" shorthand:"p"`
+	Timeout time.Duration `json:"timeout"`
+}
+
+type testConfig struct {
+	Name    string            `json:"name" desc:"Your name." env:"CUSTOM_NAME"`
+	Verbose bool              `json:"verbose" flag:"-"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Server  *testServerConfig `json:"server" desc:"Server options."`
+	Ignored string            `json:"-"`
+}
+
+func TestFromStruct(t *testing.T) {
+	cfg := &testConfig{
+		Name:   "world",
+		Server: &testServerConfig{Port: 8080},
+	}
+	groups, err := FromStruct(cfg, Prefix{Env: "ACE_TEST", Flag: ""})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	root := groups[0]
+	assert.Equal(t, "test-config", root.Key)
+	assert.Equal(t, []*Option{
+		{Type: String, JSON: "name", Env: "CUSTOM_NAME", Flag: "name", Short: "Your name.", Default: "world"},
+		{Type: Boolean, JSON: "verbose", Env: "ACE_TEST_VERBOSE"},
+		{Type: List, ValueType: String, JSON: "tags", Env: "ACE_TEST_TAGS", Flag: "tags"},
+		{Type: StringMap, ValueType: String, JSON: "labels", Env: "ACE_TEST_LABELS", Flag: "labels"},
+		{Type: Object, TargetGroupName: "server", JSON: "server", Short: "Server options."},
+	}, root.Options)
+
+	server := groups[1]
+	assert.Equal(t, "server", server.Key)
+	assert.Equal(t, "Server options.", server.Description)
+	assert.Equal(t, []*Option{
+		{Type: String, JSON: "server.host", Env: "ACE_TEST_SERVER_HOST", Flag: "server-host", Short: "Host to listen on."},
+		{Type: Integer, JSON: "server.port", Env: "ACE_TEST_SERVER_PORT", Flag: "server-port", FlagShorthand: "p", Default: "8080"},
+		{Type: Duration, JSON: "server.timeout", Env: "ACE_TEST_SERVER_TIMEOUT", Flag: "server-timeout"},
+	}, server.Options)
+
+	_, err = FromStruct("not a struct", Prefix{})
+	assert.ErrorIs(t, err, ErrNotStruct)
+}
+
+type testNode struct {
+	Name     string     `json:"name"`
+	Next     *testNode  `json:"next"`
+	Children []testNode `json:"children"`
+}
+
+type testSchedule struct {
+	Start   time.Time  `json:"start"`
+	Address netip.Addr `json:"address"`
+	Node    testNode   `json:"node"`
+}
+
+func TestFromStruct_leafAndRecursiveTypes(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	groups, err := FromStruct(&testSchedule{Start: start}, Prefix{})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, []*Option{
+		{Type: String, JSON: "start", Env: "START", Flag: "start", Default: "2024-01-02T03:04:05Z"},
+		{Type: String, JSON: "address", Env: "ADDRESS", Flag: "address"},
+		{Type: Object, TargetGroupName: "node", JSON: "node"},
+	}, groups[0].Options)
+
+	node := groups[1]
+	assert.Equal(t, "node", node.Key)
+	assert.Equal(t, []*Option{
+		{Type: String, JSON: "node.name", Env: "NODE_NAME", Flag: "node-name"},
+		{Type: Object, TargetGroupName: "node", JSON: "node.next"},
+		{Type: List, ValueType: Object, JSON: "node.children", Env: "NODE_CHILDREN", Flag: "node-children"},
+	}, node.Options)
+}
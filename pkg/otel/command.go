@@ -0,0 +1,78 @@
+package otel
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/act3-ai/go-common/pkg/errdefs"
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+	"github.com/act3-ai/go-common/pkg/secret"
+)
+
+// instrumentationName identifies the instrumentation scope of spans created by this package.
+const instrumentationName = "github.com/act3-ai/go-common/pkg/otel"
+
+// redactedValue replaces the values of sensitive flags in span attributes.
+const redactedValue = "[REDACTED]"
+
+// Span attribute keys for executed commands.
+const (
+	commandPathKey   = attribute.Key("cli.command.path") // Full path of the executed command
+	commandFlagsPref = "cli.flag."                       // Prefix of attributes for flags set by the user
+)
+
+// ExitCoder is implemented by errors that determine the process exit code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the process exit code for the error returned by a command:
+// 0 for nil, the exit code of an [ExitCoder] in the chain, or 1 otherwise.
+// It is equivalent to [errdefs.ExitCode].
+func ExitCode(err error) int {
+	return errdefs.ExitCode(err)
+}
+
+// executeWithSpan executes the command in a span named after the executed command's path.
+//
+// The span is started before the command runs, so its context is available to all
+// command hooks, including PersistentPreRun and PersistentPostRun.
+func executeWithSpan(ctx context.Context, cmd *cobra.Command) error {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, cmd.Name(),
+		trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	executed, err := cmd.ExecuteContextC(ctx)
+	if executed != nil {
+		span.SetName(executed.CommandPath())
+		span.SetAttributes(commandPathKey.String(executed.CommandPath()))
+		span.SetAttributes(flagAttributes(executed.Flags())...)
+	}
+	span.SetAttributes(semconv.ProcessExitCode(ExitCode(err)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// flagAttributes produces span attributes for the flags that were set,
+// redacting the values of sensitive flags.
+func flagAttributes(flags *pflag.FlagSet) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	flags.Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if flagutil.IsSensitive(f) || f.Value.Type() == secret.Secret {
+			value = redactedValue
+		}
+		attrs = append(attrs, attribute.String(commandFlagsPref+f.Name, value))
+	})
+	return attrs
+}
@@ -0,0 +1,59 @@
+package otel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+func TestExecuteWithSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var preRunSpan trace.SpanContext
+	root := &cobra.Command{
+		Use: "root",
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			preRunSpan = trace.SpanContextFromContext(cmd.Context())
+		},
+	}
+	sub := &cobra.Command{
+		Use: "sub",
+		RunE: func(*cobra.Command, []string) error {
+			return errors.New("failed")
+		},
+	}
+	sub.Flags().String("name", "", "")
+	sub.Flags().String("token", "", "")
+	flagutil.MarkSensitive(sub.Flags().Lookup("token"))
+	root.AddCommand(sub)
+	root.SilenceErrors, root.SilenceUsage = true, true
+	root.SetArgs([]string{"sub", "--name", "example", "--token", "hunter2"})
+
+	require.Error(t, executeWithSpan(t.Context(), root))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "root sub", span.Name())
+	assert.Equal(t, span.SpanContext(), preRunSpan, "span context is passed to hooks")
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Subset(t, span.Attributes(), []attribute.KeyValue{
+		attribute.String("cli.flag.name", "example"),
+		attribute.String("cli.flag.token", redactedValue),
+		attribute.Int("process.exit.code", 1),
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"time"
 
 	"go.opentelemetry.io/contrib/exporters/autoexport"
@@ -14,6 +16,8 @@ import (
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/act3-ai/go-common/pkg/redact"
 )
 
 // Config configures the initialization of OpenTelemetry. Typically configuration
@@ -32,14 +36,43 @@ type Config struct {
 	// MetricReaders are readers that collect metric data.
 	MetricReaders []sdkmetric.Reader
 
+	// Redactor redacts span attributes before they are passed to the span
+	// processors' OnEnd, and therefore before export. Spans are not redacted if nil.
+	Redactor *redact.Redactor
+
 	// Resource is the resource describing this component and runtime
 	// environment.
 	Resource *resource.Resource
 
+	// LocalExporter writes all signals to stderr or local files, for offline use.
+	// Set to "stderr" to pretty-print signals, or "file:<directory>" to write
+	// JSON lines files to the directory. If empty, the OTEL_EXPORTER environment
+	// variable is used unless DisableEnvConfiguration is set.
+	LocalExporter string
+
+	// LocalFileMaxSize is the size in bytes at which local exporter files are rotated,
+	// defaults to DefaultLocalFileMaxSize.
+	LocalFileMaxSize int64
+
+	// LocalFileMaxBackups is the number of rotated local exporter files to keep,
+	// defaults to DefaultLocalFileMaxBackups.
+	LocalFileMaxBackups int
+
+	// ProfileDir is a directory where CPU and heap profiles of the executed command are
+	// written, named after its command path and trace ID. If empty, the ACE_PROFILE_DIR
+	// environment variable is used unless DisableEnvConfiguration is set. See [AddProfileFlags].
+	ProfileDir string
+
+	// PprofAddr is the listen address of an HTTP server serving pprof endpoints while
+	// the command runs, such as "localhost:6060". If empty, the ACE_PPROF_ADDR
+	// environment variable is used unless DisableEnvConfiguration is set.
+	PprofAddr string
+
 	traceProvider *sdktrace.TracerProvider
 	logProvider   *sdklog.LoggerProvider
 	meterProvider *sdkmetric.MeterProvider
 	propagator    propagation.TextMapPropagator
+	closers       []io.Closer
 }
 
 // Init sets up the global OpenTelemetry providers for tracing, logging, and
@@ -66,6 +99,15 @@ func (c *Config) Init(ctx context.Context) (context.Context, error) {
 		if err := c.configureFromEnvironment(ctx); err != nil {
 			return nil, fmt.Errorf("configuring exporters from environment: %w", err)
 		}
+		if c.LocalExporter == "" {
+			c.LocalExporter = os.Getenv("OTEL_EXPORTER")
+		}
+	}
+
+	if c.LocalExporter != "" {
+		if err := c.configureLocalExporter(c.LocalExporter); err != nil {
+			return nil, fmt.Errorf("configuring local exporter: %w", err)
+		}
 	}
 
 	if len(c.SpanProcessors) > 0 {
@@ -73,6 +115,9 @@ func (c *Config) Init(ctx context.Context) (context.Context, error) {
 		traceOpts = append(traceOpts, sdktrace.WithResource(c.Resource))
 
 		for _, sp := range c.SpanProcessors {
+			if c.Redactor != nil {
+				sp = newRedactSpanProcessor(sp, c.Redactor)
+			}
 			traceOpts = append(traceOpts, sdktrace.WithSpanProcessor(sp))
 		}
 
@@ -131,6 +176,12 @@ func (c *Config) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	for _, closer := range c.closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing local exporter file: %w", err))
+		}
+	}
+
 	return errors.Join(errs...) //nolint:wrapcheck
 }
 
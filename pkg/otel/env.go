@@ -1,9 +1,13 @@
 package otel
 
 import (
+	"context"
 	"os"
+	"os/exec"
+	"slices"
 	"strings"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 )
 
@@ -56,3 +60,33 @@ func (c *EnvCarrier) Keys() []string {
 	}
 	return keys
 }
+
+// Env returns the trace context of ctx as environment variables, suitable for
+// a child process that extracts its trace context with an [EnvCarrier].
+func Env(ctx context.Context) []string {
+	carrier := NewEnvCarrier(false)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier.Env
+}
+
+// InjectEnv adds the trace context of ctx to the environment of cmd, so
+// the trace continues in the child process. If cmd.Env is nil, the current
+// process's environment is used as the base, matching [exec.Cmd] behavior.
+// Trace context inherited from the current process is replaced.
+func InjectEnv(ctx context.Context, cmd *exec.Cmd) {
+	env := Env(ctx)
+	if len(env) == 0 {
+		return
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+
+	carrier := &EnvCarrier{Env: env}
+	keys := carrier.Keys()
+	cmd.Env = slices.DeleteFunc(cmd.Env, func(e string) bool {
+		name, _, _ := strings.Cut(e, "=")
+		return slices.Contains(keys, strings.ToUpper(name))
+	})
+	cmd.Env = append(cmd.Env, env...)
+}
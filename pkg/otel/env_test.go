@@ -0,0 +1,39 @@
+package otel
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectEnv(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+
+	env := Env(ctx)
+	require.Len(t, env, 1)
+	assert.Contains(t, env[0], "TRACEPARENT=00-"+span.SpanContext().TraceID().String())
+
+	cmd := exec.Command("true")
+	cmd.Env = []string{"FOO=bar", "TRACEPARENT=stale"}
+	InjectEnv(ctx, cmd)
+	assert.Equal(t, []string{"FOO=bar", env[0]}, cmd.Env)
+
+	// the child extracts the same trace
+	child := propagation.TraceContext{}.Extract(context.Background(), &EnvCarrier{Env: cmd.Env})
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(child).TraceID())
+
+	// no span, no changes
+	cmd = exec.Command("true")
+	InjectEnv(context.Background(), cmd)
+	assert.Nil(t, cmd.Env)
+}
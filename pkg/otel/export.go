@@ -0,0 +1,189 @@
+package otel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ErrInvalidLocalExporter is returned for unsupported local exporter values.
+var ErrInvalidLocalExporter = errors.New(`invalid local exporter, want "stderr" or "file:<directory>"`)
+
+// Local exporter values for [Config.LocalExporter] and the OTEL_EXPORTER environment variable.
+const (
+	LocalExporterStderr = "stderr" // Pretty-printed to stderr
+	LocalExporterFile   = "file:"  // JSON lines files in a directory, as "file:/path/to/dir"
+)
+
+// Default rotation settings for local exporter files.
+const (
+	DefaultLocalFileMaxSize    = 10 << 20 // 10 MiB
+	DefaultLocalFileMaxBackups = 3
+)
+
+// Names of the files written by the file local exporter.
+const (
+	localTracesFile  = "traces.jsonl"
+	localLogsFile    = "logs.jsonl"
+	localMetricsFile = "metrics.jsonl"
+)
+
+// configureLocalExporter creates span, log, and metric exporters writing to stderr or local files.
+func (c *Config) configureLocalExporter(exporter string) error {
+	var tracesOut, logsOut, metricsOut io.Writer
+	var pretty bool
+	switch {
+	case exporter == LocalExporterStderr:
+		tracesOut, logsOut, metricsOut = os.Stderr, os.Stderr, os.Stderr
+		pretty = true
+	case strings.HasPrefix(exporter, LocalExporterFile):
+		dir := strings.TrimPrefix(exporter, LocalExporterFile)
+		if dir == "" {
+			return ErrInvalidLocalExporter
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating telemetry directory: %w", err)
+		}
+		maxSize, maxBackups := c.LocalFileMaxSize, c.LocalFileMaxBackups
+		if maxSize == 0 {
+			maxSize = DefaultLocalFileMaxSize
+		}
+		if maxBackups == 0 {
+			maxBackups = DefaultLocalFileMaxBackups
+		}
+		files := make([]*rotatingFile, 0, 3)
+		for _, name := range []string{localTracesFile, localLogsFile, localMetricsFile} {
+			f, err := newRotatingFile(filepath.Join(dir, name), maxSize, maxBackups)
+			if err != nil {
+				return err
+			}
+			files = append(files, f)
+			c.closers = append(c.closers, f)
+		}
+		tracesOut, logsOut, metricsOut = files[0], files[1], files[2]
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidLocalExporter, exporter)
+	}
+
+	traceOpts := []stdouttrace.Option{stdouttrace.WithWriter(tracesOut)}
+	logOpts := []stdoutlog.Option{stdoutlog.WithWriter(logsOut)}
+	metricOpts := []stdoutmetric.Option{stdoutmetric.WithWriter(metricsOut)}
+	if pretty {
+		traceOpts = append(traceOpts, stdouttrace.WithPrettyPrint())
+		logOpts = append(logOpts, stdoutlog.WithPrettyPrint())
+		metricOpts = append(metricOpts, stdoutmetric.WithPrettyPrint())
+	}
+
+	spanExp, err := stdouttrace.New(traceOpts...)
+	if err != nil {
+		return fmt.Errorf("creating local span exporter: %w", err)
+	}
+	c.SpanProcessors = append(c.SpanProcessors, sdktrace.NewBatchSpanProcessor(spanExp))
+
+	logExp, err := stdoutlog.New(logOpts...)
+	if err != nil {
+		return fmt.Errorf("creating local log exporter: %w", err)
+	}
+	c.LogProcessors = append(c.LogProcessors, sdklog.NewBatchProcessor(logExp))
+
+	metricExp, err := stdoutmetric.New(metricOpts...)
+	if err != nil {
+		return fmt.Errorf("creating local metric exporter: %w", err)
+	}
+	c.MetricReaders = append(c.MetricReaders, sdkmetric.NewPeriodicReader(metricExp))
+
+	return nil
+}
+
+// rotatingFile is a file that is rotated when it exceeds a maximum size.
+//
+// Rotated files are renamed with a numeric suffix, such as "traces.jsonl.1",
+// keeping at most maxBackups previous files.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// newRotatingFile opens the file for appending.
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the file for appending.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening telemetry file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("opening telemetry file: %w", err)
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// Write implements [io.Writer].
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err //nolint:wrapcheck
+}
+
+// rotate shifts the previous files and starts a new file.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("closing telemetry file: %w", err)
+	}
+	_ = os.Remove(f.path + "." + strconv.Itoa(f.maxBackups))
+	for i := f.maxBackups - 1; i > 0; i-- {
+		_ = os.Rename(f.path+"."+strconv.Itoa(i), f.path+"."+strconv.Itoa(i+1))
+	}
+	if f.maxBackups > 0 {
+		if err := os.Rename(f.path, f.path+".1"); err != nil {
+			return fmt.Errorf("rotating telemetry file: %w", err)
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return fmt.Errorf("rotating telemetry file: %w", err)
+	}
+	return f.open()
+}
+
+// Close implements [io.Closer].
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close() //nolint:wrapcheck
+}
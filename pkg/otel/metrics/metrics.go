@@ -0,0 +1,178 @@
+// Package metrics provides OpenTelemetry instruments for common CLI and server measurements.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/act3-ai/go-common/pkg/httputil"
+)
+
+// instrumentationName identifies the instrumentation scope of the instruments.
+const instrumentationName = "github.com/act3-ai/go-common/pkg/otel/metrics"
+
+// Attribute keys used by the instruments.
+const (
+	commandPathKey    = attribute.Key("cli.command.path")
+	exitCodeKey       = attribute.Key("process.exit.code")
+	httpMethodKey     = attribute.Key("http.request.method")
+	httpRouteKey      = attribute.Key("http.route")
+	httpStatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Runtime metrics read by the process gauges.
+const (
+	runtimeMemoryMetric     = "/memory/classes/total:bytes"
+	runtimeGoroutinesMetric = "/sched/goroutines:goroutines"
+)
+
+// Config configures the instruments created by [Setup].
+type Config struct {
+	// MeterProvider creates the instruments, defaults to the global MeterProvider
+	// configured by [otel.Config.Init].
+	MeterProvider metric.MeterProvider
+
+	// DisableProcessMetrics disables the process memory and goroutine gauges.
+	DisableProcessMetrics bool
+}
+
+// Instruments are the pre-declared instruments for CLI and server measurements.
+type Instruments struct {
+	// CommandDuration is a histogram of command durations in seconds.
+	CommandDuration metric.Float64Histogram
+
+	// HTTPRequests counts HTTP requests handled by the server.
+	HTTPRequests metric.Int64Counter
+
+	// HTTPDuration is a histogram of HTTP request durations in seconds.
+	HTTPDuration metric.Float64Histogram
+}
+
+// Setup creates the instruments with the configured MeterProvider, and registers
+// gauges for the process's memory and goroutines.
+//
+// Example:
+//
+//	inst, err := metrics.Setup(metrics.Config{})
+//	if err != nil {
+//		return err
+//	}
+//	handler := httputil.WrapHandler(mux, inst.HTTPMiddleware)
+func Setup(cfg Config) (*Instruments, error) {
+	provider := cfg.MeterProvider
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+	meter := provider.Meter(instrumentationName)
+
+	var errs []error
+	inst := &Instruments{}
+	var err error
+
+	inst.CommandDuration, err = meter.Float64Histogram("cli.command.duration",
+		metric.WithDescription("Duration of commands."),
+		metric.WithUnit("s"))
+	errs = append(errs, err)
+
+	inst.HTTPRequests, err = meter.Int64Counter("http.server.requests",
+		metric.WithDescription("Number of HTTP requests handled by the server."),
+		metric.WithUnit("{request}"))
+	errs = append(errs, err)
+
+	inst.HTTPDuration, err = meter.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP requests handled by the server."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
+	errs = append(errs, err)
+
+	if !cfg.DisableProcessMetrics {
+		errs = append(errs, registerProcessMetrics(meter))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("creating instruments: %w", err)
+	}
+	return inst, nil
+}
+
+// registerProcessMetrics registers gauges reading the Go runtime's memory and goroutine metrics.
+func registerProcessMetrics(meter metric.Meter) error {
+	memory, err := meter.Int64ObservableGauge("process.runtime.go.memory",
+		metric.WithDescription("Memory mapped by the Go runtime, an approximation of the resident set size."),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	goroutines, err := meter.Int64ObservableGauge("process.runtime.go.goroutines",
+		metric.WithDescription("Number of live goroutines."),
+		metric.WithUnit("{goroutine}"))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		samples := []metrics.Sample{
+			{Name: runtimeMemoryMetric},
+			{Name: runtimeGoroutinesMetric},
+		}
+		metrics.Read(samples)
+		for _, s := range samples {
+			if s.Value.Kind() != metrics.KindUint64 {
+				continue
+			}
+			switch s.Name {
+			case runtimeMemoryMetric:
+				o.ObserveInt64(memory, int64(s.Value.Uint64()))
+			case runtimeGoroutinesMetric:
+				o.ObserveInt64(goroutines, int64(s.Value.Uint64()))
+			}
+		}
+		return nil
+	}, memory, goroutines)
+	return err //nolint:wrapcheck
+}
+
+// RecordCommand records the duration of a command and its exit code.
+//
+// Example:
+//
+//	start := time.Now()
+//	err := root.ExecuteContext(ctx)
+//	inst.RecordCommand(ctx, root.CommandPath(), time.Since(start), otel.ExitCode(err))
+func (inst *Instruments) RecordCommand(ctx context.Context, commandPath string, duration time.Duration, exitCode int) {
+	inst.CommandDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		commandPathKey.String(commandPath),
+		exitCodeKey.Int(exitCode),
+	))
+}
+
+// HTTPMiddleware records the count and duration of HTTP requests, by method, route, and status code.
+func (inst *Instruments) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := httputil.NewResponseRecorder(w)
+		// call the next handler
+		next.ServeHTTP(rec, r)
+
+		// This must be done after calling next.ServeHTTP()
+		route := strings.TrimPrefix(r.Pattern, r.Method+" ")
+		attrs := metric.WithAttributes(
+			httpMethodKey.String(r.Method),
+			httpRouteKey.String(route),
+			httpStatusCodeKey.Int(rec.Status),
+		)
+		inst.HTTPRequests.Add(r.Context(), 1, attrs)
+		inst.HTTPDuration.Record(r.Context(), time.Since(start).Seconds(), attrs)
+	})
+}
+
+var _ httputil.MiddlewareFunc = (*Instruments)(nil).HTTPMiddleware
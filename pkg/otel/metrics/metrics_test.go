@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	out := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			out[m.Name] = m
+		}
+	}
+	return out
+}
+
+func TestSetup(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	inst, err := Setup(Config{MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))})
+	require.NoError(t, err)
+
+	inst.RecordCommand(context.Background(), "sample version", 250*time.Millisecond, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	rec := httptest.NewRecorder()
+	inst.HTTPMiddleware(mux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+
+	got := collect(t, reader)
+	assert.Contains(t, got, "cli.command.duration")
+	assert.Contains(t, got, "http.server.request.duration")
+	assert.Contains(t, got, "process.runtime.go.memory")
+	assert.Contains(t, got, "process.runtime.go.goroutines")
+
+	requests, ok := got["http.server.requests"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, requests.DataPoints, 1)
+	dp := requests.DataPoints[0]
+	assert.Equal(t, int64(1), dp.Value)
+	route, _ := dp.Attributes.Value(httpRouteKey)
+	assert.Equal(t, "/items/{id}", route.AsString())
+	status, _ := dp.Attributes.Value(httpStatusCodeKey)
+	assert.Equal(t, int64(http.StatusTeapot), status.AsInt64())
+}
+
+func TestSetupDisableProcessMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	_, err := Setup(Config{
+		MeterProvider:         sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		DisableProcessMetrics: true,
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, collect(t, reader), "process.runtime.go.goroutines")
+}
@@ -0,0 +1,220 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	httppprof "net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/act3-ai/go-common/pkg/options/flagutil"
+)
+
+// Environment variables enabling profiling, used when the corresponding [Config] field
+// is empty unless DisableEnvConfiguration is set. They are outside the OTEL_ namespace,
+// which is reserved for variables defined by the OpenTelemetry specification.
+const (
+	ProfileDirEnv = "ACE_PROFILE_DIR" // Directory for CPU and heap profiles
+	PprofAddrEnv  = "ACE_PPROF_ADDR"  // Listen address of the pprof HTTP server
+)
+
+// AddProfileFlags adds the persistent flags "--profile-dir" and "--pprof-addr" to the
+// root command, setting the profiling options of cfg. The flags default to the
+// ACE_PROFILE_DIR and ACE_PPROF_ADDR environment variables.
+//
+// Profiling is opt-in and starts once the flags are parsed, so the profiles cover
+// the executed command's hooks and action. See [Run].
+func AddProfileFlags(cmd *cobra.Command, cfg *Config) {
+	f := cmd.PersistentFlags()
+	f.StringVar(&cfg.ProfileDir, "profile-dir", "", "Write CPU and heap profiles of the command to the directory")
+	flagutil.SetEnvName(f.Lookup("profile-dir"), ProfileDirEnv)
+	f.StringVar(&cfg.PprofAddr, "pprof-addr", "", `Serve pprof endpoints at the address while the command runs, such as "localhost:6060"`)
+	flagutil.SetEnvName(f.Lookup("pprof-addr"), PprofAddrEnv)
+}
+
+// profiler profiles the execution of a command.
+type profiler struct {
+	cfg *Config
+	out io.Writer // reports the paths of profiles
+
+	started bool
+	stopped bool
+	prefix  string // path prefix of profile files
+	cpu     *os.File
+	server  *http.Server
+}
+
+// profileOptions resolves the profiling options of the configuration.
+func (c *Config) profileOptions() (dir, addr string) {
+	dir, addr = c.ProfileDir, c.PprofAddr
+	if !c.DisableEnvConfiguration {
+		if dir == "" {
+			dir = os.Getenv(ProfileDirEnv)
+		}
+		if addr == "" {
+			addr = os.Getenv(PprofAddrEnv)
+		}
+	}
+	return dir, addr
+}
+
+// register starts profiling the executed command in the root command's PersistentPreRunE,
+// after its flags are parsed. It returns a function restoring the root command's hooks.
+func (p *profiler) register(root *cobra.Command) (restore func()) {
+	preRunE := root.PersistentPreRunE
+	preRun := root.PersistentPreRun
+	root.PersistentPreRun = nil
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		p.start(cmd.Context(), cmd.CommandPath())
+		switch {
+		case preRunE != nil:
+			return preRunE(cmd, args)
+		case preRun != nil:
+			preRun(cmd, args)
+		}
+		return nil
+	}
+	return func() {
+		root.PersistentPreRunE = preRunE
+		root.PersistentPreRun = preRun
+	}
+}
+
+// start starts profiling the command, if enabled by the configuration. Profile files
+// are named after the command path and the trace of ctx, so they can be correlated with
+// the exported telemetry.
+//
+// Profiling errors are not fatal, they are logged as warnings.
+func (p *profiler) start(ctx context.Context, commandPath string) {
+	if p.started || p.stopped {
+		return
+	}
+	p.started = true
+
+	dir, addr := p.cfg.profileOptions()
+	if addr != "" {
+		if err := p.serve(ctx, addr); err != nil {
+			slog.WarnContext(ctx, "Starting pprof server failed", "error", err)
+		}
+	}
+	if dir != "" {
+		if err := p.startCPU(ctx, dir, commandPath); err != nil {
+			slog.WarnContext(ctx, "Starting CPU profile failed", "error", err)
+		}
+	}
+}
+
+// serve starts an HTTP server serving the pprof endpoints.
+func (p *profiler) serve(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+
+	p.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := p.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.WarnContext(ctx, "pprof server failed", "error", err)
+		}
+	}()
+
+	_, _ = fmt.Fprintf(p.out, "Serving pprof endpoints at http://%s/debug/pprof/\n", ln.Addr())
+	return nil
+}
+
+// startCPU starts the CPU profile.
+func (p *profiler) startCPU(ctx context.Context, dir, commandPath string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating profile directory: %w", err)
+	}
+	p.prefix = filepath.Join(dir, profileName(ctx, commandPath))
+
+	f, err := os.Create(p.prefix + ".cpu.pprof")
+	if err != nil {
+		return fmt.Errorf("creating CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("starting CPU profile: %w", err)
+	}
+	p.cpu = f
+	return nil
+}
+
+// stop stops profiling, writes the heap profile, and reports the paths of the profiles.
+func (p *profiler) stop(ctx context.Context) {
+	p.stopped = true
+	if !p.started {
+		return
+	}
+
+	if p.server != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := p.server.Shutdown(shutdownCtx); err != nil {
+			slog.WarnContext(ctx, "Stopping pprof server failed", "error", err)
+		}
+	}
+
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		if err := p.cpu.Close(); err != nil {
+			slog.WarnContext(ctx, "Writing CPU profile failed", "error", err)
+		} else {
+			_, _ = fmt.Fprintf(p.out, "Wrote CPU profile: %s\n", p.cpu.Name())
+		}
+
+		if path, err := writeHeapProfile(p.prefix + ".heap.pprof"); err != nil {
+			slog.WarnContext(ctx, "Writing heap profile failed", "error", err)
+		} else {
+			_, _ = fmt.Fprintf(p.out, "Wrote heap profile: %s\n", path)
+		}
+	}
+}
+
+// writeHeapProfile writes a heap profile to the path.
+func writeHeapProfile(path string) (string, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("creating heap profile: %w", err)
+	}
+
+	runtime.GC() // get up-to-date statistics
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("writing heap profile: %w", err)
+	}
+	return path, f.Close() //nolint:wrapcheck
+}
+
+// profileName produces the base name of profile files: the command path, with spaces
+// replaced by underscores, followed by the trace ID of ctx, or by the time and process
+// ID without a trace.
+func profileName(ctx context.Context, commandPath string) string {
+	name := strings.ReplaceAll(commandPath, " ", "_")
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return name + "-" + sc.TraceID().String()
+	}
+	return name + "-" + time.Now().Format("20060102T150405") + "-" + strconv.Itoa(os.Getpid())
+}
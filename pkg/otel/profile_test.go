@@ -0,0 +1,95 @@
+package otel
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRunProfile(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_ENABLED", "true")
+	recorder := tracetest.NewSpanRecorder()
+	cfg := &Config{
+		DisableEnvConfiguration: true,
+		SpanProcessors:          []sdktrace.SpanProcessor{recorder},
+	}
+
+	root := &cobra.Command{
+		Use: "root",
+		Run: func(*cobra.Command, []string) {},
+	}
+	AddProfileFlags(root, cfg)
+	stderr := new(bytes.Buffer)
+	root.SetErr(stderr)
+
+	dir := t.TempDir()
+	root.SetArgs([]string{"--profile-dir", dir})
+	require.NoError(t, Run(t.Context(), root, cfg, ""))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	prefix := filepath.Join(dir, "root-"+spans[0].SpanContext().TraceID().String())
+	assert.FileExists(t, prefix+".cpu.pprof")
+	assert.FileExists(t, prefix+".heap.pprof")
+	assert.Equal(t, "Wrote CPU profile: "+prefix+".cpu.pprof\nWrote heap profile: "+prefix+".heap.pprof\n", stderr.String())
+}
+
+func TestRunProfileSubcommand(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_ENABLED", "true")
+	recorder := tracetest.NewSpanRecorder()
+	cfg := &Config{
+		DisableEnvConfiguration: true,
+		SpanProcessors:          []sdktrace.SpanProcessor{recorder},
+	}
+
+	var preRunCalled bool
+	root := &cobra.Command{
+		Use: "root",
+		PersistentPreRun: func(*cobra.Command, []string) {
+			preRunCalled = true
+		},
+	}
+	sub := &cobra.Command{
+		Use: "sub",
+		Run: func(*cobra.Command, []string) {},
+	}
+	root.AddCommand(sub)
+	AddProfileFlags(root, cfg)
+	root.SetErr(new(bytes.Buffer))
+
+	dir := t.TempDir()
+	root.SetArgs([]string{"sub", "--profile-dir", dir})
+	require.NoError(t, Run(t.Context(), root, cfg, ""))
+	assert.True(t, preRunCalled, "root hook runs")
+	assert.NotNil(t, root.PersistentPreRun, "root hook restored")
+	assert.Nil(t, root.PersistentPreRunE, "root hook restored")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	prefix := filepath.Join(dir, "root_sub-"+spans[0].SpanContext().TraceID().String())
+	assert.FileExists(t, prefix+".cpu.pprof")
+	assert.FileExists(t, prefix+".heap.pprof")
+}
+
+func TestRunProfileDisabled(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{DisableEnvConfiguration: true}
+	root := &cobra.Command{
+		Use: "root",
+		Run: func(*cobra.Command, []string) {},
+	}
+	root.SetArgs(nil)
+	t.Setenv(ProfileDirEnv, dir)
+	require.NoError(t, Run(t.Context(), root, cfg, ""))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "environment is ignored")
+}
@@ -0,0 +1,61 @@
+package otel
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/act3-ai/go-common/pkg/redact"
+)
+
+// redactSpanProcessor redacts the attributes of ended spans before passing them to a processor.
+type redactSpanProcessor struct {
+	sdktrace.SpanProcessor
+	redactor *redact.Redactor
+}
+
+// newRedactSpanProcessor wraps sp to redact span attributes with redactor.
+func newRedactSpanProcessor(sp sdktrace.SpanProcessor, redactor *redact.Redactor) sdktrace.SpanProcessor {
+	return &redactSpanProcessor{SpanProcessor: sp, redactor: redactor}
+}
+
+// OnEnd implements [sdktrace.SpanProcessor].
+func (p *redactSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.SpanProcessor.OnEnd(&redactedSpan{
+		ReadOnlySpan: s,
+		attrs:        redactAttributes(p.redactor, s.Attributes()),
+	})
+}
+
+// redactedSpan is a span with redacted attributes.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs []attribute.KeyValue
+}
+
+// Attributes implements [sdktrace.ReadOnlySpan].
+func (s *redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+// redactAttributes returns a copy of attrs with sensitive values redacted.
+func redactAttributes(redactor *redact.Redactor, attrs []attribute.KeyValue) []attribute.KeyValue {
+	redacted := make([]attribute.KeyValue, len(attrs))
+	for i, kv := range attrs {
+		key := string(kv.Key)
+		switch {
+		case redactor.SensitiveKey(key):
+			redacted[i] = kv.Key.String(redact.Redacted)
+		case kv.Value.Type() == attribute.STRING:
+			redacted[i] = kv.Key.String(redactor.String(kv.Value.AsString()))
+		case kv.Value.Type() == attribute.STRINGSLICE:
+			values := kv.Value.AsStringSlice()
+			for j, v := range values {
+				values[j] = redactor.String(v)
+			}
+			redacted[i] = kv.Key.StringSlice(values)
+		default:
+			redacted[i] = kv
+		}
+	}
+	return redacted
+}
@@ -14,7 +14,26 @@ import (
 
 // Run will run the root level cobra command, with logging and with the provided
 // OpenTelemetry configuration.
+//
+// When instrumentation is enabled, the executed command runs in a span named after
+// the command's path, with attributes for the flags that were set (redacting sensitive
+// flags) and the process exit code (see [ExitCode]). The span's context is passed
+// to all of the command's hooks.
+//
+// When profiling is enabled by the configuration (see [Config.ProfileDir] and
+// [Config.PprofAddr]), the executed command is profiled from the root command's
+// PersistentPreRunE until it exits, and the paths of the written profiles are reported
+// on stderr. Subcommands that set their own persistent pre-run hooks are not profiled.
 func Run(ctx context.Context, cmd *cobra.Command, cfg *Config, verbosityEnvName string) error {
+	if cfg == nil {
+		cfg = &Config{} // ensure to check for environment configuration
+	}
+
+	// Start profiling after the flags are parsed, so profiling flags are respected
+	prof := &profiler{cfg: cfg, out: cmd.ErrOrStderr()}
+	defer prof.register(cmd)()
+	defer prof.stop(ctx)
+
 	if env.BoolOr("OTEL_INSTRUMENTATION_ENABLED", false) {
 		// Run root command with OTel instrumentation enabled.
 		return run(ctx, cmd, cfg, verbosityEnvName)
@@ -23,9 +42,6 @@ func Run(ctx context.Context, cmd *cobra.Command, cfg *Config, verbosityEnvName
 }
 
 func run(ctx context.Context, cmd *cobra.Command, cfg *Config, verbosityEnvName string) error {
-	if cfg == nil {
-		cfg = &Config{} // ensure to check for environment configuration
-	}
 	var err error
 	ctx, err = cfg.Init(ctx)
 	if err != nil {
@@ -46,5 +62,5 @@ func run(ctx context.Context, cmd *cobra.Command, cfg *Config, verbosityEnvName
 	ctx = logger.NewContext(ctx, log)
 
 	// errors from cfg.Shutdown() are not fatal so we just log them
-	return cmd.ExecuteContext(ctx)
+	return executeWithSpan(ctx, cmd)
 }
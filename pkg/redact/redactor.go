@@ -0,0 +1,141 @@
+package redact
+
+import (
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultKeys are the key patterns redacted by [Default].
+var DefaultKeys = []string{
+	"password", "passwd", "secret", "token", "authorization",
+	"apikey", "api_key", "api-key", "credential", "cookie",
+}
+
+// Value detectors for common credential formats, replaced wherever they appear in a string.
+var (
+	// JWT matches JSON Web Tokens.
+	JWT = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	// BearerToken matches bearer tokens in authorization values.
+	BearerToken = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`)
+	// AWSAccessKey matches AWS access key IDs.
+	AWSAccessKey = regexp.MustCompile(`\b(AKIA|ASIA)[A-Z0-9]{16}\b`)
+)
+
+// DefaultDetectors are the value detectors used by [Default].
+var DefaultDetectors = []*regexp.Regexp{JWT, BearerToken, AWSAccessKey}
+
+// Default is a redactor with the default key patterns and value detectors.
+var Default = &Redactor{Keys: DefaultKeys, Detectors: DefaultDetectors}
+
+// Redactor replaces sensitive data with [Redacted].
+// Values are sensitive if their key contains one of the key patterns
+// (case-insensitive), and substrings of any value matching a detector are
+// replaced.
+type Redactor struct {
+	// Keys are substrings of the keys of sensitive values.
+	Keys []string
+
+	// Detectors match sensitive substrings of values.
+	Detectors []*regexp.Regexp
+}
+
+// SensitiveKey reports whether key matches any of the key patterns.
+func (r *Redactor) SensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(r.Keys, func(k string) bool {
+		return strings.Contains(key, strings.ToLower(k))
+	})
+}
+
+// String replaces the substrings of s matching any of the detectors.
+func (r *Redactor) String(s string) string {
+	for _, d := range r.Detectors {
+		s = d.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// Value redacts the value of key, entirely if the key is sensitive.
+func (r *Redactor) Value(key, value string) string {
+	if r.SensitiveKey(key) {
+		return String(value)
+	}
+	return r.String(value)
+}
+
+// Map returns a copy of m with its values redacted.
+func (r *Redactor) Map(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(m))
+	for k, v := range m {
+		redacted[k] = r.Value(k, v)
+	}
+	return redacted
+}
+
+// Headers returns a copy of h with its values redacted.
+func (r *Redactor) Headers(h http.Header) http.Header {
+	return http.Header(r.multiMap(h))
+}
+
+// Values returns a copy of the query parameters or form values with their values redacted.
+func (r *Redactor) Values(v url.Values) url.Values {
+	return url.Values(r.multiMap(v))
+}
+
+// multiMap returns a copy of m with its values redacted.
+func (r *Redactor) multiMap(m map[string][]string) map[string][]string {
+	if m == nil {
+		return nil
+	}
+	redacted := maps.Clone(m)
+	for k, values := range redacted {
+		values = slices.Clone(values)
+		for i, v := range values {
+			values[i] = r.Value(k, v)
+		}
+		redacted[k] = values
+	}
+	return redacted
+}
+
+// URL removes the password from the URL and redacts its query parameters.
+func (r *Redactor) URL(s string) string {
+	if s == "" {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return "[Invalid URL]"
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = r.Values(u.Query()).Encode()
+	}
+	return u.Redacted()
+}
+
+// Attr redacts the value of a log attribute, recursing into groups.
+func (r *Redactor) Attr(a slog.Attr) slog.Attr {
+	if r.SensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(r.String(a.Value.String()))
+	case slog.KindGroup:
+		members := slices.Clone(a.Value.Group())
+		for i, member := range members {
+			members[i] = r.Attr(member)
+		}
+		a.Value = slog.GroupValue(members...)
+	}
+	return a
+}
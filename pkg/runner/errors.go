@@ -0,0 +1,29 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/act3-ai/go-common/pkg/errdefs"
+	"github.com/act3-ai/go-common/pkg/termdoc"
+)
+
+// HandleError prints the hint of the error returned by a command to w, if it
+// has one (see [errdefs.Hint]), and returns the process exit code for the error.
+// The error itself is printed by cobra unless the command silences errors.
+//
+// Example:
+//
+//	func main() {
+//		if err := runner.Run(ctx, root, "ACE_SAMPLE_VERBOSITY"); err != nil {
+//			os.Exit(runner.HandleError(os.Stderr, err))
+//		}
+//	}
+func HandleError(w io.Writer, err error) int {
+	if hint := errdefs.Hint(err); hint != "" {
+		hint = termdoc.AutoMarkdownFormat().Format(strings.TrimSpace(hint))
+		fmt.Fprintln(w, "Hint: "+strings.TrimSuffix(hint, "\n"))
+	}
+	return errdefs.ExitCode(err)
+}
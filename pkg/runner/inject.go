@@ -0,0 +1,138 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// Errors returned by [From].
+var (
+	ErrNoProvider    = errors.New("no provider for dependency")  // Dependency was not provided
+	ErrNilDependency = errors.New("provider returned nil value") // Provider of an interface type returned nil
+)
+
+// provider lazily creates a dependency once.
+type provider struct {
+	once  sync.Once
+	fn    func(ctx context.Context) (any, error)
+	value any
+	err   error
+}
+
+// get returns the dependency, creating it on the first call.
+func (p *provider) get(ctx context.Context) (any, error) {
+	p.once.Do(func() {
+		p.value, p.err = p.fn(ctx)
+	})
+	return p.value, p.err
+}
+
+// dependencies are the providers registered for a command, by type.
+type dependencies map[reflect.Type]*provider
+
+// contextDependenciesKey is how we find the dependencies in a context.Context.
+type contextDependenciesKey struct{}
+
+// Provide registers a function that creates a dependency of type T, for
+// example a client or a configuration loader. The function is called once,
+// when [From] is first called for T, so it can use flag values and the
+// context of the executed command.
+//
+// Example:
+//
+//	runner.Main(ctx, newRoot,
+//		runner.Provide(func(ctx context.Context) (*registry.Client, error) {
+//			return registry.NewClient(opts.Registry)
+//		}),
+//	)
+//
+// and in a command:
+//
+//	client, err := runner.From[*registry.Client](cmd.Context())
+func Provide[T any](fn func(ctx context.Context) (T, error)) Option {
+	return func(c *mainConfig) {
+		if c.deps == nil {
+			c.deps = dependencies{}
+		}
+		c.deps[reflect.TypeFor[T]()] = &provider{fn: func(ctx context.Context) (any, error) {
+			return fn(ctx)
+		}}
+	}
+}
+
+// Supply registers a dependency value of type T, e.g. a fake in tests.
+func Supply[T any](value T) Option {
+	return Provide(func(context.Context) (T, error) {
+		return value, nil
+	})
+}
+
+// ProvideConfig registers a function that loads the configuration of type T,
+// retrieved with [ConfigFrom].
+func ProvideConfig[T any](load func(ctx context.Context) (*T, error)) Option {
+	return Provide(load)
+}
+
+// WithDependencies returns a context with the dependencies registered by the
+// options, in addition to those already in ctx. Options other than [Provide],
+// [Supply], and [ProvideConfig] are ignored. [Main] does this for the
+// command's context, so it is only needed to execute commands directly, as in
+// unit tests:
+//
+//	ctx := runner.WithDependencies(t.Context(), runner.Supply[Store](fakeStore{}))
+//	err := cmd.ExecuteContext(ctx)
+func WithDependencies(ctx context.Context, opts ...Option) context.Context {
+	cfg := &mainConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return withDependencies(ctx, cfg.deps)
+}
+
+// withDependencies returns a context with deps added to those in ctx.
+func withDependencies(ctx context.Context, deps dependencies) context.Context {
+	if len(deps) == 0 {
+		return ctx
+	}
+	merged := dependencies{}
+	if parent, ok := ctx.Value(contextDependenciesKey{}).(dependencies); ok {
+		maps.Copy(merged, parent)
+	}
+	maps.Copy(merged, deps)
+	return context.WithValue(ctx, contextDependenciesKey{}, merged)
+}
+
+// From returns the dependency of type T registered in the context,
+// creating it if needed. It returns [ErrNoProvider] if T was not provided and
+// [ErrNilDependency] if T is an interface and its provider returned nil.
+//
+// The dependency is created once, with the context of the first call to From,
+// and shared by later calls. Providers should not retain values of that
+// context, such as its cancellation, in the dependency.
+func From[T any](ctx context.Context) (T, error) {
+	var zero T
+	t := reflect.TypeFor[T]()
+	deps, _ := ctx.Value(contextDependenciesKey{}).(dependencies)
+	p, ok := deps[t]
+	if !ok {
+		return zero, fmt.Errorf("%w %s", ErrNoProvider, t)
+	}
+	v, err := p.get(ctx)
+	if err != nil {
+		return zero, fmt.Errorf("creating %s: %w", t, err)
+	}
+	value, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrNilDependency, t)
+	}
+	return value, nil
+}
+
+// ConfigFrom returns the configuration of type T registered with [ProvideConfig].
+func ConfigFrom[T any](ctx context.Context) (*T, error) {
+	return From[*T](ctx)
+}
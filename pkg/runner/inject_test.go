@@ -0,0 +1,115 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/act3-ai/go-common/pkg/runner"
+)
+
+// Store is a dependency of the command under test.
+type Store interface {
+	Get(key string) (string, error)
+}
+
+// fakeStore is a Store injected in tests.
+type fakeStore map[string]string
+
+func (s fakeStore) Get(key string) (string, error) {
+	v, ok := s[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return v, nil
+}
+
+// newGetCmd creates a command printing the value of a key from the Store.
+func newGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "get KEY",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := runner.From[Store](cmd.Context())
+			if err != nil {
+				return err
+			}
+			v, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			cmd.Print(v)
+			return nil
+		},
+	}
+}
+
+func TestFrom(t *testing.T) {
+	cmd := newGetCmd()
+	out := &strings.Builder{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"greeting"})
+
+	ctx := runner.WithDependencies(t.Context(), runner.Supply[Store](fakeStore{"greeting": "hello"}))
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	assert.Equal(t, "hello", out.String())
+}
+
+func TestFromProvide(t *testing.T) {
+	calls := 0
+	ctx := runner.WithDependencies(t.Context(), runner.Provide(func(context.Context) (Store, error) {
+		calls++
+		return fakeStore{}, nil
+	}))
+
+	first, err := runner.From[Store](ctx)
+	require.NoError(t, err)
+	second, err := runner.From[Store](ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, calls, "dependency is created once")
+}
+
+func TestFromErrors(t *testing.T) {
+	_, err := runner.From[Store](t.Context())
+	require.ErrorIs(t, err, runner.ErrNoProvider)
+
+	ctx := runner.WithDependencies(t.Context(), runner.Supply[io.Writer](nil))
+	_, err = runner.From[io.Writer](ctx)
+	require.ErrorIs(t, err, runner.ErrNilDependency)
+
+	failure := errors.New("connection refused")
+	ctx = runner.WithDependencies(t.Context(), runner.Provide(func(context.Context) (Store, error) {
+		return nil, failure
+	}))
+	_, err = runner.From[Store](ctx)
+	require.ErrorIs(t, err, failure)
+}
+
+func TestWithDependencies(t *testing.T) {
+	type Config struct{ Name string }
+
+	parent := runner.WithDependencies(t.Context(),
+		runner.Supply[Store](fakeStore{"key": "parent"}),
+		runner.ProvideConfig(func(context.Context) (*Config, error) {
+			return &Config{Name: "sample"}, nil
+		}),
+	)
+	ctx := runner.WithDependencies(parent, runner.Supply[Store](fakeStore{"key": "child"}))
+
+	store, err := runner.From[Store](ctx)
+	require.NoError(t, err)
+	v, err := store.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "child", v, "dependencies override those in the parent context")
+
+	cfg, err := runner.ConfigFrom[Config](ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "sample", cfg.Name, "dependencies of the parent context are kept")
+}
@@ -0,0 +1,206 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/errdefs"
+)
+
+// ExitInterrupted is the exit code used when a second signal forces the program to exit.
+const ExitInterrupted = 130
+
+// DefaultShutdownTimeout bounds the time spent in shutdown functions.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// Option configures [Main].
+type Option func(*mainConfig)
+
+type mainConfig struct {
+	verbosityEnvName string
+	execute          func(ctx context.Context, cmd *cobra.Command) error
+	shutdown         []func(ctx context.Context) error
+	shutdownTimeout  time.Duration
+	signals          []os.Signal
+	deps             dependencies
+}
+
+// WithVerbosityEnv sets the environment variable used to set the logging
+// verbosity by the default executor, see [Run]. Defaults to the ACE_<NAME>_VERBOSITY
+// convention, with the root command's name in upper case, e.g. "ACE_SAMPLE_VERBOSITY".
+func WithVerbosityEnv(name string) Option {
+	return func(c *mainConfig) {
+		c.verbosityEnvName = name
+	}
+}
+
+// WithExecutor replaces [Run] as the function that executes the root command,
+// e.g. to use otel.Run:
+//
+//	runner.WithExecutor(func(ctx context.Context, root *cobra.Command) error {
+//		return otel.Run(ctx, root, otelCfg, verbosityEnvName)
+//	})
+func WithExecutor(execute func(ctx context.Context, cmd *cobra.Command) error) Option {
+	return func(c *mainConfig) {
+		c.execute = execute
+	}
+}
+
+// WithShutdown adds a function called after the command exits, even if it
+// fails or panics. Shutdown functions are called in reverse order with a
+// context that is not canceled by signals, but bounded by the shutdown timeout.
+func WithShutdown(fn func(ctx context.Context) error) Option {
+	return func(c *mainConfig) {
+		c.shutdown = append(c.shutdown, fn)
+	}
+}
+
+// WithShutdownTimeout sets the timeout for shutdown functions, defaults to [DefaultShutdownTimeout].
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(c *mainConfig) {
+		c.shutdownTimeout = timeout
+	}
+}
+
+// WithSignals sets the signals that cancel the command's context,
+// defaults to SIGINT and SIGTERM.
+func WithSignals(signals ...os.Signal) Option {
+	return func(c *mainConfig) {
+		c.signals = signals
+	}
+}
+
+// Main creates the root command with newRoot and executes it, returning the
+// process exit code. It is intended to be the entire body of a main function:
+//
+//	func main() {
+//		os.Exit(runner.Main(context.Background(), newRootCmd))
+//	}
+//
+// The first SIGINT or SIGTERM cancels the command's context, so it can stop
+// gracefully, and a second one exits immediately with [ExitInterrupted].
+// Dependencies registered with [Provide] are available to commands with [From].
+// Panics are recovered as internal errors. Errors are printed with their
+// hints and mapped to exit codes by [HandleError].
+func Main(ctx context.Context, newRoot func() (*cobra.Command, error), opts ...Option) int {
+	cfg := &mainConfig{
+		shutdownTimeout: DefaultShutdownTimeout,
+		signals:         []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.execute == nil {
+		cfg.execute = func(ctx context.Context, cmd *cobra.Command) error {
+			name := cfg.verbosityEnvName
+			if name == "" {
+				name = defaultVerbosityEnv(cmd.Name())
+			}
+			return Run(ctx, cmd, name)
+		}
+	}
+
+	ctx = withDependencies(ctx, cfg.deps)
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := handleSignals(ctx, cancel, cfg.signals)
+	defer stop()
+
+	root, err := execute(ctx, newRoot, cfg.execute)
+	err = errors.Join(err, cfg.runShutdown(ctx))
+
+	if err == nil {
+		return 0
+	}
+	var stderr io.Writer = os.Stderr
+	if root != nil {
+		stderr = root.ErrOrStderr()
+	}
+	fmt.Fprintln(stderr, "Error:", err)
+	return HandleError(stderr, err)
+}
+
+// defaultVerbosityEnv returns the ACE_<NAME>_VERBOSITY environment variable for a command name.
+func defaultVerbosityEnv(name string) string {
+	return "ACE_" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name) + "_VERBOSITY"
+}
+
+// execute creates and executes the root command, recovering panics.
+func execute(ctx context.Context, newRoot func() (*cobra.Command, error),
+	run func(ctx context.Context, cmd *cobra.Command) error,
+) (root *cobra.Command, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errdefs.Internal(fmt.Errorf("panic: %v\n\n%s", r, debug.Stack()),
+				"This is a bug, please report it with the output above.")
+		}
+	}()
+
+	root, err = newRoot()
+	if err != nil {
+		return nil, fmt.Errorf("creating command: %w", err)
+	}
+	// errors are printed by Main, with their hints
+	root.SilenceErrors = true
+	return root, run(ctx, root)
+}
+
+// runShutdown calls the shutdown functions in reverse order.
+func (cfg *mainConfig) runShutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.shutdownTimeout)
+	defer cancel()
+	var errs []error
+	for _, fn := range slices.Backward(cfg.shutdown) {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// handleSignals cancels ctx on the first signal and exits on the second.
+// The returned function stops handling signals.
+func handleSignals(ctx context.Context, cancel context.CancelCauseFunc, signals []os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		return func() {}
+	}
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, signals...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigs:
+			slog.InfoContext(ctx, "Received signal, shutting down, repeat to exit immediately", slog.String("signal", sig.String()))
+			cancel(fmt.Errorf("received signal %s", sig))
+		case <-done:
+			return
+		}
+		select {
+		case <-sigs:
+			os.Exit(ExitInterrupted)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
@@ -2,13 +2,18 @@ package codefmt
 
 // Location describes the current location of text in a document.
 type Location struct {
-	LineComment bool // In a line comment
+	LineComment  bool // In a line comment
+	Continuation bool // Line continues the previous line's command
+	Heredoc      bool // In the body of a heredoc
 	// MultilineComment bool   // In a multiline comment
 }
 
 // LangInfo defines basic language information needed for parsing.
 type LangInfo struct {
-	LineCommentStart string // Starts line comments
+	LineCommentStart string   // Starts line comments
+	LineContinuation string   // Ends a line that is continued on the next line
+	Heredocs         bool     // Supports heredocs, such as "<<EOF"
+	Prompts          []string // Prompt markers that may begin a command, such as "$ "
 	// MultilineCommentStart string // Starts multiline comments
 	// MultilineCommentEnd   string // Ends multiline comments
 }
@@ -17,6 +22,9 @@ type LangInfo struct {
 var (
 	Bash = LangInfo{
 		LineCommentStart: "#",
+		LineContinuation: `\`,
+		Heredocs:         true,
+		Prompts:          []string{"$ "},
 	}
 
 	Go = LangInfo{
@@ -30,6 +38,7 @@ var (
 type Formatter struct {
 	Comment func(comment string, loc Location) string // reformats inline code blocks
 	Code    func(code string, loc Location) string    // reformats inline code blocks
+	Prompt  func(prompt string, loc Location) string  // reformats prompt markers
 	Indent  func(loc Location) string                 // produces indent for a line's location
 
 	// produce column width for wrapping
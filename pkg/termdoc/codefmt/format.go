@@ -1,12 +1,20 @@
 package codefmt
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
 )
 
+// heredocRegex matches the start of a heredoc, such as <<EOF, <<-EOF, or <<'EOF' (but not a <<< herestring).
+var heredocRegex = regexp.MustCompile(`(?:^|[^<])<<-?\s*(['"]?)([A-Za-z_][A-Za-z0-9_]*)['"]?`)
+
 // Format formats markdown text according the Formatter's rules.
+//
+// Lines are formatted with awareness of the lines before them: lines continued
+// with the language's LineContinuation and heredoc bodies are not parsed for
+// comments, and wrapped lines of a command are indented past its prompt marker.
 func (format *Formatter) Format(codeText string, lang LangInfo) string {
 	cols := 0
 	if format.Columns != nil {
@@ -16,64 +24,127 @@ func (format *Formatter) Format(codeText string, lang LangInfo) string {
 	lines := strings.Split(codeText, "\n")
 	formatted := make([]string, 0, len(lines))
 	var loc Location
+	heredocEnd := ""   // delimiter ending the current heredoc
+	commandIndent := 0 // wrapping indent of the current command's first line
 	for _, line := range lines {
-		lineComment := strings.Index(line, lang.LineCommentStart)
-		// lcBefore, lcAfter, lcFound := strings.Cut(line, lang.LineCommentStart)
+		indent := extraIndent(line)
+
+		var prompt, code, comment string
 		switch {
-		// Format line comment
-		// case lcFound:
-		case lineComment != -1:
-			// Format code before comment
-			lcBefore := line[:lineComment]
-			if format.Code != nil {
-				lcBefore = format.Code(lcBefore, Location{LineComment: false})
-			}
-			// Format comment
-			lcAfter := line[lineComment:]
-			if format.Comment != nil {
-				lcAfter = format.Comment(lcAfter, Location{LineComment: true})
-			}
-			// Reassemble the line
-			line = lcBefore + lcAfter
-		// Format code line
+		// Heredoc bodies are passed through as code
+		case loc.Heredoc:
+			code = line
 		default:
-			if format.Code != nil {
-				line = format.Code(line, Location{LineComment: false})
+			code = line
+			if !loc.Continuation {
+				prompt, code = cutPrompt(line, indent, lang.Prompts)
+				commandIndent = len(prompt)
+			}
+			if i := commentIndex(code, lang.LineCommentStart); i != -1 {
+				code, comment = code[:i], code[i:]
 			}
 		}
 
+		rawCode := code
+
+		// Format the pieces of the line
+		if prompt != "" && format.Prompt != nil {
+			prompt = indent + format.Prompt(strings.TrimPrefix(prompt, indent), loc)
+		}
+		if code != "" && format.Code != nil {
+			code = format.Code(code, Location{Continuation: loc.Continuation, Heredoc: loc.Heredoc})
+		}
+		if comment != "" && format.Comment != nil {
+			comment = format.Comment(comment, Location{LineComment: true, Continuation: loc.Continuation})
+		}
+		formattedLine := prompt + code + comment
+
 		// Add formatter-defined indent:
 		if format.Indent != nil {
-			line = format.Indent(loc) + line
+			formattedLine = format.Indent(loc) + formattedLine
 		}
 
 		// Perform word wrapping:
 		if cols > 0 {
 			// Obey wrapping mode
-			var indent string
+			var wrapIndent string
 			switch format.WrapMode {
 			// No wrapping indent
 			case WrapToStartingIndentation:
-				indent = ""
-			// Preserve leading whitespace in the line
-			// Must be determined from the line itself
+				wrapIndent = ""
+			// Preserve leading whitespace in the line, aligning
+			// wrapped commands past their prompt markers
 			default:
-				indent = extraIndent(line)
+				wrapIndent = indent
+				if !loc.Heredoc && len(wrapIndent) < commandIndent {
+					wrapIndent += strings.Repeat(" ", commandIndent-len(wrapIndent))
+				}
 			}
-			// Preserve leading whitespace in the line
-			// Must be determined from the line itself
 			// Wrap lines
-			line = ansi.Wordwrap(line, cols, " ")
+			formattedLine = ansi.Wordwrap(formattedLine, cols, " ")
 			// Add indent to wrapped lines
-			line = strings.ReplaceAll(line, "\n", "\n"+indent)
+			formattedLine = strings.ReplaceAll(formattedLine, "\n", "\n"+wrapIndent)
 		}
 
-		formatted = append(formatted, line)
+		formatted = append(formatted, formattedLine)
+
+		// Determine the location of the next line
+		switch {
+		case loc.Heredoc:
+			if strings.TrimSpace(line) == heredocEnd {
+				loc.Heredoc = false
+				heredocEnd = ""
+			}
+		case lang.Heredocs && heredocRegex.MatchString(rawCode):
+			loc.Heredoc = true
+			heredocEnd = heredocRegex.FindStringSubmatch(rawCode)[2]
+		}
+		loc.Continuation = !loc.Heredoc && comment == "" && lang.LineContinuation != "" &&
+			strings.HasSuffix(strings.TrimRight(line, " \t"), lang.LineContinuation)
 	}
 
 	return strings.Join(formatted, "\n")
 }
 
+// cutPrompt separates a prompt marker from the start of a line.
+// The returned prompt includes the line's indent.
+func cutPrompt(line, indent string, prompts []string) (prompt, rest string) {
+	for _, p := range prompts {
+		if after, ok := strings.CutPrefix(line[len(indent):], p); ok {
+			return indent + p, after
+		}
+	}
+	return "", line
+}
+
+// commentIndex returns the index of the line comment in the line, or -1.
+//
+// Comments must begin the line or follow whitespace, and may not be quoted.
+func commentIndex(line, commentStart string) int {
+	if commentStart == "" {
+		return -1
+	}
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++ // skip escaped character
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\\':
+			i++ // skip escaped character
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case strings.HasPrefix(line[i:], commentStart) && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return i
+		}
+	}
+	return -1
+}
+
 func extraIndent(s string) string {
 	if strings.HasPrefix(s, " ") {
 		return " " + extraIndent(strings.TrimPrefix(s, " "))
@@ -42,7 +42,9 @@ func AutoMarkdownFormat() *mdfmt.Formatter {
 		},
 		CodeBlock: func(code string, loc mdfmt.Location) string {
 			switch loc.CodeBlockLang {
-			case "bash", "sh", "python":
+			case "bash", "sh", "shell", "console":
+				return codeFormatter.Format(code, codefmt.Bash)
+			case "python":
 				return codeFormatter.Format(code, codefmt.LangInfo{
 					LineCommentStart: "#",
 				})
@@ -92,6 +94,9 @@ func AutoCodeFormat() *codefmt.Formatter {
 		Comment: func(comment string, loc codefmt.Location) string {
 			return ansiFaint().Styled(comment)
 		},
+		Prompt: func(prompt string, loc codefmt.Location) string {
+			return ansiFaint().Styled(prompt)
+		},
 		Columns: func() int {
 			return columnsVal
 		},
@@ -1,22 +1,11 @@
 package mdfmt
 
 import (
-	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
 )
 
-// Markdown component regexes
-var (
-	mdBoldUndRegex   = regexp.MustCompile(wordUnd(`__([^_]+)__`))                   // __bold__
-	mdBoldAstRegex   = regexp.MustCompile(wordAst(`\*\*([^\*]+)\*\*`))              // **bold**
-	mdItalicUndRegex = regexp.MustCompile(wordUnd(`_([^_]+)_`))                     // _italic_
-	mdItalicAstRegex = regexp.MustCompile(wordAst(`\*([^\*]+)\*`))                  // *italic*
-	mdCodeRegex      = regexp.MustCompile("`[^`]+`")                                // `code`
-	mdLinkRegex      = regexp.MustCompile(`\[(?P<text>[^\]]+)\]\((?<url>[^\)]+)\)`) // [text](url)
-)
-
 const (
 	codeBlockStart = "```"
 	commentStart   = "<!--"
@@ -24,14 +13,6 @@ const (
 	tableStart     = "|"
 )
 
-func wordAst(re string) string {
-	return `(?P<before>^|[^\w\*])` + re + `(?P<after>[^\w\*]|$)`
-}
-
-func wordUnd(re string) string {
-	return `(?P<before>^|[^\w_])` + re + `(?P<after>[^\w_]|$)`
-}
-
 // Format formats markdown text according the Formatter's rules.
 //
 //nolint:gocognit
@@ -192,97 +173,15 @@ func (format *Formatter) formatRegularLine(line string, loc Location) string {
 		loc.Header = false
 	}
 
-	// Markdown link formatter:
-	if format.Link != nil {
-		// Replace links first, the regex gets messed up by ANSI sequences
-		line = mdLinkRegex.ReplaceAllStringFunc(line,
-			func(s string) string {
-				match := mdLinkRegex.FindStringSubmatch(s)
-				return format.Link(match[1], match[2], loc)
-			})
-	}
-	if format.Header != nil && loc.Header {
-		line = format.Header(strings.TrimSpace(strings.TrimLeft(line, "#")), loc)
-	}
-
-	// Markdown inline code formatter:
-	codeBlockMatches := mdCodeRegex.FindAllStringIndex(line, -1)
-	endPrevious := 0
-	linePieces := []string{}
-	for _, match := range codeBlockMatches {
-		// Add text before code block
-		// Get string from end of last code block to start of this one
-		before := line[endPrevious:match[0]]
-		linePieces = append(linePieces, format.textFormat(before, loc))
-
-		// Add the code block
-		codeBlock := line[match[0]:match[1]]
-		if format.Code != nil {
-			// Add formatted code block
-			codeBlockWithoutBackticks := codeBlock[1 : len(codeBlock)-1]
-			linePieces = append(linePieces, format.Code(codeBlockWithoutBackticks, loc))
-		} else {
-			// Add unformatted code block
-			linePieces = append(linePieces, codeBlock)
+	if loc.Header {
+		line = format.formatInline(strings.TrimSpace(strings.TrimLeft(line, "#")), loc)
+		if format.Header != nil {
+			return format.Header(line, loc)
 		}
-
-		// Update end index
-		endPrevious = match[1]
-	}
-
-	// Add formatted remainder (which may be entire line if no code blocks found)
-	linePieces = append(linePieces, format.textFormat(line[endPrevious:], loc))
-
-	// Rejoin line
-	line = strings.Join(linePieces, "")
-
-	return line
-}
-
-func (format *Formatter) textFormat(text string, loc Location) string {
-	// Markdown bold formatter:
-	if format.Bold != nil {
-		// Replace both underline and asterisk notation
-		text = mdBoldUndRegex.ReplaceAllStringFunc(text,
-			func(s string) string {
-				match := mdBoldUndRegex.FindStringSubmatch(s)
-				before := match[1]
-				inner := format.Bold(match[2], loc)
-				after := match[3]
-				return before + inner + after
-			})
-		text = mdBoldAstRegex.ReplaceAllStringFunc(text,
-			func(s string) string {
-				match := mdBoldAstRegex.FindStringSubmatch(s)
-				before := match[1]
-				inner := format.Bold(match[2], loc)
-				after := match[3]
-				return before + inner + after
-			})
-	}
-
-	// Markdown italic formatter:
-	if format.Italics != nil {
-		// Replace both underline and asterisk notation
-		text = mdItalicUndRegex.ReplaceAllStringFunc(text,
-			func(s string) string {
-				match := mdItalicUndRegex.FindStringSubmatch(s)
-				before := match[1]
-				inner := format.Italics(match[2], loc)
-				after := match[3]
-				return before + inner + after
-			})
-		text = mdItalicAstRegex.ReplaceAllStringFunc(text,
-			func(s string) string {
-				match := mdItalicAstRegex.FindStringSubmatch(s)
-				before := match[1]
-				inner := format.Italics(match[2], loc)
-				after := match[3]
-				return before + inner + after
-			})
+		return strings.Repeat("#", loc.Level) + " " + line
 	}
 
-	return text
+	return format.formatInline(line, loc)
 }
 
 func headerLevel(s string) int {
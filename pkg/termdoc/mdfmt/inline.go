@@ -0,0 +1,313 @@
+package mdfmt
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// inlineNode is a piece of a line being formatted.
+//
+// Text nodes hold formatted output. Delimiter nodes hold a run of "*" or "_"
+// characters that may open or close emphasis.
+type inlineNode struct {
+	text     string // formatted text, for text nodes
+	delim    byte   // delimiter character, for delimiter nodes
+	count    int    // number of delimiters remaining in the run
+	length   int    // original length of the run
+	canOpen  bool   // run can open emphasis
+	canClose bool   // run can close emphasis
+}
+
+// formatInline formats the inline markdown of text: backslash escapes,
+// code spans, links, and emphasis, following the CommonMark rules.
+func (format *Formatter) formatInline(text string, loc Location) string {
+	return renderNodes(format.processEmphasis(format.tokenize(text, loc), loc))
+}
+
+// tokenize splits text into text and delimiter nodes.
+// Escapes, code spans, and links are formatted as they are found.
+func (format *Formatter) tokenize(s string, loc Location) []inlineNode {
+	var nodes []inlineNode
+	buf := &strings.Builder{}
+	flush := func() {
+		if buf.Len() > 0 {
+			nodes = append(nodes, inlineNode{text: buf.String()})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		// Backslash escape
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+		// Code span
+		case c == '`':
+			n := runLength(s, i)
+			end := closingBackticks(s, i+n, n)
+			if end < 0 {
+				// No closing run, backticks are literal
+				buf.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			flush()
+			nodes = append(nodes, inlineNode{text: format.code(s[i+n:end], s[i:end+n], loc)})
+			i = end + n
+		// Link
+		case c == '[':
+			text, url, end, ok := parseLink(s, i)
+			if !ok {
+				buf.WriteByte(c)
+				i++
+				continue
+			}
+			flush()
+			nodes = append(nodes, inlineNode{text: format.link(format.formatInline(text, loc), url, loc)})
+			i = end
+		// Emphasis delimiter run
+		case c == '*' || c == '_':
+			n := runLength(s, i)
+			before, _ := utf8.DecodeLastRuneInString(s[:i])
+			if i == 0 {
+				before = ' '
+			}
+			after, _ := utf8.DecodeRuneInString(s[i+n:])
+			if i+n == len(s) {
+				after = ' '
+			}
+			left, right := flanking(before, after)
+			node := inlineNode{delim: c, count: n, length: n}
+			if c == '*' {
+				node.canOpen = left
+				node.canClose = right
+			} else {
+				// Underscores cannot open or close intraword emphasis
+				node.canOpen = left && (!right || isPunct(before))
+				node.canClose = right && (!left || isPunct(after))
+			}
+			flush()
+			nodes = append(nodes, node)
+			i += n
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+	return nodes
+}
+
+// processEmphasis matches opening and closing delimiter runs, replacing them
+// with formatted bold and italic text.
+//
+// This follows the "process emphasis" procedure of the CommonMark spec.
+func (format *Formatter) processEmphasis(nodes []inlineNode, loc Location) []inlineNode {
+	for c := 0; c < len(nodes); c++ {
+		closer := nodes[c]
+		if closer.delim == 0 || !closer.canClose {
+			continue
+		}
+
+		// Look back for the nearest matching opener
+		o := -1
+		for i := c - 1; i >= 0; i-- {
+			opener := nodes[i]
+			if opener.delim != closer.delim || !opener.canOpen {
+				continue
+			}
+			// Rule of 3: runs that can both open and close are not matched
+			// if their lengths sum to a multiple of 3, unless both are multiples of 3
+			if (opener.canClose || closer.canOpen) &&
+				(opener.length+closer.length)%3 == 0 &&
+				(opener.length%3 != 0 || closer.length%3 != 0) {
+				continue
+			}
+			o = i
+			break
+		}
+		if o < 0 {
+			continue
+		}
+
+		use := 1
+		if nodes[o].count >= 2 && closer.count >= 2 {
+			use = 2
+		}
+		emphasized := format.emphasis(renderNodes(nodes[o+1:c]), closer.delim, use, loc)
+
+		// Replace the nodes between the delimiters with the formatted text
+		nodes = slices.Concat(nodes[:o+1], []inlineNode{{text: emphasized}}, nodes[c:])
+		c = o + 2
+		nodes[o].count -= use
+		nodes[c].count -= use
+		if nodes[c].count == 0 {
+			nodes = slices.Delete(nodes, c, c+1)
+		}
+		if nodes[o].count == 0 {
+			nodes = slices.Delete(nodes, o, o+1)
+			c--
+		}
+		// Process the remainder of the closer, or the next node
+		c--
+	}
+	return nodes
+}
+
+// renderNodes joins the nodes, with unmatched delimiters as literal text.
+func renderNodes(nodes []inlineNode) string {
+	out := &strings.Builder{}
+	for _, n := range nodes {
+		if n.delim != 0 {
+			out.WriteString(strings.Repeat(string(n.delim), n.count))
+			continue
+		}
+		out.WriteString(n.text)
+	}
+	return out.String()
+}
+
+// code formats the contents of a code span, or returns the raw span if code formatting is disabled.
+func (format *Formatter) code(content, raw string, loc Location) string {
+	if format.Code == nil {
+		return raw
+	}
+	// Strip one space from each side of content padded by spaces
+	if len(content) >= 2 && content[0] == ' ' && content[len(content)-1] == ' ' &&
+		strings.Trim(content, " ") != "" {
+		content = content[1 : len(content)-1]
+	}
+	return format.Code(content, loc)
+}
+
+// link formats a link, or reassembles it if link formatting is disabled.
+func (format *Formatter) link(text, url string, loc Location) string {
+	if format.Link == nil {
+		return "[" + text + "](" + url + ")"
+	}
+	return format.Link(text, url, loc)
+}
+
+// emphasis formats bold (n == 2) or italic (n == 1) text,
+// or restores the delimiters if that formatting is disabled.
+func (format *Formatter) emphasis(text string, delim byte, n int, loc Location) string {
+	switch {
+	case n == 2 && format.Bold != nil:
+		return format.Bold(text, loc)
+	case n == 1 && format.Italics != nil:
+		return format.Italics(text, loc)
+	default:
+		d := strings.Repeat(string(delim), n)
+		return d + text + d
+	}
+}
+
+// parseLink parses an inline link of the form [text](url) starting at s[start].
+// It returns the link text, the destination, and the index after the link.
+func parseLink(s string, start int) (text, url string, end int, ok bool) {
+	// Find the matching closing bracket
+	depth := 0
+	closeBracket := -1
+	for i := start; i < len(s) && closeBracket < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++ // skip escaped character
+		case '`':
+			// Brackets in code spans do not count
+			n := runLength(s, i)
+			if e := closingBackticks(s, i+n, n); e >= 0 {
+				i = e + n - 1
+			} else {
+				i += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeBracket = i
+			}
+		}
+	}
+	if closeBracket < 0 || closeBracket+1 >= len(s) || s[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+
+	// Destinations in angle brackets may contain unbalanced parentheses
+	if rest := s[closeBracket+2:]; strings.HasPrefix(rest, "<") {
+		dest, after, found := strings.Cut(rest[1:], ">")
+		if !found || !strings.HasPrefix(strings.TrimLeft(after, " "), ")") {
+			return "", "", 0, false
+		}
+		end = len(s) - len(strings.TrimLeft(after, " ")) + 1
+		return s[start+1 : closeBracket], dest, end, true
+	}
+
+	// Find the matching closing parenthesis
+	depth = 0
+	for i := closeBracket + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				dest := strings.TrimSpace(s[closeBracket+2 : i])
+				dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+				return s[start+1 : closeBracket], dest, i + 1, dest != ""
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// runLength returns the number of consecutive s[i] characters starting at i.
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// closingBackticks returns the index of the next run of exactly n backticks at or after from, or -1.
+func closingBackticks(s string, from, n int) int {
+	for i := from; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		m := runLength(s, i)
+		if m == n {
+			return i
+		}
+		i += m
+	}
+	return -1
+}
+
+// flanking reports whether a delimiter run between the before and after
+// characters is left-flanking and right-flanking.
+func flanking(before, after rune) (left, right bool) {
+	left = !unicode.IsSpace(after) &&
+		(!isPunct(after) || unicode.IsSpace(before) || isPunct(before))
+	right = !unicode.IsSpace(before) &&
+		(!isPunct(before) || unicode.IsSpace(after) || isPunct(after))
+	return left, right
+}
+
+// isPunct reports whether r is a Unicode punctuation or symbol character.
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// isASCIIPunct reports whether c is an ASCII punctuation character, which may be backslash-escaped.
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,60 @@
+package mdfmt
+
+import (
+	"encoding/json"
+	"html"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// htmlFormatter renders inline markdown as HTML, for comparison with the CommonMark spec.
+var htmlFormatter = &Formatter{
+	Code: func(code string, _ Location) string {
+		return "<code>" + html.EscapeString(code) + "</code>"
+	},
+	Link: func(text, url string, _ Location) string {
+		return `<a href="` + url + `">` + text + "</a>"
+	},
+	Bold: func(text string, _ Location) string {
+		return "<strong>" + text + "</strong>"
+	},
+	Italics: func(text string, _ Location) string {
+		return "<em>" + text + "</em>"
+	},
+}
+
+// TestFormat_commonMarkInline checks inline formatting against examples
+// adapted from the CommonMark spec (version 0.31.2).
+//
+// The expected HTML omits the enclosing paragraph and HTML escaping of text.
+func TestFormat_commonMarkInline(t *testing.T) {
+	data, err := os.ReadFile("testdata/commonmark_inline.json")
+	require.NoError(t, err)
+	var cases []struct {
+		Section  string `json:"section"`
+		Markdown string `json:"markdown"`
+		HTML     string `json:"html"`
+	}
+	require.NoError(t, json.Unmarshal(data, &cases))
+
+	for _, c := range cases {
+		t.Run(c.Section+"/"+c.Markdown, func(t *testing.T) {
+			assert.Equal(t, c.HTML, htmlFormatter.Format(c.Markdown))
+		})
+	}
+}
+
+func TestFormat_disabledFormatting(t *testing.T) {
+	// Markup is preserved when no formatter is set for it
+	f := &Formatter{}
+	for _, line := range []string{
+		"`code` and ``more `code` ``",
+		"**bold** and _italic_",
+		"[text](https://example.com)",
+	} {
+		assert.Equal(t, line, f.Format(line))
+	}
+}
@@ -2,7 +2,9 @@ package termdoc
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/muesli/termenv"
@@ -12,7 +14,11 @@ import (
 )
 
 // TerminalWidth returns the width of the terminal, using fallback if it can't determine width.
+// The COLUMNS environment variable overrides the width if set to a positive integer.
 func TerminalWidth(fallback int) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
 	w := termenv.DefaultOutput().Writer()
 	if w == nil {
 		return fallback
@@ -28,15 +34,23 @@ func TerminalWidth(fallback int) int {
 	return width
 }
 
-// noColor reports whether color output is enabled.
-func noColor() bool {
+// NoColor reports whether color output is disabled, either because output
+// is not a terminal or because the NO_COLOR environment variable is set.
+func NoColor() bool {
 	return termenv.DefaultOutput().Profile == termenv.Ascii ||
 		termenv.EnvNoColor()
 }
 
+// NoColorWriter reports whether color output to w is disabled, either because
+// w is not a terminal or because the NO_COLOR environment variable is set.
+func NoColorWriter(w io.Writer) bool {
+	return termenv.NewOutput(w).Profile == termenv.Ascii ||
+		termenv.EnvNoColor()
+}
+
 // Header renders a header as a Markdown h3 if color output is disabled.
 func Header(s string) string {
-	if noColor() {
+	if NoColor() {
 		// Return Markdown-formatted
 		return "### " + strings.TrimSuffix(s, ":") + "\n"
 	}
@@ -45,7 +59,7 @@ func Header(s string) string {
 
 // Code renders an inline Code block as Markdown if color output is disabled.
 func Code(s string) string {
-	if noColor() {
+	if NoColor() {
 		// Return Markdown-formatted
 		return md.Code(s)
 	}
@@ -54,7 +68,7 @@ func Code(s string) string {
 
 // CodeBlock renders a code block as Markdown if color output is disabled.
 func CodeBlock(language, s string) string {
-	if noColor() {
+	if NoColor() {
 		// Return Markdown-formatted
 		return "\n" + md.CodeBlock(language, strings.TrimSuffix(s, "\n"))
 	}
@@ -63,7 +77,7 @@ func CodeBlock(language, s string) string {
 
 // Footer renders a footer as Markdown if color output is disabled.
 func Footer(s string) string {
-	if noColor() {
+	if NoColor() {
 		// Return Markdown-formatted
 		return md.BlockQuote(strings.TrimSpace(s))
 	}
@@ -72,7 +86,7 @@ func Footer(s string) string {
 
 // UList renders an unordered list as Markdown if color output is disabled.
 func UList(defaultPrefix string, items ...string) string {
-	if noColor() {
+	if NoColor() {
 		// Return Markdown-formatted with starting newline
 		return "\n" + md.UList(items...)
 	}
@@ -87,7 +101,7 @@ func UList(defaultPrefix string, items ...string) string {
 
 // OList renders an ordered list as Markdown if color output is disabled.
 func OList(items ...string) string {
-	if noColor() {
+	if NoColor() {
 		// Return Markdown-formatted with starting newline
 		return "\n" + md.OList(items...)
 	}
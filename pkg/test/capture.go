@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// LogRecord is a log record captured by a [LogCapture].
+type LogRecord struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+
+	// Attrs are the record's attributes, including those added with
+	// [slog.Logger.With]. Attributes in groups are flattened to keys joined by ".",
+	// e.g. "http.status".
+	Attrs []slog.Attr
+}
+
+// Attr returns the value of the attribute with the given key.
+func (r LogRecord) Attr(key string) (slog.Value, bool) {
+	for _, a := range r.Attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// LogCapture is a [slog.Handler] that captures log records for assertions.
+type LogCapture struct {
+	level   slog.Leveler
+	attrs   []slog.Attr
+	group   string
+	mu      *sync.Mutex
+	records *[]LogRecord
+}
+
+// NewLogCapture creates a handler that captures records at or above level.
+// A nil level captures all records.
+//
+// Example:
+//
+//	capture := test.NewLogCapture(nil)
+//	ctx := logger.NewContext(context.Background(), capture.Logger())
+//	...
+//	capture.AssertLogged(t, slog.LevelInfo, "request", slog.Int("status", 200))
+func NewLogCapture(level slog.Leveler) *LogCapture {
+	if level == nil {
+		level = slog.Level(-100)
+	}
+	return &LogCapture{level: level, mu: &sync.Mutex{}, records: &[]LogRecord{}}
+}
+
+// Logger returns a logger that writes to the capture.
+func (c *LogCapture) Logger() *slog.Logger {
+	return slog.New(c)
+}
+
+// Records returns the captured records.
+func (c *LogCapture) Records() []LogRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(*c.records)
+}
+
+// Reset discards the captured records.
+func (c *LogCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.records = nil
+}
+
+// Enabled implements [slog.Handler].
+func (c *LogCapture) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= c.level.Level()
+}
+
+// Handle implements [slog.Handler].
+func (c *LogCapture) Handle(_ context.Context, r slog.Record) error {
+	rec := LogRecord{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   slices.Clone(c.attrs),
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		rec.Attrs = appendFlattened(rec.Attrs, c.group, a)
+		return true
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.records = append(*c.records, rec)
+	return nil
+}
+
+// WithAttrs implements [slog.Handler].
+func (c *LogCapture) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c2 := *c
+	c2.attrs = slices.Clone(c.attrs)
+	for _, a := range attrs {
+		c2.attrs = appendFlattened(c2.attrs, c.group, a)
+	}
+	return &c2
+}
+
+// WithGroup implements [slog.Handler].
+func (c *LogCapture) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return c
+	}
+	c2 := *c
+	c2.group = joinKey(c.group, name)
+	return &c2
+}
+
+// appendFlattened appends the attribute to attrs, flattening groups.
+func appendFlattened(attrs []slog.Attr, prefix string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		if a.Key == "" {
+			return attrs
+		}
+		return append(attrs, slog.Attr{Key: joinKey(prefix, a.Key), Value: a.Value})
+	}
+	for _, ga := range a.Value.Group() {
+		attrs = appendFlattened(attrs, joinKey(prefix, a.Key), ga)
+	}
+	return attrs
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	if key == "" {
+		return prefix
+	}
+	return prefix + "." + key
+}
+
+// Logged returns the captured records at level with messages containing
+// msgSubstring and all of the attributes.
+func (c *LogCapture) Logged(level slog.Level, msgSubstring string, attrs ...slog.Attr) []LogRecord {
+	var matches []LogRecord
+	for _, r := range c.Records() {
+		if r.Level == level && strings.Contains(r.Message, msgSubstring) && hasAttrs(r, attrs) {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+// hasAttrs reports whether the record has all of the attributes.
+func hasAttrs(r LogRecord, attrs []slog.Attr) bool {
+	for _, want := range attrs {
+		got, ok := r.Attr(want.Key)
+		if !ok || !got.Equal(want.Value.Resolve()) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertLogged asserts that a record was captured at level with a message
+// containing msgSubstring and all of the attributes.
+func (c *LogCapture) AssertLogged(t testing.TB, level slog.Level, msgSubstring string, attrs ...slog.Attr) bool {
+	t.Helper()
+	if len(c.Logged(level, msgSubstring, attrs...)) > 0 {
+		return true
+	}
+	t.Errorf("no %s record with message containing %q and attributes %v\ncaptured records:\n%s",
+		level, msgSubstring, attrs, c.String())
+	return false
+}
+
+// AssertNotLogged asserts that no record was captured at level with a
+// message containing msgSubstring and all of the attributes.
+func (c *LogCapture) AssertNotLogged(t testing.TB, level slog.Level, msgSubstring string, attrs ...slog.Attr) bool {
+	t.Helper()
+	if len(c.Logged(level, msgSubstring, attrs...)) == 0 {
+		return true
+	}
+	t.Errorf("unexpected %s record with message containing %q and attributes %v\ncaptured records:\n%s",
+		level, msgSubstring, attrs, c.String())
+	return false
+}
+
+// String formats the captured records, one per line.
+func (c *LogCapture) String() string {
+	b := &strings.Builder{}
+	for _, r := range c.Records() {
+		fmt.Fprintf(b, "%s %q", r.Level, r.Message)
+		for _, a := range r.Attrs {
+			fmt.Fprintf(b, " %s", a)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+// Package clitest provides a harness for testing cobra commands.
+//
+// Example:
+//
+//	for _, tc := range []clitest.Case{
+//		{Name: "version", Args: []string{"version"}},
+//		{Name: "bad flag", Args: []string{"--bogus"}, WantExitCode: 1},
+//	} {
+//		t.Run(tc.Name, func(t *testing.T) {
+//			res := clitest.Run(t, newRootCmd(), tc)
+//			assert.Equal(t, tc.WantExitCode, res.ExitCode)
+//		})
+//	}
+package clitest
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/act3-ai/go-common/pkg/otel"
+)
+
+// DefaultWidth is the terminal width used when a case does not set one.
+const DefaultWidth = 80
+
+// Case describes an execution of a command.
+type Case struct {
+	// Name is the name of the case, for use with t.Run.
+	Name string
+
+	// Args are the command line arguments, excluding the program name.
+	Args []string
+
+	// Env are environment variables to set while the command runs.
+	Env map[string]string
+
+	// Stdin is the command's input.
+	Stdin string
+
+	// Width is the terminal width reported to the command, set with the
+	// COLUMNS environment variable. Defaults to [DefaultWidth].
+	Width int
+
+	// WantExitCode is the expected exit code, for use by table-driven tests.
+	WantExitCode int
+}
+
+// Result is the outcome of executing a command.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+
+	// Err is the error returned by the command.
+	Err error
+
+	// Executed is the command that was executed, e.g. a subcommand of the root.
+	Executed *cobra.Command
+}
+
+// Run executes cmd as described by c, capturing its output and exit code.
+// The exit code is determined with [otel.ExitCode].
+// Environment variables are set with t.Setenv, so Run cannot be used in parallel tests.
+func Run(t *testing.T, cmd *cobra.Command, c Case) Result {
+	t.Helper()
+	setEnv(t, c)
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetArgs(c.Args)
+	cmd.SetIn(strings.NewReader(c.Stdin))
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	executed, err := cmd.ExecuteContextC(t.Context())
+	return Result{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: otel.ExitCode(err),
+		Err:      err,
+		Executed: executed,
+	}
+}
+
+// Help renders the help of the subcommand of cmd found by args, as shown by
+// the --help flag, using the terminal width of c.
+func Help(t *testing.T, cmd *cobra.Command, c Case) string {
+	t.Helper()
+	setEnv(t, c)
+
+	target, _, err := cmd.Find(c.Args)
+	if err != nil {
+		t.Fatalf("finding command %v: %v", c.Args, err)
+	}
+	out := &bytes.Buffer{}
+	target.SetOut(out)
+	target.SetErr(out)
+	if err := target.Help(); err != nil {
+		t.Fatalf("rendering help: %v", err)
+	}
+	return out.String()
+}
+
+// setEnv sets the environment variables and terminal width of the case.
+func setEnv(t *testing.T, c Case) {
+	t.Helper()
+	width := c.Width
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	t.Setenv("COLUMNS", strconv.Itoa(width))
+	for k, v := range c.Env {
+		t.Setenv(k, v)
+	}
+}
@@ -0,0 +1,103 @@
+// Package golden provides golden file test helpers.
+//
+// Golden files store the expected output of a test. Run the tests with the
+// -update flag to write the current output to the golden files:
+//
+//	go test ./... -update
+package golden
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/act3-ai/go-common/pkg/fsutil"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// Normalizer rewrites output before it is compared to or written to a golden file,
+// to remove content that changes between runs.
+type Normalizer func([]byte) []byte
+
+// StripANSI removes ANSI escape sequences.
+func StripANSI(b []byte) []byte {
+	return []byte(ansi.Strip(string(b)))
+}
+
+// timestampRegexp matches RFC 3339 timestamps, with optional fractional seconds and time zone.
+var timestampRegexp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+
+// StripTimestamps replaces RFC 3339 timestamps with "<timestamp>".
+func StripTimestamps(b []byte) []byte {
+	return timestampRegexp.ReplaceAll(b, []byte("<timestamp>"))
+}
+
+// Assert checks that got matches the contents of the golden file at
+// goldenPath, after applying the normalizers to got. With the -update flag,
+// the golden file is written instead.
+func Assert(t testing.TB, got []byte, goldenPath string, normalizers ...Normalizer) {
+	t.Helper()
+	for _, normalize := range normalizers {
+		got = normalize(got)
+	}
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Fatalf("creating golden file directory: %v", err)
+		}
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		t.Fatalf("golden file %s does not exist, run the test with -update to create it", goldenPath)
+	case err != nil:
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("output does not match golden file, run the test with -update to update it\n%s", diff(goldenPath, want, got))
+	}
+}
+
+// diff renders the first differing line of want and got.
+func diff(goldenPath string, want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	line := 0
+	for line < len(wantLines) && line < len(gotLines) && wantLines[line] == gotLines[line] {
+		line++
+	}
+
+	entry := fsutil.DiffEntry{
+		Path: goldenPath,
+		Kind: fsutil.DiffModified,
+		Changes: []string{
+			fmt.Sprintf("size: %d -> %d", len(want), len(got)),
+			fmt.Sprintf("content: differs at line %d", line+1),
+		},
+	}
+	b := &strings.Builder{}
+	b.WriteString(fsutil.FormatDiff("golden", "got", []fsutil.DiffEntry{entry}))
+	if line < len(wantLines) {
+		fmt.Fprintf(b, "-%s\n", wantLines[line])
+	}
+	if line < len(gotLines) {
+		fmt.Fprintf(b, "+%s\n", gotLines[line])
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package version
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// DigestFS computes a content digest of the files in fsys, such as an [embed.FS],
+// in the form "sha256:<hex>".
+//
+// The digest covers the path and contents of every regular file, so it only depends
+// on the embedded content and can be recorded at build time to identify a release.
+func DigestFS(fsys fs.FS) (string, error) {
+	h := sha256.New()
+	// WalkDir visits files in lexical order, so the digest is deterministic
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		defer f.Close()
+
+		// Prefix the contents with the path and size so file boundaries are unambiguous
+		_, _ = fmt.Fprintf(h, "%s\x00%d\x00", name, info.Size())
+		if _, err := io.Copy(h, f); err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("computing content digest: %w", err)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
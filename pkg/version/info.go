@@ -8,16 +8,43 @@ import (
 // Info is the struct to hold the version metadata of this tool
 type Info struct {
 	// Version is the semantic version
-	Version string
+	Version string `json:"version"`
 
 	// Commit is the Git commit digest
-	Commit string
+	Commit string `json:"commit,omitempty"`
 
 	// Dirty is true if the build was dirty (not matching the commit)
-	Dirty bool
+	Dirty bool `json:"dirty,omitempty"`
 
 	// Built is the datetime of the last commit
-	Built string
+	Built string `json:"built,omitempty"`
+
+	// GoVersion is the version of Go used to build the binary
+	GoVersion string `json:"goVersion,omitempty"`
+
+	// Path is the package path of the main package
+	Path string `json:"path,omitempty"`
+
+	// Platform is the target operating system and architecture, such as "linux/amd64"
+	Platform string `json:"platform,omitempty"`
+
+	// Deps are the modules the binary was built with
+	Deps []Module `json:"deps,omitempty"`
+}
+
+// Module describes a module dependency
+type Module struct {
+	// Path is the module path
+	Path string `json:"path"`
+
+	// Version is the module version
+	Version string `json:"version"`
+
+	// Sum is the module checksum
+	Sum string `json:"sum,omitempty"`
+
+	// Replace is the module this module was replaced with, if any
+	Replace *Module `json:"replace,omitempty"`
 }
 
 // Get returns the version info
@@ -47,7 +74,10 @@ func parse() Info {
 		For a given commit with multiple tags, which tag should be used as the version.
 	*/
 	v.Version = info.Main.Version
+	v.GoVersion = info.GoVersion
+	v.Path = info.Path
 
+	var goos, goarch string
 	for _, kv := range info.Settings {
 		switch kv.Key {
 		case "vcs.revision":
@@ -56,8 +86,33 @@ func parse() Info {
 			v.Built = kv.Value
 		case "vcs.modified":
 			v.Dirty = kv.Value == "true"
+		case "GOOS":
+			goos = kv.Value
+		case "GOARCH":
+			goarch = kv.Value
 		}
 	}
+	if goos != "" && goarch != "" {
+		v.Platform = goos + "/" + goarch
+	}
+
+	for _, dep := range info.Deps {
+		v.Deps = append(v.Deps, newModule(dep))
+	}
 
 	return v
 }
+
+// newModule converts the build info module
+func newModule(m *debug.Module) Module {
+	mod := Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+	if m.Replace != nil {
+		replace := newModule(m.Replace)
+		mod.Replace = &replace
+	}
+	return mod
+}